@@ -3,116 +3,182 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/profile"
 )
 
 var autoCmd = &cobra.Command{
-	Use:    "auto",
-	Hidden: true,
-	Short:  "Auto-select profile based on project config",
-	Long: `Detect and display the profile for the current project.
+	Use:   "auto",
+	Short: "Auto-select profile based on project config",
+	Long: `Detect and display the profile for the current directory.
 
 Looks for .ccp.yaml in the current directory or parent directories.
+Rules are matched against the current directory relative to the file;
+the first match wins, otherwise the default profile applies. Missing
+profiles fall back to the fallbacks list in order.
 
 Example .ccp.yaml:
   profile: dev
-
-Use with shell integration:
-  export CLAUDE_CONFIG_DIR=$(ccp auto --path)`,
+  rules:
+    - match: "services/*"
+      profile: backend
+    - match: "**/frontend"
+      profile: web
+  fallbacks: [default]
+
+For automatic activation on directory change, add the shell hook:
+  eval "$(ccp config shell)"          # bash/zsh
+  ccp config shell --shell fish | source
+
+--hook <shell> prints the commands the hook evaluates: it exports
+CLAUDE_CONFIG_DIR when entering a configured tree and restores the
+previous value when leaving it.`,
 	RunE: runAuto,
 }
 
 var (
 	autoPath bool
+	autoHook string
+)
+
+// Environment variables used by the auto-activation hook to remember what it
+// changed, so leaving a configured tree restores the previous state
+const (
+	autoProfileEnv = "CCP_AUTO_PROFILE"
+	autoSavedEnv   = "CCP_AUTO_SAVED"
+	autoUnsetValue = "__unset__"
 )
 
 func init() {
 	autoCmd.Flags().BoolVar(&autoPath, "path", false, "Output profile path instead of name")
+	autoCmd.Flags().StringVar(&autoHook, "hook", "", "Print shell commands to activate/restore the profile (bash, zsh, fish)")
 	rootCmd.AddCommand(autoCmd)
 }
 
-type projectConfig struct {
-	Profile string `yaml:"profile"`
-}
-
 func runAuto(cmd *cobra.Command, args []string) error {
 	paths, err := config.ResolvePaths()
 	if err != nil {
 		return err
 	}
 
-	if !paths.IsInitialized() {
-		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	if autoHook != "" {
+		if !isSupportedShell(autoHook) {
+			return fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish)", autoHook)
+		}
+		// The hook runs on every directory change: report problems on stderr
+		// and restore the previous environment rather than failing
+		profileName := ""
+		if paths.IsInitialized() {
+			res, err := resolveAutoProfile(paths)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "ccp: %v\n", err)
+			} else {
+				profileName = res.Profile
+			}
+		}
+		fmt.Print(autoHookScript(autoHook, profileName, paths))
+		return nil
 	}
 
-	// Search for .ccp.yaml in current dir and parents
-	configPath, err := findProjectConfig()
-	if err != nil {
-		return fmt.Errorf("no .ccp.yaml found in current directory or parents")
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
 	}
 
-	// Parse config
-	data, err := os.ReadFile(configPath)
+	res, err := resolveAutoProfile(paths)
 	if err != nil {
-		return fmt.Errorf("failed to read %s: %w", configPath, err)
-	}
-
-	var cfg projectConfig
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return fmt.Errorf("failed to parse %s: %w", configPath, err)
-	}
-
-	if cfg.Profile == "" {
-		return fmt.Errorf("no profile specified in %s", configPath)
+		return err
 	}
-
-	// Verify profile exists
-	mgr := profile.NewManager(paths)
-	if !mgr.Exists(cfg.Profile) {
-		return fmt.Errorf("profile not found: %s (from %s)", cfg.Profile, configPath)
+	if res.Profile == "" {
+		if cwd, _ := os.Getwd(); config.FindProjectConfig(cwd) == "" {
+			return fmt.Errorf("no .ccp.yaml found in current directory or parents")
+		}
+		return fmt.Errorf("no profile specified for this directory in .ccp.yaml")
 	}
 
 	if autoPath {
-		fmt.Println(paths.ProfileDir(cfg.Profile))
+		fmt.Println(paths.ProfileDir(res.Profile))
 	} else {
-		fmt.Println(cfg.Profile)
+		fmt.Println(res.Profile)
 	}
 
 	return nil
 }
 
-func findProjectConfig() (string, error) {
-	dir, err := os.Getwd()
+// resolveAutoProfile resolves the .ccp.yaml profile for the working directory
+func resolveAutoProfile(paths *config.Paths) (*config.ProjectResolution, error) {
+	cwd, err := os.Getwd()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	mgr := profile.NewManager(paths)
+	return config.ResolveProjectProfile(cwd, mgr.Exists)
+}
 
-	for {
-		configPath := filepath.Join(dir, ".ccp.yaml")
-		if _, err := os.Stat(configPath); err == nil {
-			return configPath, nil
+// autoHookScript returns the shell commands that move the environment to
+// profileName ("" = no profile applies). Only the transitions are emitted:
+// nothing when the profile is already active.
+func autoHookScript(shell, profileName string, paths *config.Paths) string {
+	active := os.Getenv(autoProfileEnv)
+	if profileName == active {
+		return ""
+	}
+
+	var out string
+	if profileName == "" {
+		// Leaving a configured tree: restore what was there before
+		if saved := os.Getenv(autoSavedEnv); saved == autoUnsetValue || saved == "" {
+			out += shellUnset(shell, "CLAUDE_CONFIG_DIR")
+		} else {
+			out += shellExport(shell, "CLAUDE_CONFIG_DIR", saved)
 		}
+		out += shellUnset(shell, autoProfileEnv)
+		out += shellUnset(shell, autoSavedEnv)
+		return out
+	}
 
-		// Also check .ccp.yml
-		configPath = filepath.Join(dir, ".ccp.yml")
-		if _, err := os.Stat(configPath); err == nil {
-			return configPath, nil
+	if active == "" {
+		// Entering a configured tree: remember the current value first
+		saved, ok := os.LookupEnv("CLAUDE_CONFIG_DIR")
+		if !ok || saved == "" {
+			saved = autoUnsetValue
 		}
+		out += shellExport(shell, autoSavedEnv, saved)
+	}
+	out += shellExport(shell, "CLAUDE_CONFIG_DIR", paths.ProfileDir(profileName))
+	out += shellExport(shell, autoProfileEnv, profileName)
+	return out
+}
+
+func shellExport(shell, name, value string) string {
+	if shell == "fish" {
+		return fmt.Sprintf("set -gx %s %s;\n", name, fishQuote(value))
+	}
+	return fmt.Sprintf("export %s='%s';\n", name, strings.ReplaceAll(value, "'", `'\''`))
+}
 
-		// Move to parent
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			// Reached root
-			break
+func shellUnset(shell, name string) string {
+	if shell == "fish" {
+		return fmt.Sprintf("set -e %s;\n", name)
+	}
+	return fmt.Sprintf("unset %s;\n", name)
+}
+
+// fishQuote single-quotes a value for fish, escaping \ and '
+func fishQuote(s string) string {
+	out := "'"
+	for _, r := range s {
+		if r == '\\' || r == '\'' {
+			out += "\\"
 		}
-		dir = parent
+		out += string(r)
 	}
+	return out + "'"
+}
 
-	return "", fmt.Errorf("not found")
+func isSupportedShell(shell string) bool {
+	return shell == "bash" || shell == "zsh" || shell == "fish"
 }
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -16,6 +18,7 @@ import (
 
 var (
 	bundleCreateDesc     string
+	bundleCreateSettings string
 	bundleCreateSkills   []string
 	bundleCreateAgents   []string
 	bundleCreateHooks    []string
@@ -41,7 +44,13 @@ bundle (the original standalone hub items are left untouched). Link it as a unit
   ccp bundle create design --desc "Design review bundle"
   ccp link <profile> bundles/design
 
-Members live inside the bundle and cannot be linked individually.`,
+Members live inside the bundle and cannot be linked individually.
+
+--settings attaches a settings.json fragment (permissions, env, model, ...)
+that is merged into the settings of every profile linking the bundle, after
+the settings template and before the profile's own fragment:
+
+  ccp bundle create deploy --skill deploy --settings ./deploy-settings.json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runBundleCreate,
 }
@@ -49,6 +58,7 @@ Members live inside the bundle and cannot be linked individually.`,
 func init() {
 	f := bundleCreateCmd.Flags()
 	f.StringVar(&bundleCreateDesc, "desc", "", "Bundle description")
+	f.StringVar(&bundleCreateSettings, "settings", "", "settings.json fragment to merge into linking profiles")
 	f.StringArrayVar(&bundleCreateSkills, "skill", nil, "Skill to include (repeatable)")
 	f.StringArrayVar(&bundleCreateAgents, "agent", nil, "Agent to include (repeatable)")
 	f.StringArrayVar(&bundleCreateHooks, "hook", nil, "Hook to include (repeatable)")
@@ -96,6 +106,12 @@ func runBundleCreate(cmd *cobra.Command, args []string) error {
 	if err := createBundleFromHub(paths, h, name, bundleCreateDesc, members); err != nil {
 		return err
 	}
+	if bundleCreateSettings != "" {
+		if err := setBundleSettings(paths, name, bundleCreateSettings); err != nil {
+			os.RemoveAll(paths.BundleDir(name))
+			return err
+		}
+	}
 
 	printBundleSummary(name, members)
 	return nil
@@ -158,25 +174,20 @@ func createBundleFromHub(paths *config.Paths, h *hub.Hub, name, desc string, mem
 		return fmt.Errorf("bundle directory already exists: %s", bundleDir)
 	}
 
+	registry, _ := source.LoadRegistry(paths.RegistryPath())
+	bundle := &hub.Bundle{Name: name, Description: desc, Version: "1.0.0", Members: members}
 	for _, m := range members.AllComponents() {
-		itemType := config.HubItemType(m.Type)
-		if !h.HasItem(itemType, m.Name) {
+		item := h.GetItem(config.HubItemType(m.Type), m.Name)
+		if item == nil {
 			os.RemoveAll(bundleDir) // roll back partial bundle
 			return fmt.Errorf("hub item not found: %s/%s", m.Type, m.Name)
 		}
-		src := paths.HubItemPath(itemType, m.Name)
-		dst := filepath.Join(bundleDir, m.Type, m.Name)
-		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		if err := syncBundleMember(paths, registry, bundle, m, item); err != nil {
 			os.RemoveAll(bundleDir)
 			return err
 		}
-		if err := source.CopyTree(src, dst); err != nil {
-			os.RemoveAll(bundleDir)
-			return fmt.Errorf("failed to copy %s/%s: %w", m.Type, m.Name, err)
-		}
 	}
 
-	bundle := &hub.Bundle{Name: name, Description: desc, Version: "1.0.0", Members: members}
 	if err := bundle.Save(paths.BundlesDir()); err != nil {
 		os.RemoveAll(bundleDir)
 		return fmt.Errorf("failed to write bundle manifest: %w", err)
@@ -184,6 +195,48 @@ func createBundleFromHub(paths *config.Paths, h *hub.Hub, name, desc string, mem
 	return nil
 }
 
+// setBundleSettings validates a settings.json fragment and stores it in the
+// bundle
+func setBundleSettings(paths *config.Paths, name, file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read settings fragment: %w", err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return fmt.Errorf("invalid settings fragment %s: %w", file, err)
+	}
+	if _, ok := settings["hooks"]; ok {
+		return fmt.Errorf("settings fragment must not define hooks: add a hooks member to the bundle instead")
+	}
+	return os.WriteFile(filepath.Join(paths.BundleDir(name), hub.BundleSettingsFile), data, 0644)
+}
+
+// syncBundleMember copies a hub item over the bundle member ref, replacing
+// any previous copy, and records the item as the member's origin
+func syncBundleMember(paths *config.Paths, registry *source.Registry, bundle *hub.Bundle, ref hub.ComponentRef, item *hub.Item) error {
+	dst := filepath.Join(paths.BundleDir(bundle.Name), ref.Type, ref.Name)
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := source.CopyTree(item.Path, dst); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", hub.MemberKey(ref), err)
+	}
+
+	origin := memberOriginFor(registry, item)
+	hash, err := hub.ContentHash(dst)
+	if err != nil {
+		return err
+	}
+	origin.Hash = hash
+	origin.SyncedAt = time.Now()
+	bundle.SetOrigin(ref, origin)
+	return nil
+}
+
 func printBundleSummary(name string, members hub.ComponentList) {
 	fmt.Printf("Created bundle '%s' with %d member(s):\n\n", name, members.Count())
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+var bundleDiffCmd = &cobra.Command{
+	Use:   "diff <name>",
+	Short: "Show how bundle members differ from their hub items",
+	Long: `Compare each bundle member with the hub item it was copied from.
+
+Members are reported as modified when the standalone hub item changed since
+the bundle copy (added, removed and changed files are listed, along with the
+upstream commit when the item is tracked). Members edited inside the bundle
+itself are flagged, since 'ccp bundle update' would overwrite those edits.
+
+Examples:
+  ccp bundle diff design
+  ccp hub update skills/foo && ccp bundle diff design`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundleDiff,
+}
+
+func init() {
+	bundleCmd.AddCommand(bundleDiffCmd)
+}
+
+// bundleMemberStatus is how a bundle member compares with its origin
+type bundleMemberStatus string
+
+const (
+	memberUnchanged bundleMemberStatus = "unchanged"
+	memberModified  bundleMemberStatus = "modified"
+	memberMissing   bundleMemberStatus = "missing" // origin hub item no longer exists
+)
+
+// bundleMemberDiff describes one member of a bundle against its origin
+type bundleMemberDiff struct {
+	Ref     hub.ComponentRef
+	Item    string // origin hub item key
+	Status  bundleMemberStatus
+	Added   []string
+	Removed []string
+	Changed []string
+
+	// Edited is set when the bundle's copy no longer matches what was synced
+	Edited bool
+
+	OldCommit string
+	NewCommit string
+}
+
+func runBundleDiff(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	bundle, err := hub.LoadBundle(paths.BundlesDir(), name)
+	if err != nil {
+		return fmt.Errorf("bundle not found: %s", name)
+	}
+	h, err := hub.NewScanner().Scan(paths.HubDir)
+	if err != nil {
+		return fmt.Errorf("failed to scan hub: %w", err)
+	}
+
+	diffs, err := diffBundle(paths, h, bundle)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Bundle: %s", bundle.Name)
+	if bundle.Version != "" {
+		fmt.Printf(" (version %s)", bundle.Version)
+	}
+	fmt.Println()
+	fmt.Println()
+	if printBundleDiff(diffs) == 0 {
+		fmt.Println("All members match their hub items")
+		return nil
+	}
+	fmt.Printf("\nApply with: ccp bundle update %s\n", bundle.Name)
+	return nil
+}
+
+// diffBundle compares every member of bundle with the hub item it came from.
+// Members without a recorded origin are compared with the hub item of the
+// same type and name.
+func diffBundle(paths *config.Paths, h *hub.Hub, bundle *hub.Bundle) ([]bundleMemberDiff, error) {
+	registry, _ := source.LoadRegistry(paths.RegistryPath())
+	bundleDir := paths.BundleDir(bundle.Name)
+
+	var diffs []bundleMemberDiff
+	for _, ref := range bundle.Members.AllComponents() {
+		d := bundleMemberDiff{Ref: ref, Item: hub.MemberKey(ref), Status: memberUnchanged}
+		origin := bundle.Origin(ref)
+		if origin != nil {
+			d.Item = origin.Item
+			d.OldCommit = origin.Commit
+		}
+
+		memberPath := filepath.Join(bundleDir, ref.Type, ref.Name)
+		memberHashes, err := hub.FileHashes(memberPath)
+		if err != nil {
+			return nil, fmt.Errorf("bundle member %s: %w", hub.MemberKey(ref), err)
+		}
+		if origin != nil && origin.Hash != "" {
+			if sum, err := hub.ContentHash(memberPath); err == nil && sum != origin.Hash {
+				d.Edited = true
+			}
+		}
+
+		item := hubItemByKey(h, d.Item)
+		if item == nil {
+			d.Status = memberMissing
+			diffs = append(diffs, d)
+			continue
+		}
+		d.NewCommit = memberOriginFor(registry, item).Commit
+
+		itemHashes, err := hub.FileHashes(item.Path)
+		if err != nil {
+			return nil, fmt.Errorf("hub item %s: %w", d.Item, err)
+		}
+		d.Added, d.Removed, d.Changed = compareFileHashes(memberHashes, itemHashes)
+		if len(d.Added)+len(d.Removed)+len(d.Changed) > 0 {
+			d.Status = memberModified
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+// compareFileHashes lists files only in next (added), only in prev (removed)
+// and present in both with different content (changed), each sorted
+func compareFileHashes(prev, next map[string]string) (added, removed, changed []string) {
+	for name, sum := range next {
+		old, ok := prev[name]
+		switch {
+		case !ok:
+			added = append(added, name)
+		case old != sum:
+			changed = append(changed, name)
+		}
+	}
+	for name := range prev {
+		if _, ok := next[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
+
+// hubItemByKey looks up a hub item from its "type/name" key
+func hubItemByKey(h *hub.Hub, key string) *hub.Item {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	return h.GetItem(config.HubItemType(parts[0]), parts[1])
+}
+
+// memberOriginFor describes where a hub item comes from: its source.yaml
+// when it has one, otherwise the registry source it was installed from.
+// Hash and SyncedAt are left for the caller to fill in.
+func memberOriginFor(registry *source.Registry, item *hub.Item) hub.MemberOrigin {
+	origin := hub.MemberOrigin{Item: string(item.Type) + "/" + item.Name}
+	if item.Source != nil {
+		origin.Source = item.Source.SourceInfo()
+		if item.Source.GitHub != nil {
+			origin.Commit = item.Source.GitHub.Commit
+		}
+		return origin
+	}
+	if registry != nil {
+		if entry := registry.FindSourceByItem(origin.Item); entry != nil {
+			origin.Source = entry.ID
+			origin.Commit = entry.Source.Commit
+		}
+	}
+	return origin
+}
+
+// printBundleDiff prints members that differ from their origin and returns
+// how many did
+func printBundleDiff(diffs []bundleMemberDiff) int {
+	count := 0
+	for _, d := range diffs {
+		if d.Status == memberUnchanged && !d.Edited {
+			continue
+		}
+		count++
+
+		label := hub.MemberKey(d.Ref)
+		if d.Item != label {
+			label += " (from " + d.Item + ")"
+		}
+		fmt.Printf("%s: %s\n", label, d.Status)
+		if d.OldCommit != d.NewCommit && d.NewCommit != "" {
+			old := shortenSHA(d.OldCommit)
+			if old == "" {
+				old = "(untracked)"
+			}
+			fmt.Printf("  commit %s -> %s\n", old, shortenSHA(d.NewCommit))
+		}
+		for _, f := range d.Added {
+			fmt.Printf("  + %s\n", f)
+		}
+		for _, f := range d.Removed {
+			fmt.Printf("  - %s\n", f)
+		}
+		for _, f := range d.Changed {
+			fmt.Printf("  ~ %s\n", f)
+		}
+		if d.Edited {
+			fmt.Println("  ! edited inside the bundle since the last sync")
+		}
+	}
+	return count
+}
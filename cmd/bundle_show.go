@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -49,8 +51,41 @@ func runBundleShow(cmd *cobra.Command, args []string) error {
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	for _, m := range bundle.Members.AllComponents() {
-		fmt.Fprintf(w, "  %s\t%s\n", m.Type, m.Name)
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", m.Type, m.Name, formatMemberOrigin(bundle.Origin(m)))
 	}
 	w.Flush()
+
+	settings, err := hub.LoadBundleSettings(paths.BundlesDir(), name)
+	if err != nil {
+		return err
+	}
+	if settings != nil {
+		keys := make([]string, 0, len(settings))
+		for key := range settings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Printf("\nSettings (%s): %s\n", hub.BundleSettingsFile, strings.Join(keys, ", "))
+	}
 	return nil
 }
+
+// formatMemberOrigin describes where a member was copied from, e.g.
+// "from skills/foo (owner/repo@1a2b3c4), synced 2026-01-02"
+func formatMemberOrigin(origin *hub.MemberOrigin) string {
+	if origin == nil {
+		return ""
+	}
+	out := "from " + origin.Item
+	if origin.Source != "" {
+		src := origin.Source
+		if origin.Commit != "" {
+			src += "@" + shortenSHA(origin.Commit)
+		}
+		out += " (" + src + ")"
+	}
+	if !origin.SyncedAt.IsZero() {
+		out += ", synced " + formatDate(origin.SyncedAt, "")
+	}
+	return out
+}
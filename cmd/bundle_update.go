@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/profile"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+var (
+	bundleUpdatePull   bool
+	bundleUpdateMinor  bool
+	bundleUpdateForce  bool
+	bundleUpdateDryRun bool
+)
+
+var bundleUpdateCmd = &cobra.Command{
+	Use:   "update <name>",
+	Short: "Re-sync bundle members from their hub items",
+	Long: `Re-copy bundle members whose hub items changed, bump the bundle version
+and relink every profile that uses the bundle.
+
+Each member is compared with the hub item it was copied from (see
+'ccp bundle diff'). With --pull, members whose hub items are tracked
+upstream (GitHub) are first updated from their source, as with
+'ccp hub update'. The version gets a patch bump, or a minor bump with
+--minor. Members edited inside the bundle are not overwritten unless
+--force is given.
+
+Examples:
+  ccp bundle update design
+  ccp bundle update design --pull --minor
+  ccp bundle update design --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundleUpdate,
+}
+
+func init() {
+	bundleUpdateCmd.Flags().BoolVar(&bundleUpdatePull, "pull", false, "Update tracked hub items from upstream first")
+	bundleUpdateCmd.Flags().BoolVar(&bundleUpdateMinor, "minor", false, "Bump the minor version instead of the patch version")
+	bundleUpdateCmd.Flags().BoolVarP(&bundleUpdateForce, "force", "f", false, "Overwrite members edited inside the bundle")
+	bundleUpdateCmd.Flags().BoolVarP(&bundleUpdateDryRun, "dry-run", "n", false, "Show what would change without making changes")
+	bundleCmd.AddCommand(bundleUpdateCmd)
+}
+
+func runBundleUpdate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	bundle, err := hub.LoadBundle(paths.BundlesDir(), name)
+	if err != nil {
+		return fmt.Errorf("bundle not found: %s", name)
+	}
+	scanner := hub.NewScanner()
+	h, err := scanner.Scan(paths.HubDir)
+	if err != nil {
+		return fmt.Errorf("failed to scan hub: %w", err)
+	}
+
+	if bundleUpdatePull && !bundleUpdateDryRun {
+		if pullBundleOrigins(paths, h, bundle) > 0 {
+			if h, err = scanner.Scan(paths.HubDir); err != nil {
+				return fmt.Errorf("failed to scan hub: %w", err)
+			}
+		}
+	}
+
+	diffs, err := diffBundle(paths, h, bundle)
+	if err != nil {
+		return err
+	}
+	printBundleDiff(diffs)
+
+	newVersion := hub.BumpVersion(bundle.Version, bundleUpdateMinor)
+	if bundleUpdateDryRun {
+		if countBundleChanges(diffs) == 0 {
+			fmt.Printf("Bundle '%s' is up to date\n", name)
+			return nil
+		}
+		fmt.Printf("\nWould update %d member(s) and bump version %s -> %s\n", countBundleChanges(diffs), bundle.Version, newVersion)
+		return nil
+	}
+
+	oldVersion := bundle.Version
+	changed, err := applyBundleUpdate(paths, h, bundle, diffs, bundleUpdateMinor, bundleUpdateForce)
+	if err != nil {
+		return err
+	}
+	if changed == 0 {
+		fmt.Printf("Bundle '%s' is up to date (version %s)\n", name, bundle.Version)
+		return nil
+	}
+	fmt.Printf("\nUpdated %d member(s), version %s -> %s\n", changed, oldVersion, bundle.Version)
+
+	relinked, err := relinkBundleProfiles(paths, name)
+	if len(relinked) > 0 {
+		fmt.Printf("Relinked in: %s\n", strings.Join(relinked, ", "))
+	}
+	return err
+}
+
+// pullBundleOrigins updates the upstream-tracked hub items behind a bundle's
+// members and returns how many changed
+func pullBundleOrigins(paths *config.Paths, h *hub.Hub, bundle *hub.Bundle) int {
+	pulled := 0
+	for _, ref := range bundle.Members.AllComponents() {
+		key := hub.MemberKey(ref)
+		if origin := bundle.Origin(ref); origin != nil {
+			key = origin.Item
+		}
+		item := hubItemByKey(h, key)
+		if item == nil || item.Source == nil || item.Source.Type != hub.SourceTypeGitHub {
+			continue
+		}
+		fmt.Printf("Pulling %s from %s...\n", key, item.Source.SourceInfo())
+		if err := updateItem(paths, *item); err != nil {
+			if !errors.Is(err, errAlreadyUpToDate) {
+				fmt.Printf("  Warning: %v\n", err)
+			}
+			continue
+		}
+		pulled++
+	}
+	return pulled
+}
+
+// countBundleChanges returns how many members an update would re-copy
+func countBundleChanges(diffs []bundleMemberDiff) int {
+	n := 0
+	for _, d := range diffs {
+		if d.Status == memberModified {
+			n++
+		}
+	}
+	return n
+}
+
+// applyBundleUpdate re-copies modified members from their hub items, bumps
+// the version when anything changed and saves the manifest. Origins are
+// (re)recorded for every member still in the hub, so bundles created before
+// origins were tracked gain them on their first update. Returns the number of
+// members re-copied.
+func applyBundleUpdate(paths *config.Paths, h *hub.Hub, bundle *hub.Bundle, diffs []bundleMemberDiff, minor, force bool) (int, error) {
+	if !force {
+		var edited []string
+		for _, d := range diffs {
+			if d.Edited && d.Status == memberModified {
+				edited = append(edited, hub.MemberKey(d.Ref))
+			}
+		}
+		if len(edited) > 0 {
+			return 0, fmt.Errorf("members edited inside the bundle would be overwritten: %s (use --force)", strings.Join(edited, ", "))
+		}
+	}
+
+	registry, _ := source.LoadRegistry(paths.RegistryPath())
+	changed := 0
+	dirty := false
+	for _, d := range diffs {
+		item := hubItemByKey(h, d.Item)
+		if item == nil {
+			fmt.Printf("Warning: %s no longer exists in the hub, keeping bundled copy of %s\n", d.Item, hub.MemberKey(d.Ref))
+			continue
+		}
+
+		switch {
+		case d.Status == memberModified:
+			if err := syncBundleMember(paths, registry, bundle, d.Ref, item); err != nil {
+				return changed, err
+			}
+			changed++
+		case bundle.Origin(d.Ref) == nil || d.OldCommit != d.NewCommit:
+			// Content already matches: only refresh what the origin records
+			origin := memberOriginFor(registry, item)
+			hash, err := hub.ContentHash(filepath.Join(paths.BundleDir(bundle.Name), d.Ref.Type, d.Ref.Name))
+			if err != nil {
+				return changed, err
+			}
+			origin.Hash = hash
+			origin.SyncedAt = time.Now()
+			bundle.SetOrigin(d.Ref, origin)
+		default:
+			continue
+		}
+		dirty = true
+	}
+
+	if changed > 0 {
+		bundle.Version = hub.BumpVersion(bundle.Version, minor)
+	}
+	if dirty {
+		if err := bundle.Save(paths.BundlesDir()); err != nil {
+			return changed, fmt.Errorf("failed to write bundle manifest: %w", err)
+		}
+	}
+	return changed, nil
+}
+
+// relinkBundleProfiles relinks the bundle in every profile that uses it, so
+// member links match the updated manifest. Members are refreshed in place,
+// so the bundle keeps its position (and settings merge order) in each
+// profile. Returns the relinked profiles.
+func relinkBundleProfiles(paths *config.Paths, name string) ([]string, error) {
+	mgr := profile.NewManager(paths)
+	profiles, err := mgr.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	var relinked []string
+	var errs []string
+	for _, p := range profiles {
+		if !slices.Contains(p.Manifest.Hub.Bundles, name) {
+			continue
+		}
+		if err := mgr.LinkHubBundle(p.Name, name); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", p.Name, err))
+			continue
+		}
+		relinked = append(relinked, p.Name)
+	}
+	if len(errs) > 0 {
+		return relinked, fmt.Errorf("failed to relink bundle: %s", strings.Join(errs, "; "))
+	}
+	return relinked, nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/profile"
+)
+
+func TestCreateBundleFromHub_RecordsOrigins(t *testing.T) {
+	paths, h := newBundleTestHub(t)
+	members := hub.ComponentList{Skills: []string{"foo"}, Agents: []string{"bar.md"}}
+	if err := createBundleFromHub(paths, h, "b", "", members); err != nil {
+		t.Fatalf("createBundleFromHub: %v", err)
+	}
+
+	bundle, err := hub.LoadBundle(paths.BundlesDir(), "b")
+	if err != nil {
+		t.Fatalf("LoadBundle: %v", err)
+	}
+	origin := bundle.Origin(hub.ComponentRef{Type: "skills", Name: "foo"})
+	if origin == nil || origin.Item != "skills/foo" || origin.Hash == "" || origin.SyncedAt.IsZero() {
+		t.Fatalf("skill origin = %+v", origin)
+	}
+	if bundle.Origin(hub.ComponentRef{Type: "agents", Name: "bar.md"}) == nil {
+		t.Error("agent origin not recorded")
+	}
+}
+
+func TestBundleUpdate_ResyncsChangedMembers(t *testing.T) {
+	paths, h := newBundleTestHub(t)
+	members := hub.ComponentList{Skills: []string{"foo"}, Hooks: []string{"baz"}}
+	if err := createBundleFromHub(paths, h, "b", "", members); err != nil {
+		t.Fatalf("createBundleFromHub: %v", err)
+	}
+
+	skillDir := filepath.Join(paths.HubItemDir(config.HubSkills), "foo")
+	writeFile(t, filepath.Join(skillDir, "SKILL.md"), "# foo v2")
+	writeFile(t, filepath.Join(skillDir, "refs.md"), "refs")
+
+	bundle, _ := hub.LoadBundle(paths.BundlesDir(), "b")
+	diffs, err := diffBundle(paths, h, bundle)
+	if err != nil {
+		t.Fatalf("diffBundle: %v", err)
+	}
+	if countBundleChanges(diffs) != 1 {
+		t.Fatalf("expected 1 changed member, got %+v", diffs)
+	}
+	for _, d := range diffs {
+		if d.Ref.Type != "skills" {
+			if d.Status != memberUnchanged {
+				t.Errorf("%s should be unchanged, got %s", hub.MemberKey(d.Ref), d.Status)
+			}
+			continue
+		}
+		if len(d.Changed) != 1 || d.Changed[0] != "SKILL.md" || len(d.Added) != 1 || d.Added[0] != "refs.md" {
+			t.Errorf("skill diff = added %v changed %v", d.Added, d.Changed)
+		}
+	}
+
+	changed, err := applyBundleUpdate(paths, h, bundle, diffs, false, false)
+	if err != nil || changed != 1 {
+		t.Fatalf("applyBundleUpdate = %d, %v", changed, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(paths.BundleDir("b"), "skills", "foo", "SKILL.md"))
+	if string(data) != "# foo v2" {
+		t.Errorf("bundled skill not re-copied: %q", data)
+	}
+
+	reloaded, _ := hub.LoadBundle(paths.BundlesDir(), "b")
+	if reloaded.Version != "1.0.1" {
+		t.Errorf("version = %q, want 1.0.1", reloaded.Version)
+	}
+	diffs, _ = diffBundle(paths, h, reloaded)
+	if countBundleChanges(diffs) != 0 {
+		t.Errorf("expected bundle to be up to date after update, got %+v", diffs)
+	}
+}
+
+func TestBundleUpdate_RefusesToOverwriteBundleEdits(t *testing.T) {
+	paths, h := newBundleTestHub(t)
+	if err := createBundleFromHub(paths, h, "b", "", hub.ComponentList{Skills: []string{"foo"}}); err != nil {
+		t.Fatalf("createBundleFromHub: %v", err)
+	}
+	writeFile(t, filepath.Join(paths.BundleDir("b"), "skills", "foo", "SKILL.md"), "# edited in bundle")
+	writeFile(t, filepath.Join(paths.HubItemDir(config.HubSkills), "foo", "SKILL.md"), "# foo v2")
+
+	bundle, _ := hub.LoadBundle(paths.BundlesDir(), "b")
+	diffs, _ := diffBundle(paths, h, bundle)
+	if _, err := applyBundleUpdate(paths, h, bundle, diffs, false, false); err == nil {
+		t.Fatal("expected error when a member was edited inside the bundle")
+	}
+	if changed, err := applyBundleUpdate(paths, h, bundle, diffs, true, true); err != nil || changed != 1 {
+		t.Fatalf("forced update = %d, %v", changed, err)
+	}
+	if bundle.Version != "1.1.0" {
+		t.Errorf("version = %q, want 1.1.0", bundle.Version)
+	}
+}
+
+func TestRelinkBundleProfiles(t *testing.T) {
+	paths, h := newBundleTestHub(t)
+	if err := createBundleFromHub(paths, h, "b", "", hub.ComponentList{Skills: []string{"foo"}}); err != nil {
+		t.Fatalf("createBundleFromHub: %v", err)
+	}
+	if err := createBundleFromHub(paths, h, "c", "", hub.ComponentList{Agents: []string{"bar.md"}}); err != nil {
+		t.Fatalf("createBundleFromHub: %v", err)
+	}
+
+	mgr := profile.NewManager(paths)
+	for _, name := range []string{"uses", "other"} {
+		if _, err := mgr.Create(name, profile.NewManifest(name, "")); err != nil {
+			t.Fatalf("create profile %s: %v", name, err)
+		}
+	}
+	for _, bundle := range []string{"b", "c"} {
+		if err := mgr.LinkHubBundle("uses", bundle); err != nil {
+			t.Fatalf("LinkHubBundle(%s): %v", bundle, err)
+		}
+	}
+
+	relinked, err := relinkBundleProfiles(paths, "b")
+	if err != nil {
+		t.Fatalf("relinkBundleProfiles: %v", err)
+	}
+	if len(relinked) != 1 || relinked[0] != "uses" {
+		t.Errorf("relinked = %v, want [uses]", relinked)
+	}
+	if _, err := os.Stat(filepath.Join(paths.ProfileDir("uses"), "skills", "foo", "SKILL.md")); err != nil {
+		t.Errorf("bundle member not linked after relink: %v", err)
+	}
+	// The relinked bundle keeps its place, so settings merge in the same order
+	p, _ := mgr.Get("uses")
+	if !slices.Equal(p.Manifest.Hub.Bundles, []string{"b", "c"}) {
+		t.Errorf("manifest bundles = %v, want [b c]", p.Manifest.Hub.Bundles)
+	}
+}
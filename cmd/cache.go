@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the offline package cache",
+	Long: `Manage the local package cache in ~/.ccp/cache.
+
+Downloaded archives are kept and registry lookups and searches are recorded.
+Bare mirrors of git sources are made by 'ccp cache fill', or on every online
+install and update with mirror = true under [cache] in ccp.toml. With
+--offline (or CCP_OFFLINE=1) installs, updates and searches resolve only
+from the cache.
+
+Examples:
+  ccp cache fill                       # Mirror every source in the registry
+  ccp --offline install owner/repo     # Install without network access
+  ccp cache serve --addr 0.0.0.0:8765  # Share the cache as a skills.sh mirror`,
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// initSourceCache routes providers and registries through the package cache
+func initSourceCache() {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return
+	}
+	if v := os.Getenv("CCP_OFFLINE"); v != "" && v != "0" && v != "false" {
+		offline = true
+	}
+	source.UseCache(source.NewCache(paths.CacheDir(), offline, config.GetConfig().Cache.Mirror))
+}
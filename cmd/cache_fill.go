@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+var cacheFillCmd = &cobra.Command{
+	Use:   "fill [source...]",
+	Short: "Pre-populate the cache from the source registry",
+	Long: `Mirror every source in ccp.toml (or only the given ones) into the cache,
+together with a record of its items, so that later installs, updates and
+searches work with --offline.
+
+Examples:
+  ccp cache fill
+  ccp cache fill me/skills anthropics/skills`,
+	RunE: runCacheFill,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheFillCmd)
+}
+
+func runCacheFill(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+	cache := source.ActiveCache()
+	if cache.Offline() {
+		return fmt.Errorf("cannot fill the cache in offline mode")
+	}
+
+	registry, err := source.LoadRegistry(paths.RegistryPath())
+	if err != nil {
+		return err
+	}
+	entries := registry.ListSources()
+	if len(args) > 0 {
+		entries = nil
+		for _, id := range args {
+			src, err := registry.GetSource(id)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, source.SourceEntry{ID: id, Source: *src})
+		}
+	}
+	if len(entries) == 0 {
+		fmt.Println("No sources in registry. Add sources with: ccp install <owner/repo>")
+		return nil
+	}
+
+	installer := source.NewInstaller(paths, registry)
+	ctx := context.Background()
+	filled := 0
+	for _, entry := range entries {
+		fmt.Printf("Caching %s...\n", entry.ID)
+		contents := installer.DiscoverItems(paths.SourceDir(entry.ID))
+		if err := cache.Fill(ctx, entry.ID, entry.Source, contents); err != nil {
+			fmt.Printf("  ⚠ %v\n", err)
+			continue
+		}
+		fmt.Printf("  ✓ %s (%d items)\n", entry.Source.URL, len(contents))
+		filled++
+	}
+
+	fmt.Printf("\nCached %d/%d sources in %s\n", filled, len(entries), cache.Dir())
+	if filled < len(entries) {
+		return fmt.Errorf("%d sources could not be cached", len(entries)-filled)
+	}
+	return nil
+}
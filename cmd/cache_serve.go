@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/source"
+)
+
+var cacheServeAddr string
+
+var cacheServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the cache as a local skills.sh mirror",
+	Long: `Serve the package cache over HTTP with the skills.sh API, so other machines
+can install from it by pointing skillssh.base_url in their ccp.toml here:
+
+  [skillssh]
+  base_url = "http://<this-host>:8765"
+
+Package lookups return the cached git mirror (served with git's dumb HTTP
+protocol) or archive as the download URL, so clients never reach the
+original host. Searches match cached packages only.
+
+Examples:
+  ccp cache serve
+  ccp cache serve --addr 0.0.0.0:8765`,
+	Args: cobra.NoArgs,
+	RunE: runCacheServe,
+}
+
+func init() {
+	cacheServeCmd.Flags().StringVar(&cacheServeAddr, "addr", "localhost:8765", "Address to listen on")
+	cacheCmd.AddCommand(cacheServeCmd)
+}
+
+func runCacheServe(cmd *cobra.Command, args []string) error {
+	cache := source.ActiveCache()
+	if cache == nil {
+		return fmt.Errorf("cannot resolve the ccp directory")
+	}
+	fmt.Printf("Serving %s on http://%s (Ctrl+C to stop)\n", cache.Dir(), cacheServeAddr)
+	return http.ListenAndServe(cacheServeAddr, cache.Handler())
+}
@@ -62,7 +62,7 @@ func completeHubItems(cmd *cobra.Command, args []string, toComplete string) ([]s
 
 // completeHubTypes returns completion for hub item types
 func completeHubTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	types := []string{"skills", "agents", "hooks", "rules", "commands", "settings-templates", "bundles"}
+	types := []string{"skills", "agents", "hooks", "rules", "commands", "output-styles", "statuslines", "memory", "settings-templates", "bundles"}
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -8,20 +8,37 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	configShellName   string
+	configShellNoAuto bool
+)
+
 var configShellCmd = &cobra.Command{
 	Use:   "shell",
 	Short: "Output shell configuration for Claude integration",
-	Long: `Output shell aliases and functions for Claude Code integration.
+	Long: `Output shell aliases, functions and hooks for Claude Code integration.
 
 Add the output to your shell configuration file (.bashrc, .zshrc, etc.):
 
   ccp config shell >> ~/.zshrc
   source ~/.zshrc
 
+or evaluate it at startup:
+
+  eval "$(ccp config shell)"                        # bash/zsh
+  ccp config shell --shell fish | source            # fish (config.fish)
+
 This configures:
   - claude alias: Loads profile's CLAUDE.md and rules via --add-dir
   - ccp-use function: Quick profile switching with mise env reload
   - Automatic CLAUDE_CONFIG_DIR from ccp which --path
+  - Auto-activation: on directory change, sets CLAUDE_CONFIG_DIR from the
+    nearest .ccp.yaml (see 'ccp auto') and restores it when leaving
+
+The auto-activation hook (chpwd in zsh, PROMPT_COMMAND in bash, a PWD
+watcher in fish) only runs when the directory changes, and only calls ccp
+when a .ccp.yaml exists up the tree or a profile needs restoring. Use
+--no-auto to leave it out.
 
 The output is wrapped in a ccp existence check, making it safe for
 synced shell configs across machines where ccp may not be installed.`,
@@ -29,17 +46,39 @@ synced shell configs across machines where ccp may not be installed.`,
 }
 
 func init() {
+	configShellCmd.Flags().StringVar(&configShellName, "shell", "", "Shell to generate for: bash, zsh, fish (default: $SHELL)")
+	configShellCmd.Flags().BoolVar(&configShellNoAuto, "no-auto", false, "Omit the .ccp.yaml auto-activation hook")
 	configCmd.AddCommand(configShellCmd)
 }
 
 func runConfigShell(cmd *cobra.Command, args []string) error {
-	// Detect shell
-	shell := detectShell()
+	shell := configShellName
+	if shell == "" {
+		shell = detectShell()
+	}
+	if !isSupportedShell(shell) {
+		if configShellName != "" {
+			return fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish)", shell)
+		}
+		// Unknown login shell: POSIX-style output works for sh-compatible shells
+		shell = "bash"
+	}
 
 	fmt.Printf("# ccp shell configuration (%s)\n", shell)
 	fmt.Println("# Add this to your shell config file")
 	fmt.Println()
 
+	if shell == "fish" {
+		printFishConfig()
+	} else {
+		printPosixConfig(shell)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func printPosixConfig(shell string) {
 	// Wrap in ccp existence check for synced shell configs
 	fmt.Println("# Only load if ccp is installed")
 	fmt.Println("if command -v ccp &> /dev/null; then")
@@ -58,10 +97,82 @@ func runConfigShell(cmd *cobra.Command, args []string) error {
 	fmt.Println("      eval \"$(mise env)\"")
 	fmt.Println("    fi")
 	fmt.Println("  }")
+
+	if !configShellNoAuto {
+		fmt.Println()
+		fmt.Println("  # Auto-activate profiles from .ccp.yaml on directory change")
+		fmt.Println("  _ccp_auto_find() {")
+		fmt.Println(`    local dir="$PWD"`)
+		fmt.Println("    while :; do")
+		fmt.Println(`      [[ -f "$dir/.ccp.yaml" || -f "$dir/.ccp.yml" ]] && return 0`)
+		fmt.Println(`      [[ -z "$dir" ]] && return 1`)
+		fmt.Println(`      dir="${dir%/*}"`)
+		fmt.Println("    done")
+		fmt.Println("  }")
+		fmt.Println("  _ccp_auto_hook() {")
+		fmt.Println(`    [[ "$PWD" == "$_CCP_AUTO_PWD" ]] && return`)
+		fmt.Println(`    _CCP_AUTO_PWD="$PWD"`)
+		fmt.Println(`    if _ccp_auto_find || [[ -n "$CCP_AUTO_PROFILE" ]]; then`)
+		fmt.Printf("      eval \"$(command ccp auto --hook %s)\"\n", shell)
+		fmt.Println("    fi")
+		fmt.Println("  }")
+		if shell == "zsh" {
+			fmt.Println("  autoload -Uz add-zsh-hook")
+			fmt.Println("  add-zsh-hook chpwd _ccp_auto_hook")
+			fmt.Println("  _ccp_auto_hook")
+		} else {
+			fmt.Println(`  if [[ ";${PROMPT_COMMAND:-};" != *";_ccp_auto_hook;"* ]]; then`)
+			fmt.Println(`    PROMPT_COMMAND="_ccp_auto_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"`)
+			fmt.Println("  fi")
+		}
+	}
+
 	fmt.Println("fi")
+}
+
+func printFishConfig() {
+	fmt.Println("# Only load if ccp is installed")
+	fmt.Println("if command -q ccp")
+
+	fmt.Println("  # Claude alias - loads profile's CLAUDE.md and rules")
+	fmt.Println("  function claude --wraps claude")
+	fmt.Println("    set -l dir $CLAUDE_CONFIG_DIR")
+	fmt.Println("    test -n \"$dir\"; or set dir (ccp which --path 2>/dev/null)")
+	fmt.Println("    CLAUDE_CODE_ADDITIONAL_DIRECTORIES_CLAUDE_MD=1 command claude --add-dir $dir $argv")
+	fmt.Println("  end")
 	fmt.Println()
 
-	return nil
+	fmt.Println("  # Quick profile switch (optional)")
+	fmt.Println("  function ccp-use")
+	fmt.Println("    ccp use $argv")
+	fmt.Println("    # Reload mise env if available")
+	fmt.Println("    if command -q mise; and test -f mise.toml")
+	fmt.Println("      mise env -s fish | source")
+	fmt.Println("    end")
+	fmt.Println("  end")
+
+	if !configShellNoAuto {
+		fmt.Println()
+		fmt.Println("  # Auto-activate profiles from .ccp.yaml on directory change")
+		fmt.Println("  function __ccp_auto_find")
+		fmt.Println("    set -l dir $PWD")
+		fmt.Println("    while true")
+		fmt.Println("      if test -f \"$dir/.ccp.yaml\"; or test -f \"$dir/.ccp.yml\"")
+		fmt.Println("        return 0")
+		fmt.Println("      end")
+		fmt.Println("      test -z \"$dir\"; and return 1")
+		fmt.Println("      set dir (string replace -r '/[^/]*$' '' -- $dir)")
+		fmt.Println("    end")
+		fmt.Println("  end")
+		fmt.Println("  function __ccp_auto_hook --on-variable PWD")
+		fmt.Println("    if __ccp_auto_find; or set -q CCP_AUTO_PROFILE")
+		fmt.Println("      command ccp auto --hook fish | source")
+		fmt.Println("    end")
+		fmt.Println("  end")
+		fmt.Println("  __ccp_auto_hook")
+	}
+
+	fmt.Println("end")
 }
 
 func detectShell() string {
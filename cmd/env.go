@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -26,6 +28,9 @@ Supported formats:
   direnv  - Update .envrc with export CLAUDE_CONFIG_DIR
   shell   - Print shell export command (default)
 
+Variables from the profile's [env] table are written too; ${env:NAME}
+references in their values are kept as references for mise and the shell.
+
 Examples:
   ccp env dev                    # Print shell export
   ccp env dev --format=mise      # Update mise.toml
@@ -66,113 +71,82 @@ func runEnv(cmd *cobra.Command, args []string) error {
 	switch envFormat {
 	case "shell":
 		fmt.Printf("export CLAUDE_CONFIG_DIR=\"%s\"\n", profilePath)
+		envVars := profileFileEnv(p.Manifest, envFormat)
+		for _, name := range p.Manifest.EnvNames() {
+			fmt.Printf("export %s=\"%s\"\n", name, envVars[name])
+		}
 		return nil
 
 	case "mise":
-		return updateMiseToml(profilePath)
+		envVars := profileFileEnv(p.Manifest, envFormat)
+		envVars["CLAUDE_CONFIG_DIR"] = profilePath
+		return updateMiseTomlMulti(envVars)
 
 	case "direnv":
-		return updateEnvrc(profilePath)
+		envVars := profileFileEnv(p.Manifest, envFormat)
+		envVars["CLAUDE_CONFIG_DIR"] = profilePath
+		return updateEnvrcMulti(envVars)
 
 	default:
 		return fmt.Errorf("unknown format: %s (valid: shell, mise, direnv)", envFormat)
 	}
 }
 
-func updateMiseToml(profilePath string) error {
-	miseFile := "mise.toml"
-
-	// Check if mise.toml exists
-	content := ""
-	if data, err := os.ReadFile(miseFile); err == nil {
-		content = string(data)
+// profileExecEnv returns the profile's env as KEY=VALUE pairs for a command
+// ccp starts, with ${env:NAME} references resolved from ccp's environment
+func profileExecEnv(manifest *profile.Manifest) []string {
+	var env []string
+	for _, name := range manifest.EnvNames() {
+		env = append(env, name+"="+profile.ExpandEnvRefs(manifest.Env[name], nil, os.Getenv))
 	}
+	return env
+}
 
-	envLine := fmt.Sprintf("CLAUDE_CONFIG_DIR = \"%s\"", profilePath)
-
-	// Check if already has CLAUDE_CONFIG_DIR
-	if strings.Contains(content, "CLAUDE_CONFIG_DIR") {
-		// Replace existing
-		lines := strings.Split(content, "\n")
-		for i, line := range lines {
-			if strings.Contains(line, "CLAUDE_CONFIG_DIR") {
-				lines[i] = envLine
-				break
-			}
-		}
-		content = strings.Join(lines, "\n")
-	} else {
-		// Add to [env] section or create it
-		if strings.Contains(content, "[env]") {
-			// Find [env] section and add after it
-			lines := strings.Split(content, "\n")
-			for i, line := range lines {
-				if strings.TrimSpace(line) == "[env]" {
-					// Insert after [env]
-					newLines := make([]string, 0, len(lines)+1)
-					newLines = append(newLines, lines[:i+1]...)
-					newLines = append(newLines, envLine)
-					newLines = append(newLines, lines[i+1:]...)
-					lines = newLines
-					break
-				}
-			}
-			content = strings.Join(lines, "\n")
+// profileFileEnv returns the profile's env as quoted-string contents for a
+// file of format (shell, direnv or mise). ${env:NAME} references stay
+// references, so they are resolved when the file is loaded.
+func profileFileEnv(manifest *profile.Manifest, format string) map[string]string {
+	envVars := make(map[string]string)
+	for name, value := range manifest.EnvVars() {
+		if format == "mise" {
+			envVars[name] = profile.ExpandEnvRefs(value, tomlStringEscape, func(ref string) string {
+				return "{{env." + ref + "}}"
+			})
 		} else {
-			// Add [env] section
-			if content != "" && !strings.HasSuffix(content, "\n") {
-				content += "\n"
-			}
-			content += fmt.Sprintf("\n[env]\n%s\n", envLine)
+			envVars[name] = profile.ExpandEnvRefs(value, shellStringEscape, func(ref string) string {
+				return "${" + ref + "}"
+			})
 		}
 	}
-
-	if err := os.WriteFile(miseFile, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write mise.toml: %w", err)
-	}
-
-	fmt.Printf("Updated mise.toml with CLAUDE_CONFIG_DIR = \"%s\"\n", profilePath)
-	return nil
+	return envVars
 }
 
-func updateEnvrc(profilePath string) error {
-	envrcFile := ".envrc"
-	envLine := fmt.Sprintf("export CLAUDE_CONFIG_DIR=\"%s\"", profilePath)
-
-	// Check if .envrc exists
-	content := ""
-	if data, err := os.ReadFile(envrcFile); err == nil {
-		content = string(data)
-	}
+// shellStringEscape escapes s for use inside a double-quoted shell string
+func shellStringEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`").Replace(s)
+}
 
-	// Check if already has CLAUDE_CONFIG_DIR
-	if strings.Contains(content, "CLAUDE_CONFIG_DIR") {
-		// Replace existing
-		lines := strings.Split(content, "\n")
-		for i, line := range lines {
-			if strings.Contains(line, "CLAUDE_CONFIG_DIR") {
-				lines[i] = envLine
-				break
-			}
-		}
-		content = strings.Join(lines, "\n")
-	} else {
-		// Append
-		if content != "" && !strings.HasSuffix(content, "\n") {
-			content += "\n"
-		}
-		content += envLine + "\n"
-	}
+// tomlStringEscape escapes s for use inside a TOML basic string
+func tomlStringEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
 
-	if err := os.WriteFile(envrcFile, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write .envrc: %w", err)
-	}
+// envKeysMarker starts the comment that records which profile variables
+// ccp wrote to a mise.toml or .envrc, so switching to a profile without
+// them takes them out again
+const envKeysMarker = "# ccp-env:"
 
-	fmt.Printf("Updated .envrc with CLAUDE_CONFIG_DIR\n")
-	fmt.Println("Run 'direnv allow' to apply changes")
-	return nil
+// ccpEnvKeys are set for every profile and never recorded by the marker
+var ccpEnvKeys = map[string]bool{
+	"CLAUDE_CONFIG_DIR":                            true,
+	"CLAUDE_CODE_ADDITIONAL_DIRECTORIES_CLAUDE_MD": true,
 }
 
+var (
+	miseKeyPattern  = regexp.MustCompile(`^\s*([A-Za-z0-9_.-]+)\s*=`)
+	envrcKeyPattern = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)=`)
+)
+
 // updateMiseTomlMulti updates mise.toml with multiple env vars
 func updateMiseTomlMulti(envVars map[string]string) error {
 	miseFile := "mise.toml"
@@ -182,59 +156,54 @@ func updateMiseTomlMulti(envVars map[string]string) error {
 		content = string(data)
 	}
 
-	lines := strings.Split(content, "\n")
-
-	// Process each env var
-	for key, value := range envVars {
-		envLine := fmt.Sprintf("%s = \"%s\"", key, value)
-		found := false
+	if err := os.WriteFile(miseFile, []byte(updateMiseContent(content, envVars)), 0644); err != nil {
+		return fmt.Errorf("failed to write mise.toml: %w", err)
+	}
 
-		// Check if already has this key
-		for i, line := range lines {
-			if strings.Contains(line, key+" =") || strings.Contains(line, key+"=") {
-				lines[i] = envLine
-				found = true
-				break
-			}
-		}
+	fmt.Printf("Updated mise.toml with Claude env vars\n")
+	return nil
+}
 
-		if !found {
-			// Need to add it to [env] section
-			envSectionFound := false
-			for i, line := range lines {
-				if strings.TrimSpace(line) == "[env]" {
-					// Insert after [env]
-					newLines := make([]string, 0, len(lines)+1)
-					newLines = append(newLines, lines[:i+1]...)
-					newLines = append(newLines, envLine)
-					newLines = append(newLines, lines[i+1:]...)
-					lines = newLines
-					envSectionFound = true
-					break
-				}
-			}
+// updateMiseContent sets envVars in the [env] table of mise.toml content,
+// adding the table if needed. Keys elsewhere in the file are left alone.
+func updateMiseContent(content string, envVars map[string]string) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
 
-			if !envSectionFound {
-				// Add [env] section at end
-				if len(lines) > 0 && lines[len(lines)-1] != "" {
-					lines = append(lines, "")
-				}
-				lines = append(lines, "[env]", envLine)
-			}
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "[env]" {
+			start = i + 1
+			break
 		}
 	}
-
-	content = strings.Join(lines, "\n")
-	if !strings.HasSuffix(content, "\n") {
-		content += "\n"
+	if start == -1 {
+		if len(lines) > 0 && lines[len(lines)-1] != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "[env]")
+		start = len(lines)
+	}
+	end := len(lines)
+	for i := start; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "[") {
+			end = i
+			break
+		}
 	}
 
-	if err := os.WriteFile(miseFile, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write mise.toml: %w", err)
+	table := setEnvLines(lines[start:end], envVars, miseKeyPattern, func(key, value string) string {
+		return fmt.Sprintf("%s = \"%s\"", key, value)
+	})
+	// Keep a blank line before the next table
+	if end < len(lines) && len(table) > 0 && strings.TrimSpace(table[len(table)-1]) != "" {
+		table = append(table, "")
 	}
 
-	fmt.Printf("Updated mise.toml with Claude env vars\n")
-	return nil
+	result := append(append(append([]string{}, lines[:start]...), table...), lines[end:]...)
+	return strings.Join(result, "\n") + "\n"
 }
 
 // updateEnvrcMulti updates .envrc with multiple env vars
@@ -246,37 +215,117 @@ func updateEnvrcMulti(envVars map[string]string) error {
 		content = string(data)
 	}
 
-	lines := strings.Split(content, "\n")
+	if err := os.WriteFile(envrcFile, []byte(updateEnvrcContent(content, envVars)), 0644); err != nil {
+		return fmt.Errorf("failed to write .envrc: %w", err)
+	}
 
-	// Process each env var
-	for key, value := range envVars {
-		envLine := fmt.Sprintf("export %s=\"%s\"", key, value)
-		found := false
+	fmt.Printf("Updated .envrc with Claude env vars\n")
+	fmt.Println("Run 'direnv allow' to apply changes")
+	return nil
+}
 
-		for i, line := range lines {
-			if strings.Contains(line, key+"=") || strings.Contains(line, key+" =") {
-				lines[i] = envLine
-				found = true
-				break
+// updateEnvrcContent sets envVars as exports in .envrc content
+func updateEnvrcContent(content string, envVars map[string]string) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+	lines = setEnvLines(lines, envVars, envrcKeyPattern, func(key, value string) string {
+		return fmt.Sprintf("export %s=\"%s\"", key, value)
+	})
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// setEnvLines rewrites the lines assigning each key of envVars, matched by
+// exact name through keyPattern, and appends the missing ones. Profile
+// variables recorded by the envKeysMarker comment but absent from envVars
+// are removed, and the marker is rewritten for the new set.
+func setEnvLines(lines []string, envVars map[string]string, keyPattern *regexp.Regexp, format func(key, value string) string) []string {
+	previous := make(map[string]bool)
+	for _, line := range lines {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), envKeysMarker); ok {
+			for _, key := range strings.Fields(rest) {
+				previous[key] = true
 			}
 		}
+	}
 
-		if !found {
-			// Append
-			lines = append(lines, envLine)
+	var result []string
+	written := make(map[string]bool)
+	firstWritten := -1
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), envKeysMarker) {
+			continue
+		}
+		m := keyPattern.FindStringSubmatch(line)
+		if m == nil {
+			result = append(result, line)
+			continue
+		}
+		key := m[1]
+		value, set := envVars[key]
+		switch {
+		case set && !written[key]:
+			if firstWritten == -1 {
+				firstWritten = len(result)
+			}
+			result = append(result, format(key, value))
+			written[key] = true
+		case set || previous[key]:
+			// Duplicate of a line just written, or left over from the
+			// previous profile
+		default:
+			result = append(result, line)
 		}
 	}
 
-	content = strings.Join(lines, "\n")
-	if !strings.HasSuffix(content, "\n") {
-		content += "\n"
+	// Missing keys go after the last line written, or at the end
+	insertAt := len(result)
+	for insertAt > 0 && strings.TrimSpace(result[insertAt-1]) == "" {
+		insertAt--
+	}
+	for i := len(result) - 1; i >= 0; i-- {
+		if m := keyPattern.FindStringSubmatch(result[i]); m != nil && written[m[1]] {
+			insertAt = i + 1
+			break
+		}
+	}
+	var added []string
+	for _, key := range sortedEnvKeys(envVars) {
+		if !written[key] {
+			added = append(added, format(key, envVars[key]))
+		}
+	}
+	if firstWritten == -1 || insertAt <= firstWritten {
+		firstWritten = insertAt
 	}
+	result = append(result[:insertAt], append(added, result[insertAt:]...)...)
 
-	if err := os.WriteFile(envrcFile, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write .envrc: %w", err)
+	var recorded []string
+	for _, key := range sortedEnvKeys(envVars) {
+		if !ccpEnvKeys[key] {
+			recorded = append(recorded, key)
+		}
+	}
+	if len(recorded) > 0 {
+		marker := envKeysMarker + " " + strings.Join(recorded, " ")
+		result = append(result[:firstWritten], append([]string{marker}, result[firstWritten:]...)...)
 	}
+	return result
+}
 
-	fmt.Printf("Updated .envrc with Claude env vars\n")
-	fmt.Println("Run 'direnv allow' to apply changes")
-	return nil
+// sortedEnvKeys returns the names in envVars, CLAUDE_CONFIG_DIR first and
+// the rest sorted, so files are updated in a stable order
+func sortedEnvKeys(envVars map[string]string) []string {
+	keys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		if key != "CLAUDE_CONFIG_DIR" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if _, ok := envVars["CLAUDE_CONFIG_DIR"]; ok {
+		keys = append([]string{"CLAUDE_CONFIG_DIR"}, keys...)
+	}
+	return keys
 }
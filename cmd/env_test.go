@@ -0,0 +1,80 @@
+package cmd
+
+import "testing"
+
+func TestUpdateMiseContent(t *testing.T) {
+	content := `[tools]
+go = "1.22"
+PROFILE = "tools-table"
+
+[env]
+AWS_PROFILE = "mine"
+ANTHROPIC_MODEL = "opus"
+
+[tasks.build]
+run = "go build"
+`
+	got := updateMiseContent(content, map[string]string{
+		"CLAUDE_CONFIG_DIR":       "/p/work",
+		"PROFILE":                 "work",
+		"CLAUDE_CODE_USE_BEDROCK": "1",
+	})
+	want := `[tools]
+go = "1.22"
+PROFILE = "tools-table"
+
+[env]
+AWS_PROFILE = "mine"
+ANTHROPIC_MODEL = "opus"
+# ccp-env: CLAUDE_CODE_USE_BEDROCK PROFILE
+CLAUDE_CONFIG_DIR = "/p/work"
+CLAUDE_CODE_USE_BEDROCK = "1"
+PROFILE = "work"
+
+[tasks.build]
+run = "go build"
+`
+	if got != want {
+		t.Errorf("first update:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Switching to a profile without them removes the previous profile's keys
+	got = updateMiseContent(got, map[string]string{"CLAUDE_CONFIG_DIR": "/p/home"})
+	want = `[tools]
+go = "1.22"
+PROFILE = "tools-table"
+
+[env]
+AWS_PROFILE = "mine"
+ANTHROPIC_MODEL = "opus"
+CLAUDE_CONFIG_DIR = "/p/home"
+
+[tasks.build]
+run = "go build"
+`
+	if got != want {
+		t.Errorf("switch update:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := updateMiseContent("", map[string]string{"CLAUDE_CONFIG_DIR": "/p"}); got != "[env]\nCLAUDE_CONFIG_DIR = \"/p\"\n" {
+		t.Errorf("new file = %q", got)
+	}
+}
+
+func TestUpdateEnvrcContent(t *testing.T) {
+	content := "export AWS_PROFILE=\"mine\"\nexport CLAUDE_CONFIG_DIR=\"/p/old\"\nuse flake\n"
+	got := updateEnvrcContent(content, map[string]string{
+		"CLAUDE_CONFIG_DIR": "/p/work",
+		"PROFILE":           "work",
+	})
+	want := "export AWS_PROFILE=\"mine\"\n# ccp-env: PROFILE\nexport CLAUDE_CONFIG_DIR=\"/p/work\"\nexport PROFILE=\"work\"\nuse flake\n"
+	if got != want {
+		t.Errorf("first update = %q, want %q", got, want)
+	}
+
+	got = updateEnvrcContent(got, map[string]string{"CLAUDE_CONFIG_DIR": "/p/home"})
+	want = "export AWS_PROFILE=\"mine\"\nexport CLAUDE_CONFIG_DIR=\"/p/home\"\nuse flake\n"
+	if got != want {
+		t.Errorf("switch update = %q, want %q", got, want)
+	}
+}
@@ -67,7 +67,7 @@ func runHubList(cmd *cobra.Command, args []string) error {
 			}
 		}
 		if !valid {
-			return fmt.Errorf("invalid type: %s (valid: skills, agents, hooks, rules, commands)", args[0])
+			return fmt.Errorf("invalid type: %s (valid: skills, agents, hooks, rules, commands, output-styles, statuslines, memory)", args[0])
 		}
 		typesToShow = []config.HubItemType{itemType}
 	} else {
@@ -87,7 +87,7 @@ func runHubAdd(cmd *cobra.Command, args []string) error {
 		// Two args: Original behavior (type + name-or-path)
 		itemType := config.HubItemType(args[0])
 		if !isValidHubType(itemType) {
-			return fmt.Errorf("invalid type: %s (valid: skills, agents, hooks, rules, commands)", args[0])
+			return fmt.Errorf("invalid type: %s (valid: skills, agents, hooks, rules, commands, output-styles, statuslines, memory)", args[0])
 		}
 		if hubAddFromProfile != "" {
 			return runHubAddFromProfile(paths, itemType, args[1])
@@ -160,6 +160,8 @@ func runHubAddFromProfile(paths *config.Paths, itemType config.HubItemType, item
 	}
 
 	fmt.Printf("Added %s/%s to hub from profile '%s'\n", itemType, itemName, hubAddFromProfile)
+	refreshSearchIndex(paths)
+	reportLint(paths, []string{string(itemType) + "/" + itemName})
 
 	// Offer to replace profile item with symlink
 	fmt.Printf("\nTo link this item back to the profile, run:\n")
@@ -209,6 +211,8 @@ func runHubAddFromPath(paths *config.Paths, itemType config.HubItemType, srcPath
 	}
 
 	fmt.Printf("Added %s/%s\n", itemType, itemName)
+	refreshSearchIndex(paths)
+	reportLint(paths, []string{string(itemType) + "/" + itemName})
 	return nil
 }
 
@@ -421,6 +425,7 @@ func runInteractiveHubAdd(paths *config.Paths, profileName string) error {
 	}
 
 	// Promote selected items
+	var promoted []string
 	needsSettingsRegen := false
 	for _, itemType := range config.AllHubItemTypes() {
 		items, ok := selections[string(itemType)]
@@ -434,6 +439,7 @@ func runInteractiveHubAdd(paths *config.Paths, profileName string) error {
 				continue
 			}
 			fmt.Printf("Promoted %s/%s to hub\n", itemType, itemName)
+			promoted = append(promoted, string(itemType)+"/"+itemName)
 		}
 
 		// Track if settings regeneration is needed
@@ -456,6 +462,7 @@ func runInteractiveHubAdd(paths *config.Paths, profileName string) error {
 	}
 
 	fmt.Printf("\nPromoted %d item(s) to hub from profile '%s'\n", totalAdded, profileName)
+	reportLint(paths, promoted)
 	return nil
 }
 
@@ -588,9 +595,9 @@ func promoteToHub(paths *config.Paths, p *profile.Profile, itemType config.HubIt
 		return fmt.Errorf("failed to remove original item: %w", err)
 	}
 
-	// Create symlink: profile -> hub
-	if err := symMgr.Create(profileItemPath, hubItemPath); err != nil {
-		return fmt.Errorf("failed to create symlink: %w", err)
+	// Create symlink (or copy): profile -> hub
+	if err := profile.MaterializeItem(p.Path, p.Manifest, profileItemPath, hubItemPath); err != nil {
+		return fmt.Errorf("failed to %s item: %w", materializeVerb(p.Manifest), err)
 	}
 
 	// Update manifest to track the hub item
@@ -12,7 +12,6 @@ import (
 	"github.com/samhoang/ccp/internal/hub"
 	"github.com/samhoang/ccp/internal/picker"
 	"github.com/samhoang/ccp/internal/profile"
-	"github.com/samhoang/ccp/internal/symlink"
 )
 
 var hubLinkCmd = &cobra.Command{
@@ -201,8 +200,6 @@ func runInteractiveAddLink(paths *config.Paths, p *profile.Profile) error {
 }
 
 func syncAddedLinks(paths *config.Paths, p *profile.Profile, selections map[string][]string) error {
-	symMgr := symlink.New()
-
 	// Create symlinks for newly added items
 	for _, itemType := range config.AllHubItemTypes() {
 		itemTypeStr := string(itemType)
@@ -228,18 +225,25 @@ func syncAddedLinks(paths *config.Paths, p *profile.Profile, selections map[stri
 				continue
 			}
 
-			// Create symlink
-			if err := symMgr.Create(profileItemPath, hubItemPath); err != nil {
-				fmt.Printf("Warning: failed to create symlink for %s/%s: %v\n", itemType, itemName, err)
+			// Create symlink (or copy)
+			if err := profile.MaterializeItem(p.Path, p.Manifest, profileItemPath, hubItemPath); err != nil {
+				fmt.Printf("Warning: failed to %s %s/%s: %v\n", materializeVerb(p.Manifest), itemType, itemName, err)
 			}
 		}
 	}
 
-	// Regenerate settings.json if hooks were added
+	if err := renderProfileMemory(paths, p, ""); err != nil {
+		return err
+	}
+
+	// Regenerate settings.json if hooks or a statusline were added
 	hasHooks := false
 	if items, ok := selections["hooks"]; ok && len(items) > 0 {
 		hasHooks = true
 	}
+	if items, ok := selections["statuslines"]; ok && len(items) > 0 {
+		hasHooks = true
+	}
 
 	if hasHooks {
 		if err := profile.RegenerateSettings(paths, p.Path, p.Manifest); err != nil {
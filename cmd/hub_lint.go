@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+)
+
+var hubLintJSON bool
+
+var hubLintCmd = &cobra.Command{
+	Use:   "lint [type/name]",
+	Short: "Validate hub item frontmatter and structure",
+	Long: `Check hub items for problems Claude Code would silently ignore.
+
+Checks per item type:
+  skills              SKILL.md frontmatter: name, description, allowed-tools,
+                      model, name/directory mismatch, broken relative file links
+  agents              frontmatter: name, description, tools, model,
+                      name/file mismatch
+  commands            optional frontmatter: description, allowed-tools, model
+  rules               broken relative file links
+  hooks               hooks.json structure, event names, missing scripts
+  memory              single .md file, integer priority, broken relative links
+  output-styles       single .md file, description, broken relative links
+  statuslines         statusline layout, missing or non-executable scripts
+  settings-templates  settings.json present and valid JSON
+
+Exits non-zero when any error is found.
+
+Examples:
+  ccp hub lint                      # Lint the whole hub
+  ccp hub lint skills/my-skill      # Lint one item
+  ccp hub lint bundles/my-bundle    # Lint a bundle's members
+  ccp hub lint --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runHubLint,
+}
+
+func init() {
+	hubLintCmd.Flags().BoolVarP(&hubLintJSON, "json", "j", false, "Output diagnostics as JSON")
+	hubCmd.AddCommand(hubLintCmd)
+}
+
+func runHubLint(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	var diags []hub.Diagnostic
+	if len(args) == 0 {
+		h, err := hub.NewScanner().Scan(paths.HubDir)
+		if err != nil {
+			return fmt.Errorf("failed to scan hub: %w", err)
+		}
+		diags = hub.LintHub(h)
+	} else {
+		parts := strings.SplitN(args[0], "/", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid format: use <type>/<name>")
+		}
+		diags, err = lintHubItem(paths, config.HubItemType(parts[0]), parts[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	if hubLintJSON {
+		if diags == nil {
+			diags = []hub.Diagnostic{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diags); err != nil {
+			return err
+		}
+	} else {
+		printDiagnostics(diags)
+		if len(diags) == 0 {
+			fmt.Println("No problems found")
+		}
+	}
+
+	if hub.HasErrors(diags) {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("lint found errors")
+	}
+	return nil
+}
+
+// lintHubItem lints a single hub item or bundle by type and name
+func lintHubItem(paths *config.Paths, itemType config.HubItemType, itemName string) ([]hub.Diagnostic, error) {
+	if itemType == config.HubBundles {
+		b, err := hub.LoadBundle(paths.BundlesDir(), itemName)
+		if err != nil {
+			return nil, fmt.Errorf("bundle not found: %s", itemName)
+		}
+		return hub.LintBundle(paths.BundleDir(itemName), b), nil
+	}
+
+	if !isValidHubType(itemType) {
+		return nil, fmt.Errorf("invalid type: %s", itemType)
+	}
+
+	itemPath := resolveHubItemPath(paths, itemType, itemName)
+	if itemPath == "" {
+		return nil, fmt.Errorf("item not found: %s/%s", itemType, itemName)
+	}
+	if itemType == config.HubSettingsTemplates {
+		itemPath = filepath.Join(paths.HubItemDir(itemType), itemName)
+	}
+	info, err := os.Stat(itemPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return hub.LintItem(hub.Item{
+		Name:  itemName,
+		Type:  itemType,
+		Path:  itemPath,
+		IsDir: info.IsDir(),
+	}), nil
+}
+
+// printDiagnostics writes diagnostics one per line, followed by a summary
+func printDiagnostics(diags []hub.Diagnostic) {
+	errCount, warnCount := 0, 0
+	for _, d := range diags {
+		fmt.Println(d.String())
+		if d.Severity == hub.SeverityError {
+			errCount++
+		} else {
+			warnCount++
+		}
+	}
+	if len(diags) > 0 {
+		fmt.Printf("\n%d error(s), %d warning(s)\n", errCount, warnCount)
+	}
+}
+
+// reportLint lints freshly added hub items and prints any findings. Problems
+// are reported but never block the add/install that triggered them.
+func reportLint(paths *config.Paths, items []string) {
+	var diags []hub.Diagnostic
+	for _, item := range items {
+		parts := strings.SplitN(item, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		d, err := lintHubItem(paths, config.HubItemType(parts[0]), parts[1])
+		if err != nil {
+			continue
+		}
+		diags = append(diags, d...)
+	}
+	if len(diags) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Lint:")
+	printDiagnostics(diags)
+}
@@ -1,17 +1,25 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"io"
+	"net/http"
 	"os"
-	"os/exec"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/parallel"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+var (
+	hubOutdatedParallel int
+	hubOutdatedTimeout  time.Duration
 )
 
 var hubOutdatedCmd = &cobra.Command{
@@ -20,7 +28,11 @@ var hubOutdatedCmd = &cobra.Command{
 	Short:  "Show hub items with available updates",
 	Long: `Check hub items against their source repositories for updates.
 
-Compares the installed commit SHA against the latest commit on the remote branch.
+Asks each installed source's provider for its latest version (git ls-remote
+for any git host, ETag/Last-Modified or a checksum for HTTP archives) and
+compares it with the installed one. Items with a GitHub source.yaml are
+checked against their repository. Sources are checked concurrently; results
+are cached so 'ccp status' can show an updates badge without the network.
 
 Examples:
   ccp hub outdated                 # Check all items for updates`,
@@ -28,11 +40,23 @@ Examples:
 }
 
 func init() {
+	hubOutdatedCmd.Flags().IntVarP(&hubOutdatedParallel, "parallel", "j", parallel.DefaultWorkers, "Number of sources to check at once")
+	hubOutdatedCmd.Flags().DurationVar(&hubOutdatedTimeout, "timeout", time.Minute, "Time limit per source")
 	hubCmd.AddCommand(hubOutdatedCmd)
 }
 
+// outdatedTarget is one upstream to check: a registry source (id set) or a
+// GitHub repository shared by items with a source.yaml
+type outdatedTarget struct {
+	name string
+	id   string
+	src  source.Source
+}
+
 type outdatedItem struct {
-	item      hub.Item
+	itemType  string
+	name      string
+	source    string
 	localSHA  string
 	remoteSHA string
 }
@@ -53,57 +77,156 @@ func runHubOutdated(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to scan hub: %w", err)
 	}
 
-	// Find all items with source tracking
-	var checkable []hub.Item
-	var local []hub.Item
+	registry, err := source.LoadRegistry(paths.RegistryPath())
+	if err != nil {
+		return err
+	}
+
+	// Registry sources are checked once each; their installed items share
+	// the result. Items with a GitHub source.yaml are grouped by repo and ref.
+	var targets []outdatedTarget
+	for _, entry := range registry.ListSources() {
+		targets = append(targets, outdatedTarget{name: entry.ID, id: entry.ID, src: entry.Source})
+	}
 
+	installed := make(map[string]bool)
+	for _, entry := range registry.ListSources() {
+		for _, item := range entry.Source.Installed {
+			installed[item] = true
+		}
+	}
+	repoIndex := make(map[string]int)
+	var tracked []hub.Item
+	var local int
 	for _, item := range h.AllItems() {
-		if item.Source != nil && item.Source.Type == hub.SourceTypeGitHub && item.Source.GitHub != nil {
-			checkable = append(checkable, item)
-		} else if item.IsDir {
-			local = append(local, item)
+		if item.Source == nil || item.Source.Type != hub.SourceTypeGitHub || item.Source.GitHub == nil {
+			if item.IsDir {
+				local++
+			}
+			continue
 		}
+		if installed[string(item.Type)+"/"+item.Name] {
+			continue
+		}
+		gh := item.Source.GitHub
+		key := gh.RepoURL() + "@" + gh.Ref
+		if _, ok := repoIndex[key]; !ok {
+			name := gh.Owner + "/" + gh.Repo
+			if gh.Ref != "" {
+				name += "@" + gh.Ref
+			}
+			repoIndex[key] = len(targets)
+			targets = append(targets, outdatedTarget{
+				name: name,
+				src:  source.Source{Provider: "git", URL: gh.RepoURL(), Ref: gh.Ref},
+			})
+		}
+		tracked = append(tracked, item)
 	}
 
-	if len(checkable) == 0 {
-		fmt.Println("No items with GitHub source tracking found")
-		if len(local) > 0 {
-			fmt.Printf("  %d items without source tracking (manually added)\n", len(local))
+	if len(targets) == 0 {
+		fmt.Println("No sources or items with source tracking found")
+		if local > 0 {
+			fmt.Printf("  %d items without source tracking (manually added)\n", local)
 		}
 		return nil
 	}
 
-	fmt.Printf("Checking %d items for updates...\n\n", len(checkable))
+	fmt.Printf("Checking %d sources for updates...\n\n", len(targets))
+
+	latest := source.LoadLatestCache(paths.CacheDir())
+	infos := make([]*source.LatestInfo, len(targets))
+	tasks := make([]parallel.Task, len(targets))
+	for i, target := range targets {
+		tasks[i] = parallel.Task{
+			Name: target.name,
+			Run: func(ctx context.Context, report func(string)) (string, error) {
+				var info *source.LatestInfo
+				var err error
+				if target.id != "" {
+					// Always ask upstream (TTL 0), but leave the answer for 'ccp status'
+					info, err = latest.Check(ctx, target.id, target.src, 0)
+				} else {
+					info, err = source.GetProvider("git").CheckLatest(ctx, target.src)
+				}
+				if err != nil {
+					return "", err
+				}
+				infos[i] = info
+				if target.id != "" && info.UpdateAvailable {
+					return "update available: " + shortVersion(info.Latest), nil
+				}
+				return shortVersion(info.Latest), nil
+			},
+		}
+	}
+	outcomes := parallel.Run(context.Background(), tasks, sourceTaskOptions(hubOutdatedParallel, hubOutdatedTimeout))
+	fmt.Println()
+
+	if err := latest.Save(); err != nil {
+		fmt.Printf("Warning: failed to save update check cache: %v\n", err)
+	}
 
 	var outdated []outdatedItem
-	var upToDate []hub.Item
+	var upToDate int
 	var errors []string
+	var sourcesOutdated, itemsOutdated bool
+
+	for i, target := range targets {
+		if target.id == "" {
+			continue
+		}
+		items := target.src.Installed
+		if err := outcomes[i].Err; err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", target.id, err))
+			continue
+		}
+		if !infos[i].UpdateAvailable {
+			upToDate += len(items)
+			continue
+		}
+		sourcesOutdated = true
+		localVersion := infos[i].Current
+		if localVersion == "" {
+			localVersion = "(unknown)"
+		}
+		for _, item := range items {
+			itemType, name, _ := strings.Cut(item, "/")
+			outdated = append(outdated, outdatedItem{
+				itemType:  itemType,
+				name:      name,
+				source:    target.id,
+				localSHA:  localVersion,
+				remoteSHA: infos[i].Latest,
+			})
+		}
+	}
 
-	for _, item := range checkable {
-		src := item.Source.GitHub
-		remoteSHA, err := getRemoteCommit(src.Owner, src.Repo, src.Ref)
-		if err != nil {
+	for _, item := range tracked {
+		gh := item.Source.GitHub
+		i := repoIndex[gh.RepoURL()+"@"+gh.Ref]
+		if err := outcomes[i].Err; err != nil {
 			errors = append(errors, fmt.Sprintf("%s/%s: %v", item.Type, item.Name, err))
 			continue
 		}
+		remoteSHA := infos[i].Latest
 
-		localSHA := src.Commit
+		localSHA := gh.Commit
 		if localSHA == "" {
 			// No local commit tracked, consider it outdated
-			outdated = append(outdated, outdatedItem{
-				item:      item,
-				localSHA:  "(unknown)",
-				remoteSHA: remoteSHA,
-			})
-		} else if !strings.HasPrefix(remoteSHA, localSHA) && !strings.HasPrefix(localSHA, remoteSHA) {
-			outdated = append(outdated, outdatedItem{
-				item:      item,
-				localSHA:  localSHA,
-				remoteSHA: remoteSHA,
-			})
-		} else {
-			upToDate = append(upToDate, item)
+			localSHA = "(unknown)"
+		} else if strings.HasPrefix(remoteSHA, localSHA) || strings.HasPrefix(localSHA, remoteSHA) {
+			upToDate++
+			continue
 		}
+		itemsOutdated = true
+		outdated = append(outdated, outdatedItem{
+			itemType:  string(item.Type),
+			name:      item.Name,
+			source:    item.Source.SourceInfo(),
+			localSHA:  localSHA,
+			remoteSHA: remoteSHA,
+		})
 	}
 
 	// Display results
@@ -113,24 +236,27 @@ func runHubOutdated(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(w, "TYPE\tNAME\tSOURCE\tLOCAL\tREMOTE\n")
 		fmt.Fprintf(w, "----\t----\t------\t-----\t------\n")
 		for _, o := range outdated {
-			localShort := shortenSHA(o.localSHA)
-			remoteShort := shortenSHA(o.remoteSHA)
 			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-				o.item.Type, o.item.Name, o.item.Source.SourceInfo(), localShort, remoteShort)
+				o.itemType, o.name, o.source, shortVersion(o.localSHA), shortVersion(o.remoteSHA))
 		}
 		w.Flush()
 		fmt.Println()
-		fmt.Printf("Run 'ccp hub update --all' to update all items\n")
+		if sourcesOutdated {
+			fmt.Printf("Run 'ccp source update' to update sources\n")
+		}
+		if itemsOutdated {
+			fmt.Printf("Run 'ccp hub update --all' to update all items\n")
+		}
 	} else {
 		fmt.Println("All items are up to date")
 	}
 
-	if len(upToDate) > 0 && len(outdated) > 0 {
-		fmt.Printf("\n%d items up to date\n", len(upToDate))
+	if upToDate > 0 && len(outdated) > 0 {
+		fmt.Printf("\n%d items up to date\n", upToDate)
 	}
 
 	if len(errors) > 0 {
-		fmt.Printf("\n%d items could not be checked:\n", len(errors))
+		fmt.Printf("\n%d could not be checked:\n", len(errors))
 		for _, e := range errors {
 			fmt.Printf("  - %s\n", e)
 		}
@@ -139,28 +265,23 @@ func runHubOutdated(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-// getRemoteCommit fetches the latest commit SHA for a GitHub repo
-func getRemoteCommit(owner, repo, ref string) (string, error) {
-	if ref == "" {
-		ref = "HEAD"
-	}
-
-	repoURL := fmt.Sprintf("https://github.com/%s/%s.git", owner, repo)
-	cmd := exec.Command("git", "ls-remote", repoURL, ref)
-	cmd.Stderr = io.Discard
-
-	output, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch remote: %w", err)
-	}
-
-	// Output format: "<sha>\t<ref>"
-	parts := strings.Fields(string(output))
-	if len(parts) < 1 {
-		return "", fmt.Errorf("no commit found for ref: %s", ref)
+// shortVersion abbreviates a commit SHA or an HTTP version from
+// HTTPProvider.CheckLatest for display
+func shortVersion(version string) string {
+	switch {
+	case strings.HasPrefix(version, "sha256:"):
+		return shortenSHA(strings.TrimPrefix(version, "sha256:"))
+	case strings.HasPrefix(version, "etag:"):
+		etag := strings.Trim(strings.TrimPrefix(strings.TrimPrefix(version, "etag:"), "W/"), `"`)
+		return shortenSHA(etag)
+	case strings.HasPrefix(version, "modified:"):
+		modified := strings.TrimPrefix(version, "modified:")
+		if t, err := http.ParseTime(modified); err == nil {
+			return t.Format("2006-01-02")
+		}
+		return modified
 	}
-
-	return parts[0], nil
+	return shortenSHA(version)
 }
 
 // shortenSHA returns first 7 chars of a SHA
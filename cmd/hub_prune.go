@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -14,29 +15,42 @@ import (
 	"github.com/samhoang/ccp/internal/hub"
 	"github.com/samhoang/ccp/internal/picker"
 	"github.com/samhoang/ccp/internal/profile"
+	"github.com/samhoang/ccp/internal/refs"
+	"github.com/samhoang/ccp/internal/stats"
 )
 
 var (
 	pruneForce       bool
 	pruneInteractive bool
 	pruneType        string
+	pruneUnused      bool
 )
 
 var hubPruneCmd = &cobra.Command{
 	Use:    "prune",
 	Hidden: true,
 	Short:  "Remove unused hub items",
-	Long: `Remove hub items that are not used by any profile.
+	Long: `Remove hub items that nothing references.
+
+An item is kept while a profile links it, a bundle was built from it, a
+project received it through 'ccp project add', a source lists it as
+installed, or a plugin lists it as a component. Items only referenced
+outside profiles are listed with the reason instead of being pruned;
+'ccp hub why <type/name>' explains every reference.
 
 By default, shows a list of orphaned items and asks for confirmation.
 Use --interactive (-i) to select which items to remove.
 Use --force (-f) to remove all orphaned items without confirmation.
+Use --unused to also list linked items that session transcripts show
+were never invoked (see 'ccp stats'). These are suggestions only: unlink
+them first and a later prune will remove them.
 
 Examples:
   ccp hub prune                  # Show orphans, confirm removal
   ccp hub prune -i               # Interactive selection
   ccp hub prune -f               # Remove all orphans without confirmation
-  ccp hub prune --type=skills    # Only prune skills`,
+  ccp hub prune --type=skills    # Only prune skills
+  ccp hub prune --unused         # Also suggest linked-but-unused items`,
 	RunE: runHubPrune,
 }
 
@@ -44,6 +58,7 @@ func init() {
 	hubPruneCmd.Flags().BoolVarP(&pruneForce, "force", "f", false, "Remove all orphaned items without confirmation")
 	hubPruneCmd.Flags().BoolVarP(&pruneInteractive, "interactive", "i", false, "Interactively select items to remove")
 	hubPruneCmd.Flags().StringVar(&pruneType, "type", "", "Only prune specific type (skills, agents, hooks, rules, commands)")
+	hubPruneCmd.Flags().BoolVar(&pruneUnused, "unused", false, "Also suggest linked items never used in sessions")
 	hubCmd.AddCommand(hubPruneCmd)
 }
 
@@ -64,69 +79,51 @@ func runHubPrune(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to scan hub: %w", err)
 	}
 
-	// Build usage map
-	usage := make(map[string][]string)
-
-	// Initialize with all hub items
-	for _, item := range h.AllItems() {
-		key := fmt.Sprintf("%s/%s", item.Type, item.Name)
-		usage[key] = []string{}
-	}
-
-	// Scan profiles for usage
-	entries, err := os.ReadDir(paths.ProfilesDir)
+	graph, err := refs.Build(paths)
 	if err != nil {
-		return fmt.Errorf("failed to read profiles: %w", err)
+		return err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() || entry.Name() == "shared" {
+	// Orphans have no references at all; items referenced only by bundles,
+	// projects, sources or plugins are kept and reported with the reason.
+	protected, _ := loadProtectedItems(paths)
+	var orphans, kept []string
+	var protectedCount int
+	for _, item := range h.AllItems() {
+		key := fmt.Sprintf("%s/%s", item.Type, item.Name)
+		if pruneType != "" && string(item.Type) != pruneType {
 			continue
 		}
-
-		profileName := entry.Name()
-		profileDir := filepath.Join(paths.ProfilesDir, profileName)
-		manifestPath := profile.ManifestPath(profileDir)
-
-		manifest, err := profile.LoadManifest(manifestPath)
-		if err != nil {
+		if len(graph.Names(key, refs.KindProfile)) > 0 {
 			continue
 		}
-
-		for _, itemType := range config.AllHubItemTypes() {
-			items := manifest.GetHubItems(itemType)
-			for _, itemName := range items {
-				key := fmt.Sprintf("%s/%s", itemType, itemName)
-				if _, exists := usage[key]; exists {
-					usage[key] = append(usage[key], profileName)
-				}
-			}
+		if len(graph.Refs(key)) > 0 {
+			kept = append(kept, key)
+			continue
 		}
+		if protected[key] {
+			protectedCount++
+			continue
+		}
+		orphans = append(orphans, key)
 	}
 
-	// Find orphans (excluding protected items)
-	protected, _ := loadProtectedItems(paths)
-	var orphans []string
-	var protectedCount int
-	for key, profiles := range usage {
-		if len(profiles) == 0 {
-			// Skip protected items
-			if protected[key] {
-				protectedCount++
-				continue
-			}
-			// Filter by type if specified
-			if pruneType != "" {
-				parts := strings.SplitN(key, "/", 2)
-				if len(parts) == 2 && parts[0] != pruneType {
-					continue
-				}
-			}
-			orphans = append(orphans, key)
+	sort.Strings(kept)
+	sort.Strings(orphans)
+
+	if pruneUnused {
+		if err := printUnusedSuggestions(paths); err != nil {
+			fmt.Printf("Warning: %v\n", err)
 		}
 	}
 
-	sort.Strings(orphans)
+	if len(kept) > 0 {
+		fmt.Println("Not linked in any profile but still in use (see 'ccp hub why <type/name>'):")
+		for _, key := range kept {
+			fmt.Printf("  - %s (%s)\n", key, graph.Reason(key))
+		}
+		fmt.Println()
+	}
 
 	if len(orphans) == 0 {
 		if protectedCount > 0 {
@@ -218,3 +215,38 @@ func runHubPrune(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// printUnusedSuggestions lists items that have been linked for at least
+// stats.DefaultUnusedAfter without a recorded invocation
+func printUnusedSuggestions(paths *config.Paths) error {
+	profiles, err := profile.NewManager(paths).List()
+	if err != nil {
+		return fmt.Errorf("failed to list profiles: %w", err)
+	}
+	_, items, err := collectItemUsage(paths, profiles)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var unused []linkedItemUsage
+	for _, item := range items {
+		if pruneType != "" && string(item.Type) != pruneType {
+			continue
+		}
+		if item.Unused(stats.DefaultUnusedAfter, now) {
+			unused = append(unused, item)
+		}
+	}
+	if len(unused) == 0 {
+		return nil
+	}
+
+	fmt.Printf("Linked but never used in sessions for %d+ days:\n", int(stats.DefaultUnusedAfter/(24*time.Hour)))
+	for _, item := range unused {
+		fmt.Printf("  - %s (in %s)\n", item.Key(), strings.Join(item.Profiles, ", "))
+	}
+	fmt.Println("Unlink with 'ccp unlink <profile> <type/name>'; prune removes them once orphaned.")
+	fmt.Println()
+	return nil
+}
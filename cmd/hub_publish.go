@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+var (
+	hubPublishTo      string
+	hubPublishBranch  string
+	hubPublishMessage string
+	hubPublishPlugin  string
+	hubPublishPush    bool
+)
+
+var hubPublishCmd = &cobra.Command{
+	Use:   "publish <type/name>",
+	Short: "Publish a hub item or bundle back to a git source",
+	Long: `Copy a hub item into the checkout of an installed git source and commit it.
+
+The item goes where the source's layout expects it: an item the source
+already provides is overwritten in place, a new one lands in the type
+directory declared by plugin.json, the marketplace plugin (choose with
+--plugin when there are several) or <type>/ at the root. Explicit component
+lists in plugin.json and marketplace.json are extended when they do not
+already cover the item. Bundles (bundles/<name>) are published as a plugin
+under plugins/<name>/ and added to marketplace.json when present.
+
+The commit is made on a branch (default ccp/publish-<type>-<name>) with your
+git identity; the checkout is then switched back so 'ccp source update'
+keeps tracking upstream. --push pushes the branch to origin.
+
+Without --to, the item is published to the source it was installed from.
+
+Examples:
+  ccp hub publish skills/debugging --to me/skills
+  ccp hub publish agents/reviewer.md --to me/agents --push
+  ccp hub publish bundles/design --to me/marketplace --branch design-v2`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeHubItems,
+	RunE:              runHubPublish,
+}
+
+func init() {
+	hubPublishCmd.Flags().StringVar(&hubPublishTo, "to", "", "Source to publish to (default: the source the item came from)")
+	hubPublishCmd.Flags().StringVar(&hubPublishBranch, "branch", "", "Branch to commit on")
+	hubPublishCmd.Flags().StringVarP(&hubPublishMessage, "message", "m", "", "Commit message")
+	hubPublishCmd.Flags().StringVar(&hubPublishPlugin, "plugin", "", "Marketplace plugin to publish into")
+	hubPublishCmd.Flags().BoolVar(&hubPublishPush, "push", false, "Push the branch to origin")
+	hubCmd.AddCommand(hubPublishCmd)
+}
+
+func runHubPublish(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	parts := strings.SplitN(args[0], "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid item path: %s (expected type/name)", args[0])
+	}
+	itemType := config.HubItemType(parts[0])
+	itemName := parts[1]
+
+	registry, err := source.LoadRegistry(paths.RegistryPath())
+	if err != nil {
+		return err
+	}
+	sourceID := hubPublishTo
+	if sourceID == "" {
+		entry := registry.FindSourceByItem(args[0])
+		if entry == nil {
+			return fmt.Errorf("%s was not installed from a source: choose one with --to", args[0])
+		}
+		sourceID = entry.ID
+	}
+
+	opts := source.PublishOptions{
+		Branch:  hubPublishBranch,
+		Message: hubPublishMessage,
+		Plugin:  hubPublishPlugin,
+		Push:    hubPublishPush,
+	}
+	publisher := source.NewPublisher(paths, registry)
+
+	var result *source.PublishResult
+	if itemType == config.HubBundles {
+		bundle, err := hub.LoadBundle(paths.BundlesDir(), itemName)
+		if err != nil {
+			return fmt.Errorf("bundle not found: %s", itemName)
+		}
+		fmt.Printf("Publishing bundle %s to %s...\n", itemName, sourceID)
+		result, err = publisher.PublishBundle(sourceID, bundle.Name, bundle.Description, bundle.Version, paths.BundleDir(itemName), opts)
+		if err != nil {
+			return err
+		}
+	} else {
+		if !isValidHubType(itemType) {
+			return fmt.Errorf("invalid type: %s", parts[0])
+		}
+		h, err := hub.NewScanner().Scan(paths.HubDir)
+		if err != nil {
+			return fmt.Errorf("failed to scan hub: %w", err)
+		}
+		item := h.GetItem(itemType, itemName)
+		if item == nil {
+			return fmt.Errorf("item not found: %s", args[0])
+		}
+		fmt.Printf("Publishing %s to %s...\n", args[0], sourceID)
+		result, err = publisher.Publish(sourceID, args[0], item.Path, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if result.Commit == "" {
+		fmt.Printf("  %s is already identical in %s, nothing to commit\n", result.Dest, sourceID)
+		return nil
+	}
+	fmt.Printf("  Copied to %s\n", result.Dest)
+	for _, manifest := range result.Manifests {
+		fmt.Printf("  Updated %s\n", manifest)
+	}
+	fmt.Printf("  Committed %s on branch %s\n", shortenSHA(result.Commit), result.Branch)
+	if result.Pushed {
+		fmt.Printf("  Pushed %s to origin\n", result.Branch)
+	} else {
+		fmt.Printf("\nPush with: git -C %s push -u origin %s\n", paths.SourceDir(sourceID), result.Branch)
+	}
+	return nil
+}
@@ -186,6 +186,7 @@ func removeHubItem(paths *config.Paths, itemType config.HubItemType, itemName st
 	}
 
 	fmt.Printf("Removed %s/%s\n", itemType, itemName)
+	refreshSearchIndex(paths)
 	return nil
 }
 
@@ -261,7 +262,11 @@ func copyHubItemToProfiles(paths *config.Paths, itemType config.HubItemType, ite
 					return fmt.Errorf("failed to remove symlink in profile %s: %w", profileName, err)
 				}
 			} else {
-				// Already a local copy, skip
+				// Already a local copy (or a copy-mode copy, which the
+				// profile now owns), skip
+				if err := profile.DisownItem(profileDir, profileItemPath); err != nil {
+					return fmt.Errorf("failed to update profile %s: %w", profileName, err)
+				}
 				fmt.Printf("  Skipped profile '%s' — already has local %s/%s\n", profileName, itemType, itemName)
 				continue
 			}
@@ -290,12 +295,17 @@ func copyHubItemToProfiles(paths *config.Paths, itemType config.HubItemType, ite
 			return fmt.Errorf("failed to save manifest for profile %s: %w", profileName, err)
 		}
 
-		// Regenerate settings if hooks changed
-		if itemType == config.HubHooks {
+		// Regenerate settings if hooks or the statusline changed
+		if itemType == config.HubHooks || itemType == config.HubStatuslines {
 			if err := profile.RegenerateSettings(paths, profileDir, manifest); err != nil {
 				fmt.Printf("  Warning: failed to regenerate settings for profile %s: %v\n", profileName, err)
 			}
 		}
+		if itemType == config.HubMemory {
+			if _, err := profile.RenderMemory(paths, profileDir, manifest); err != nil {
+				fmt.Printf("  Warning: failed to render %s for profile %s: %v\n", profile.MemoryFile, profileName, err)
+			}
+		}
 
 		fmt.Printf("  Copied %s/%s → profile '%s' (now local)\n", itemType, itemName, profileName)
 	}
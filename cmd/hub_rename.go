@@ -10,7 +10,6 @@ import (
 
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/profile"
-	"github.com/samhoang/ccp/internal/symlink"
 )
 
 var hubRenameCmd = &cobra.Command{
@@ -78,27 +77,28 @@ func runHubRename(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to rename: %w", err)
 	}
 
-	// Update profile symlinks and manifests
-	symMgr := symlink.New()
+	// Update profile symlinks (or copies) and manifests
 	for _, profileName := range profilesToUpdate {
 		profileDir := paths.ProfileDir(profileName)
 		manifestPath := profile.ManifestPath(profileDir)
 
+		manifest, err := profile.LoadManifest(manifestPath)
+		if err != nil {
+			continue
+		}
+
 		// Update symlink
 		oldLink := filepath.Join(profileDir, string(itemType), oldName)
 		newLink := filepath.Join(profileDir, string(itemType), newName)
 
+		profile.RemoveItem(profileDir, oldLink)
 		os.Remove(oldLink)
-		if err := symMgr.Create(newLink, newPath); err != nil {
-			fmt.Printf("Warning: failed to update symlink in profile %s: %v\n", profileName, err)
+		if err := profile.MaterializeItem(profileDir, manifest, newLink, newPath); err != nil {
+			fmt.Printf("Warning: failed to update %s/%s in profile %s: %v\n", itemType, newName, profileName, err)
 			continue
 		}
 
 		// Update manifest
-		manifest, err := profile.LoadManifest(manifestPath)
-		if err != nil {
-			continue
-		}
 
 		items := manifest.GetHubItems(itemType)
 		for i, item := range items {
@@ -114,6 +114,7 @@ func runHubRename(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("Renamed %s/%s -> %s/%s\n", itemType, oldName, itemType, newName)
+	refreshSearchIndex(paths)
 	if len(profilesToUpdate) > 0 {
 		fmt.Printf("Updated profiles: %s\n", strings.Join(profilesToUpdate, ", "))
 	}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+)
+
+var (
+	hubSearchType  string
+	hubSearchLimit int
+	hubSearchJSON  bool
+)
+
+var hubSearchCmd = &cobra.Command{
+	Use:     "search <query>",
+	Aliases: []string{"grep"},
+	Short:   "Full-text search across hub item contents",
+	Long: `Search hub items by name, frontmatter description and body text.
+
+Terms are matched exactly, by prefix, or fuzzily (small typos), and every
+term must match. Results are ranked: name hits outrank description hits,
+which outrank body hits.
+
+The index lives in ~/.ccp/cache/search-index.json and is refreshed
+incrementally — only items whose files changed are reindexed.
+
+Examples:
+  ccp hub search terraform plan
+  ccp hub search "code review" --type agents
+  ccp hub search kubernets            # fuzzy: matches kubernetes`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runHubSearch,
+}
+
+func init() {
+	hubSearchCmd.Flags().StringVarP(&hubSearchType, "type", "t", "", "Only search one item type")
+	hubSearchCmd.Flags().IntVarP(&hubSearchLimit, "limit", "n", 20, "Maximum number of results (0 = all)")
+	hubSearchCmd.Flags().BoolVarP(&hubSearchJSON, "json", "j", false, "Output as JSON")
+	hubCmd.AddCommand(hubSearchCmd)
+}
+
+func runHubSearch(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	opts := hub.SearchOptions{Limit: hubSearchLimit}
+	if hubSearchType != "" {
+		itemType := config.HubItemType(hubSearchType)
+		if !isValidHubType(itemType) && itemType != config.HubBundles {
+			return fmt.Errorf("invalid type: %s (valid: skills, agents, hooks, rules, commands, bundles)", hubSearchType)
+		}
+		opts.Types = []config.HubItemType{itemType}
+	}
+
+	idx, err := loadSearchIndex(paths)
+	if err != nil {
+		return err
+	}
+
+	results := idx.Search(strings.Join(args, " "), opts)
+
+	if hubSearchJSON {
+		if results == nil {
+			results = []hub.SearchResult{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No matching items")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%.1f\n", r.Key, r.Score)
+		if r.Snippet != "" {
+			if r.Line > 0 {
+				fmt.Fprintf(w, "  %d: %s\t\n", r.Line, r.Snippet)
+			} else {
+				fmt.Fprintf(w, "  %s\t\n", r.Snippet)
+			}
+		}
+	}
+	return w.Flush()
+}
+
+// loadSearchIndex loads the hub search index and brings it up to date,
+// saving it back only when something changed
+func loadSearchIndex(paths *config.Paths) (*hub.SearchIndex, error) {
+	h, err := hub.NewScanner().Scan(paths.HubDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan hub: %w", err)
+	}
+
+	idx := hub.LoadSearchIndex(paths.SearchIndexPath())
+	if idx.Refresh(h) {
+		if err := idx.Save(paths.SearchIndexPath()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save search index: %v\n", err)
+		}
+	}
+	return idx, nil
+}
+
+// refreshSearchIndex updates the search index after a hub mutation. Failures
+// are non-fatal: the next search rebuilds whatever is stale.
+func refreshSearchIndex(paths *config.Paths) {
+	loadSearchIndex(paths)
+}
+
+// hubItemDescriptions returns frontmatter descriptions keyed by "type/name",
+// served from the search index so pickers don't parse every item file
+func hubItemDescriptions(paths *config.Paths) map[string]string {
+	idx, err := loadSearchIndex(paths)
+	if err != nil {
+		return nil
+	}
+	descs := make(map[string]string, len(idx.Docs))
+	for key, doc := range idx.Docs {
+		if doc.Description != "" {
+			descs[key] = doc.Description
+		}
+	}
+	return descs
+}
@@ -75,13 +75,15 @@ func runHubShowInteractive(paths *config.Paths) error {
 	}
 
 	// Build flat list of all items for single-select picker
+	annotations := loadHubItemAnnotations(paths, h)
 	var items []picker.Item
 	for _, itemType := range config.AllHubItemTypes() {
 		for _, item := range h.GetItems(itemType) {
-			items = append(items, picker.Item{
-				ID:    fmt.Sprintf("%s/%s", itemType, item.Name),
-				Label: fmt.Sprintf("%s/%s", itemType, item.Name),
-			})
+			key := fmt.Sprintf("%s/%s", itemType, item.Name)
+			pi := annotations.annotate(item)
+			pi.ID = key
+			pi.Label = key
+			items = append(items, pi)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -21,6 +22,10 @@ var (
 	hubUpdateDryRun bool
 )
 
+// errAlreadyUpToDate is returned by updateItem when the upstream commit has
+// not moved
+var errAlreadyUpToDate = errors.New("already up to date")
+
 var hubUpdateCmd = &cobra.Command{
 	Use:   "update [<type>/<name>]",
 	Short: "Update hub items from their source repositories",
@@ -204,7 +209,7 @@ func updateFromGitHub(paths *config.Paths, item hub.Item) error {
 
 	// Check if already up to date
 	if src.Commit != "" && src.Commit == newCommit {
-		return fmt.Errorf("already up to date")
+		return errAlreadyUpToDate
 	}
 
 	// Find source directory in repo
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/refs"
+)
+
+var hubWhyCmd = &cobra.Command{
+	Use:   "why <type/name>",
+	Short: "Explain what keeps a hub item in use",
+	Long: `List every reference to a hub item: profiles linking it, bundles built
+from it, projects it was copied into with 'ccp project add', sources that
+installed it and plugins that list it as a component.
+
+An item without references is what 'ccp hub prune' removes.
+
+Examples:
+  ccp hub why skills/debugging
+  ccp hub why bundles/design`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeHubItems,
+	RunE:              runHubWhy,
+}
+
+func init() {
+	hubCmd.AddCommand(hubWhyCmd)
+}
+
+func runHubWhy(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	key := strings.TrimSuffix(args[0], "/")
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid item path: %s (expected type/name)", args[0])
+	}
+	itemType := config.HubItemType(parts[0])
+	if itemType != config.HubBundles && !isValidHubType(itemType) {
+		return fmt.Errorf("invalid type: %s", parts[0])
+	}
+
+	graph, err := refs.Build(paths)
+	if err != nil {
+		return err
+	}
+	references := graph.Refs(key)
+
+	_, statErr := os.Stat(paths.HubItemPath(itemType, parts[1]))
+	exists := statErr == nil
+	if !exists && len(references) == 0 {
+		return fmt.Errorf("item not found: %s", key)
+	}
+
+	if !exists {
+		fmt.Printf("%s is not in the hub, but is still referenced:\n", key)
+	} else if len(references) == 0 {
+		fmt.Printf("%s is not referenced by anything.\n", key)
+		protected, _ := loadProtectedItems(paths)
+		if protected[key] {
+			fmt.Println("It is protected, so 'ccp hub prune' keeps it.")
+		} else {
+			fmt.Println("'ccp hub prune' would remove it.")
+		}
+		return nil
+	} else {
+		fmt.Printf("%s is referenced by:\n", key)
+	}
+
+	for _, ref := range references {
+		fmt.Printf("  %-8s %s — %s\n", ref.Kind, ref.Name, describeRef(ref))
+	}
+	return nil
+}
+
+// describeRef explains what a reference of each kind means
+func describeRef(ref refs.Ref) string {
+	switch ref.Kind {
+	case refs.KindProfile:
+		return "linked in profile.toml"
+	case refs.KindBundle:
+		return "bundle member copied from it ('ccp bundle update' re-syncs from it)"
+	case refs.KindProject:
+		return "copied into the project by 'ccp project add'"
+	case refs.KindSource:
+		return "installed from this source"
+	case refs.KindPlugin:
+		return "component of this plugin"
+	}
+	return string(ref.Kind)
+}
@@ -3,16 +3,20 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/migration"
+	"github.com/samhoang/ccp/internal/picker"
 )
 
 var (
-	initDryRun bool
-	initForce  bool
+	initDryRun  bool
+	initForce   bool
+	initMerge   bool
+	initProfile string
 )
 
 var initCmd = &cobra.Command{
@@ -26,13 +30,28 @@ This command:
 3. Creates ~/.ccp/profiles/shared/ for shared data
 4. Replaces ~/.claude with a symlink to the active profile
 
-Settings are extracted as a reusable template that can be applied to other profiles.`,
+Settings are extracted as a reusable template that can be applied to other profiles.
+
+With --merge, a local ~/.claude is folded into an existing ~/.ccp instead
+(e.g. one synced from another machine). Each item is adopted into the hub,
+skipped when the hub already holds an identical copy, or renamed (name-2)
+when a different item has its name. The profile given by --profile is
+created or refreshed from the local settings, hooks outside ~/.claude are
+classified interactively, and the local ~/.claude is kept as a
+~/.claude.pre-merge-<time> backup.
+
+Examples:
+  ccp init                            # First-time setup
+  ccp init --merge --dry-run          # Show what a merge would do
+  ccp init --merge --profile laptop   # Merge into profile 'laptop'`,
 	RunE: runInit,
 }
 
 func init() {
 	initCmd.Flags().BoolVar(&initDryRun, "dry-run", false, "Show migration plan without executing")
 	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite existing hub structure")
+	initCmd.Flags().BoolVar(&initMerge, "merge", false, "Merge a local ~/.claude into an existing ~/.ccp")
+	initCmd.Flags().StringVar(&initProfile, "profile", "default", "Profile to create or refresh with --merge")
 	rootCmd.AddCommand(initCmd)
 }
 
@@ -54,6 +73,10 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("~/.claude directory not found\n\nPlease create a Claude Code configuration first by running claude")
 	}
 
+	if initMerge {
+		return runInitMerge(paths)
+	}
+
 	// Check if already initialized
 	if paths.IsInitialized() && !initForce {
 		return fmt.Errorf("ccp already initialized (~/.ccp exists)\n\nUse --force to reinitialize (this may cause data loss)")
@@ -137,6 +160,133 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runInitMerge folds a local ~/.claude into an already initialized ~/.ccp
+func runInitMerge(paths *config.Paths) error {
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' without --merge first")
+	}
+	if initProfile == "" || initProfile == "shared" || filepath.Base(initProfile) != initProfile {
+		return fmt.Errorf("invalid profile name: %q", initProfile)
+	}
+
+	migrator := migration.NewMigrator(paths)
+	plan, err := migrator.PlanMerge(initProfile)
+	if err != nil {
+		return fmt.Errorf("failed to create merge plan: %w", err)
+	}
+
+	fmt.Println("Merge Plan:")
+	fmt.Println()
+
+	counts := make(map[migration.MergeAction]int)
+	for _, item := range plan.Items {
+		counts[item.Action]++
+		switch item.Action {
+		case migration.MergeAdopt:
+			fmt.Printf("  + %s/%s\n", item.Type, item.Name)
+		case migration.MergeSkip:
+			fmt.Printf("  = %s/%s (identical to hub/%s/%s)\n", item.Type, item.Name, item.Type, item.HubName)
+		case migration.MergeRename:
+			fmt.Printf("  ~ %s/%s → %s (name taken by a different item)\n", item.Type, item.Name, item.HubName)
+		}
+	}
+	if len(plan.Items) == 0 {
+		fmt.Println("  No hub-eligible items found")
+	} else {
+		fmt.Printf("\n  %d to adopt, %d identical, %d renamed\n",
+			counts[migration.MergeAdopt], counts[migration.MergeSkip], counts[migration.MergeRename])
+	}
+
+	if plan.HookClassification != nil {
+		c := plan.HookClassification
+		fmt.Printf("  Hooks: %d inside ~/.claude, %d inline, %d outside\n", len(c.Inside), len(c.Inline), len(c.Outside))
+	}
+	if len(plan.SettingsTemplate) > 0 {
+		switch plan.TemplateAction {
+		case migration.MergeSkip:
+			fmt.Printf("  Settings template: reuse identical template '%s'\n", plan.TemplateName)
+		default:
+			fmt.Printf("  Settings template: %d keys (will be saved as '%s')\n", len(plan.SettingsTemplate), plan.TemplateName)
+		}
+	}
+	if plan.ProfileExists {
+		fmt.Printf("  Profile: refresh existing '%s'\n", plan.Profile)
+	} else {
+		fmt.Printf("  Profile: create '%s'\n", plan.Profile)
+	}
+	if len(plan.FilesToCopy) > 0 {
+		fmt.Printf("  Config files: %v\n", plan.FilesToCopy)
+	}
+	for _, f := range plan.FileConflicts {
+		fmt.Printf("  ⚠ %s differs from the profile's copy; the local one stays in the backup\n", f)
+	}
+	if len(plan.DataDirs) > 0 {
+		fmt.Printf("  Data directories (merged into shared): %v\n", plan.DataDirs)
+	}
+	if len(plan.Leftovers) > 0 {
+		fmt.Printf("  Other entries: %v\n", plan.Leftovers)
+	}
+	fmt.Printf("  Backup: %s\n", plan.BackupDir)
+	fmt.Println()
+
+	if initDryRun {
+		fmt.Println("Dry run - no changes made")
+		return nil
+	}
+
+	if hp := plan.HookMigrationPlan; hp != nil && len(hp.Decisions) > 0 {
+		items := make([]picker.HookMigrationItem, len(hp.Decisions))
+		for i, d := range hp.Decisions {
+			items[i] = picker.HookMigrationItem{
+				Name:       migration.GenerateHookName(d.Hook.ExtractedHook),
+				FilePath:   d.Hook.FilePath,
+				HookType:   d.Hook.HookType,
+				Matcher:    d.Hook.Matcher,
+				ParentDirs: d.Hook.ParentDirs,
+				Choice:     picker.HookMigrationCopy,
+			}
+		}
+		chosen, err := picker.RunHookMigrationPicker("Hooks outside ~/.claude", items)
+		if err != nil {
+			return fmt.Errorf("picker error: %w", err)
+		}
+		if chosen == nil {
+			fmt.Println("Merge cancelled")
+			return nil
+		}
+		for i, item := range chosen {
+			switch item.Choice {
+			case picker.HookMigrationSkip:
+				hp.Decisions[i].Choice = migration.HookChoiceSkip
+			case picker.HookMigrationKeep:
+				hp.Decisions[i].Choice = migration.HookChoiceKeep
+			default:
+				hp.Decisions[i].Choice = migration.HookChoiceCopy
+			}
+		}
+	}
+
+	fmt.Println("Executing merge...")
+	if err := migrator.ExecuteMerge(plan); err != nil {
+		return fmt.Errorf("merge failed: %w", err)
+	}
+
+	if err := os.MkdirAll(paths.StorePluginsDir(), 0755); err != nil {
+		fmt.Printf("Warning: could not create store structure: %v\n", err)
+	}
+
+	fmt.Println()
+	fmt.Println("Merge complete!")
+	fmt.Printf("  ~/.claude → %s\n", paths.ProfileDir(plan.Profile))
+	fmt.Printf("  Original kept at %s\n", plan.BackupDir)
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  1. Verify: ccp profile check %s\n", plan.Profile)
+	fmt.Printf("  2. Remove the backup once satisfied: rm -rf %s\n", plan.BackupDir)
+
+	return nil
+}
+
 // initConfigFile creates the default ccp.toml if it doesn't exist
 func initConfigFile(paths *config.Paths) error {
 	configPath := paths.CcpDir + "/ccp.toml"
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/parallel"
 )
 
 var installCmd = &cobra.Command{
@@ -14,7 +16,7 @@ If the package is not already added, it will be added automatically.
 If no items are specified, interactive selection is shown.
 
 When called without arguments, syncs all sources from ccp.toml:
-- Clones missing sources
+- Clones missing sources (concurrently, --parallel at a time)
 - Reinstalls items listed in registry
 
 Examples:
@@ -29,5 +31,7 @@ Examples:
 func init() {
 	installCmd.Flags().BoolVarP(&sourceInstallAll, "all", "a", false, "Install all available items")
 	installCmd.Flags().BoolVarP(&sourceInstallInteractive, "interactive", "i", false, "Interactive item selection")
+	installCmd.Flags().IntVarP(&sourceInstallParallel, "parallel", "j", parallel.DefaultWorkers, "Number of sources to sync at once")
+	installCmd.Flags().DurationVar(&sourceInstallTimeout, "timeout", defaultSourceTimeout, "Time limit per source when syncing")
 	rootCmd.AddCommand(installCmd)
 }
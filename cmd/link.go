@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,7 +13,6 @@ import (
 	"github.com/samhoang/ccp/internal/hub"
 	"github.com/samhoang/ccp/internal/picker"
 	"github.com/samhoang/ccp/internal/profile"
-	"github.com/samhoang/ccp/internal/symlink"
 )
 
 var linkCmd = &cobra.Command{
@@ -124,7 +124,7 @@ func runLink(cmd *cobra.Command, args []string) error {
 		}
 	}
 	if !valid {
-		return fmt.Errorf("invalid item type: %s (valid: skills, agents, hooks, rules, commands)", parts[0])
+		return fmt.Errorf("invalid item type: %s (valid: skills, agents, hooks, rules, commands, output-styles, statuslines, memory)", parts[0])
 	}
 
 	// Verify hub item exists
@@ -154,6 +154,7 @@ func runInteractiveLink(paths *config.Paths, p *profile.Profile) error {
 	if err != nil {
 		return fmt.Errorf("failed to scan hub: %w", err)
 	}
+	annotations := loadHubItemAnnotations(paths, h)
 
 	// Build tabs for the tabbed picker
 	var tabs []picker.Tab
@@ -171,11 +172,7 @@ func runInteractiveLink(paths *config.Paths, p *profile.Profile) error {
 		}
 
 		for _, item := range items {
-			pickerItems = append(pickerItems, picker.Item{
-				ID:       item.Name,
-				Label:    item.Name,
-				Selected: currentSelected[item.Name],
-			})
+			pickerItems = append(pickerItems, annotations.pickerItem(item, currentSelected[item.Name]))
 		}
 
 		tabs = append(tabs, picker.Tab{
@@ -225,9 +222,10 @@ func runInteractiveLink(paths *config.Paths, p *profile.Profile) error {
 }
 
 func syncLinkChanges(paths *config.Paths, p *profile.Profile) error {
-	symMgr := symlink.New()
+	// An unlinked statusline must be taken out of settings.json
+	statuslineRemoved := false
 
-	// Sync hub item symlinks
+	// Sync hub item symlinks (or copies)
 	for _, itemType := range config.AllHubItemTypes() {
 		itemDir := filepath.Join(p.Path, string(itemType))
 
@@ -250,11 +248,8 @@ func syncLinkChanges(paths *config.Paths, p *profile.Profile) error {
 
 		for _, entry := range entries {
 			if !manifestItems[entry.Name()] {
-				linkPath := filepath.Join(itemDir, entry.Name())
-				isLink, _ := symMgr.IsSymlink(linkPath)
-				if isLink {
-					os.Remove(linkPath)
-				}
+				profile.RemoveItem(p.Path, filepath.Join(itemDir, entry.Name()))
+				statuslineRemoved = statuslineRemoved || itemType == config.HubStatuslines
 			}
 		}
 
@@ -268,24 +263,20 @@ func syncLinkChanges(paths *config.Paths, p *profile.Profile) error {
 				continue
 			}
 
-			// Check if symlink already exists and is correct
-			isLink, _ := symMgr.IsSymlink(profileItemPath)
-			if isLink {
-				target, err := symMgr.ReadLink(profileItemPath)
-				if err == nil && target == hubItemPath {
-					continue
-				}
-				os.Remove(profileItemPath)
-			}
-
-			if err := symMgr.Create(profileItemPath, hubItemPath); err != nil {
-				fmt.Printf("Warning: failed to create symlink for %s/%s: %v\n", itemType, itemName, err)
+			if _, err := profile.EnsureItem(p.Path, p.Manifest, profileItemPath, hubItemPath, false); errors.Is(err, profile.ErrCopyModified) {
+				fmt.Printf("Kept %s/%s: %v\n", itemType, itemName, err)
+			} else if err != nil {
+				fmt.Printf("Warning: failed to %s %s/%s: %v\n", materializeVerb(p.Manifest), itemType, itemName, err)
 			}
 		}
 	}
 
-	// Regenerate settings.json for hooks
-	if len(p.Manifest.Hub.Hooks) > 0 {
+	if err := renderProfileMemory(paths, p, ""); err != nil {
+		return err
+	}
+
+	// Regenerate settings.json for hooks, statuslines and bundle settings
+	if len(p.Manifest.Hub.Hooks) > 0 || len(p.Manifest.Hub.Bundles) > 0 || len(p.Manifest.Hub.Statuslines) > 0 || statuslineRemoved {
 		if err := profile.RegenerateSettings(paths, p.Path, p.Manifest); err != nil {
 			return fmt.Errorf("failed to regenerate settings.json: %w", err)
 		}
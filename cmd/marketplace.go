@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var marketplaceCmd = &cobra.Command{
+	Use:   "marketplace",
+	Short: "Export hub items as a Claude Code plugin marketplace",
+	Long: `Generate a Claude Code plugin marketplace from hub items and bundles.
+
+Examples:
+  ccp marketplace build ./my-marketplace --all
+  ccp marketplace build ./design --bundle design`,
+}
+
+func init() {
+	rootCmd.AddCommand(marketplaceCmd)
+}
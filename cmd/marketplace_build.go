@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/picker"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+var (
+	marketplaceBuildName        string
+	marketplaceBuildOwner       string
+	marketplaceBuildDescription string
+	marketplaceBuildItems       []string
+	marketplaceBuildBundles     []string
+	marketplaceBuildAll         bool
+	marketplaceBuildPlugin      string
+	marketplaceBuildSplit       bool
+	marketplaceBuildForce       bool
+)
+
+var marketplaceBuildCmd = &cobra.Command{
+	Use:   "build <dir>",
+	Short: "Write hub items and bundles as a plugin marketplace",
+	Long: `Write a Claude Code plugin marketplace into <dir>.
+
+Every bundle becomes a plugin of the same name, with the bundle's description
+and version. Loose hub items are grouped into one plugin (named after the
+marketplace, or --plugin), or one plugin per item with --split. Hook items are
+wired into the plugin's hooks/hooks.json; rules are copied but only usable
+through ccp, since Claude Code plugins do not load them.
+
+Choose content with --item and --bundle, everything with --all, or pick
+interactively when neither is given. The result is what 'ccp source add'
+reads back, so a marketplace can be pushed to git and installed elsewhere.
+
+Examples:
+  ccp marketplace build ./out --all --name my-tools
+  ccp marketplace build ./out --bundle design --item skills/debugging
+  ccp marketplace build ./out --item agents/reviewer.md --split`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMarketplaceBuild,
+}
+
+func init() {
+	marketplaceBuildCmd.Flags().StringVar(&marketplaceBuildName, "name", "", "Marketplace name (default: directory name)")
+	marketplaceBuildCmd.Flags().StringVar(&marketplaceBuildOwner, "owner", "", "Marketplace owner (default: git user.name)")
+	marketplaceBuildCmd.Flags().StringVar(&marketplaceBuildDescription, "description", "", "Marketplace description")
+	marketplaceBuildCmd.Flags().StringArrayVar(&marketplaceBuildItems, "item", nil, "Hub item to include as type/name (repeatable)")
+	marketplaceBuildCmd.Flags().StringArrayVar(&marketplaceBuildBundles, "bundle", nil, "Bundle to include as a plugin (repeatable)")
+	marketplaceBuildCmd.Flags().BoolVar(&marketplaceBuildAll, "all", false, "Include every hub item and bundle")
+	marketplaceBuildCmd.Flags().StringVar(&marketplaceBuildPlugin, "plugin", "", "Plugin name for loose items (default: marketplace name)")
+	marketplaceBuildCmd.Flags().BoolVar(&marketplaceBuildSplit, "split", false, "Make one plugin per loose item")
+	marketplaceBuildCmd.Flags().BoolVarP(&marketplaceBuildForce, "force", "f", false, "Write into a non-empty directory")
+	marketplaceBuildCmd.RegisterFlagCompletionFunc("item", completeHubItems)
+	marketplaceCmd.AddCommand(marketplaceBuildCmd)
+}
+
+func runMarketplaceBuild(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	dir, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 && !marketplaceBuildForce {
+		return fmt.Errorf("%s is not empty: use --force to write into it", args[0])
+	}
+
+	h, err := hub.NewScanner().Scan(paths.HubDir)
+	if err != nil {
+		return fmt.Errorf("failed to scan hub: %w", err)
+	}
+
+	items, bundles := marketplaceBuildItems, marketplaceBuildBundles
+	switch {
+	case marketplaceBuildAll:
+		items, bundles = nil, nil
+		for _, itemType := range bundleMemberTypes {
+			for _, item := range h.GetItems(itemType) {
+				items = append(items, string(itemType)+"/"+item.Name)
+			}
+		}
+		all, err := hub.ListBundles(paths.BundlesDir())
+		if err != nil {
+			return err
+		}
+		for _, b := range all {
+			bundles = append(bundles, b.Name)
+		}
+	case len(items) == 0 && len(bundles) == 0:
+		items, bundles, err = pickMarketplaceContent(paths, h)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 && len(bundles) == 0 {
+			fmt.Println("Nothing selected.")
+			return nil
+		}
+	}
+
+	name := marketplaceBuildName
+	if name == "" {
+		name = strings.ToLower(filepath.Base(dir))
+	}
+	owner := marketplaceBuildOwner
+	if owner == "" {
+		owner = defaultMarketplaceOwner()
+	}
+	spec := source.MarketplaceSpec{Name: name, Owner: owner, Description: marketplaceBuildDescription}
+
+	pluginName := marketplaceBuildPlugin
+	if pluginName == "" {
+		pluginName = name
+	}
+	plugins, err := marketplacePlugins(paths, h, items, bundles, pluginName, marketplaceBuildSplit)
+	if err != nil {
+		return err
+	}
+	spec.Plugins = plugins
+
+	warnings, err := source.BuildMarketplace(dir, spec)
+	for _, w := range warnings {
+		fmt.Printf("Warning: %s\n", w)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Built marketplace %s in %s\n", name, dir)
+	for _, plugin := range spec.Plugins {
+		fmt.Printf("  %s (%d items)\n", plugin.Name, len(plugin.Items))
+	}
+	fmt.Printf("\nTry it with: /plugin marketplace add %s\n", dir)
+	return nil
+}
+
+// marketplacePlugins turns the selected bundles and loose items into plugins:
+// one per bundle, then the loose items as one plugin or one each with split.
+func marketplacePlugins(paths *config.Paths, h *hub.Hub, items, bundles []string, pluginName string, split bool) ([]source.MarketplacePlugin, error) {
+	var plugins []source.MarketplacePlugin
+	for _, name := range bundles {
+		bundle, err := hub.LoadBundle(paths.BundlesDir(), name)
+		if err != nil {
+			return nil, fmt.Errorf("bundle not found: %s", name)
+		}
+		plugin := source.MarketplacePlugin{Name: bundle.Name, Description: bundle.Description, Version: bundle.Version}
+		for _, ref := range bundle.Members.AllComponents() {
+			plugin.Items = append(plugin.Items, source.MarketplaceItem{
+				Type: ref.Type,
+				Name: ref.Name,
+				Path: filepath.Join(paths.BundleDir(name), ref.Type, ref.Name),
+			})
+		}
+		plugins = append(plugins, plugin)
+	}
+
+	loose := source.MarketplacePlugin{Name: pluginName, Version: "1.0.0"}
+	for _, key := range items {
+		item := hubItemByKey(h, key)
+		if item == nil {
+			return nil, fmt.Errorf("item not found: %s", key)
+		}
+		if item.Type == config.HubSettingsTemplates {
+			return nil, fmt.Errorf("%s: settings templates cannot be exported to a marketplace", key)
+		}
+		entry := source.MarketplaceItem{Type: string(item.Type), Name: item.Name, Path: item.Path}
+		if split {
+			plugins = append(plugins, source.MarketplacePlugin{
+				Name:    strings.TrimSuffix(item.Name, filepath.Ext(item.Name)),
+				Version: "1.0.0",
+				Items:   []source.MarketplaceItem{entry},
+			})
+			continue
+		}
+		loose.Items = append(loose.Items, entry)
+	}
+	if len(loose.Items) > 0 {
+		plugins = append(plugins, loose)
+	}
+	return plugins, nil
+}
+
+// pickMarketplaceContent runs the tabbed picker over hub items and bundles.
+func pickMarketplaceContent(paths *config.Paths, h *hub.Hub) ([]string, []string, error) {
+	var tabs []picker.Tab
+	all, err := hub.ListBundles(paths.BundlesDir())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(all) > 0 {
+		var pickerItems []picker.Item
+		for _, b := range all {
+			pickerItems = append(pickerItems, picker.Item{ID: b.Name, Label: b.Name})
+		}
+		tabs = append(tabs, picker.Tab{Name: string(config.HubBundles), Items: pickerItems})
+	}
+	for _, itemType := range bundleMemberTypes {
+		var pickerItems []picker.Item
+		for _, item := range h.GetItems(itemType) {
+			pickerItems = append(pickerItems, picker.Item{ID: string(itemType) + "/" + item.Name, Label: item.Name})
+		}
+		if len(pickerItems) > 0 {
+			tabs = append(tabs, picker.Tab{Name: string(itemType), Items: pickerItems})
+		}
+	}
+	if len(tabs) == 0 {
+		return nil, nil, fmt.Errorf("no hub items available to export")
+	}
+
+	fmt.Println("Choose what to export (space to select, tab to switch tabs, enter to confirm)")
+	fmt.Println()
+	selections, err := picker.RunTabbed(tabs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("picker error: %w", err)
+	}
+	var items []string
+	for _, itemType := range bundleMemberTypes {
+		items = append(items, selections[string(itemType)]...)
+	}
+	return items, selections[string(config.HubBundles)], nil
+}
+
+// defaultMarketplaceOwner uses the git identity, falling back to $USER.
+func defaultMarketplaceOwner() string {
+	if out, err := exec.Command("git", "config", "user.name").Output(); err == nil {
+		if name := strings.TrimSpace(string(out)); name != "" {
+			return name
+		}
+	}
+	return os.Getenv("USER")
+}
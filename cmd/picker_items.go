@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/picker"
+	"github.com/samhoang/ccp/internal/profile"
+)
+
+// hubItemAnnotations carries what the pickers show next to a hub item:
+// description, source group and badges. Built once per picker invocation.
+type hubItemAnnotations struct {
+	descriptions map[string]string
+	protected    map[string]bool
+	usedBy       map[string]int      // "type/name" -> number of profiles
+	bundles      map[string][]string // "type/name" -> bundles containing it
+}
+
+// loadHubItemAnnotations gathers picker annotations for every item in h.
+// Lookups that fail only drop the corresponding badges.
+func loadHubItemAnnotations(paths *config.Paths, h *hub.Hub) *hubItemAnnotations {
+	a := &hubItemAnnotations{
+		descriptions: hubItemDescriptions(paths),
+		usedBy:       make(map[string]int),
+		bundles:      make(map[string][]string),
+	}
+	a.protected, _ = loadProtectedItems(paths)
+
+	bundleMembers := make(map[string][]string)
+	for _, b := range h.Bundles {
+		for _, ref := range b.Members.AllComponents() {
+			key := ref.Type + "/" + ref.Name
+			a.bundles[key] = append(a.bundles[key], b.Name)
+			bundleMembers[b.Name] = append(bundleMembers[b.Name], key)
+		}
+	}
+
+	entries, err := os.ReadDir(paths.ProfilesDir)
+	if err != nil {
+		return a
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == "shared" {
+			continue
+		}
+		manifest, err := profile.LoadManifest(profile.ManifestPath(filepath.Join(paths.ProfilesDir, entry.Name())))
+		if err != nil {
+			continue
+		}
+
+		used := make(map[string]bool)
+		for _, itemType := range config.AllHubItemTypes() {
+			for _, name := range manifest.GetHubItems(itemType) {
+				used[fmt.Sprintf("%s/%s", itemType, name)] = true
+			}
+		}
+		for _, name := range manifest.Hub.Bundles {
+			used[fmt.Sprintf("%s/%s", config.HubBundles, name)] = true
+			for _, key := range bundleMembers[name] {
+				used[key] = true
+			}
+		}
+		for key := range used {
+			a.usedBy[key]++
+		}
+	}
+
+	return a
+}
+
+// pickerItem builds an annotated picker item for a hub item, using the bare
+// item name as ID and label
+func (a *hubItemAnnotations) pickerItem(item hub.Item, selected bool) picker.Item {
+	pi := a.annotate(item)
+	pi.ID = item.Name
+	pi.Label = item.Name
+	pi.Selected = selected
+	return pi
+}
+
+// annotate fills description, group, badges and preview for a hub item
+func (a *hubItemAnnotations) annotate(item hub.Item) picker.Item {
+	key := fmt.Sprintf("%s/%s", item.Type, item.Name)
+
+	pi := picker.Item{
+		Description: a.descriptions[key],
+		Group:       "local",
+		PreviewPath: hub.MarkdownPath(item),
+	}
+	if pi.PreviewPath == "" {
+		if !item.IsDir {
+			pi.PreviewPath = item.Path
+		} else if item.Type == config.HubHooks {
+			pi.PreviewPath = filepath.Join(item.Path, "hooks.json")
+		}
+	}
+	if item.Source != nil {
+		pi.Group = item.Source.SourceInfo()
+		if item.Source.Type != hub.SourceTypeLocal {
+			pi.Badges = append(pi.Badges, string(item.Source.Type))
+		}
+	}
+	if a.protected[key] {
+		pi.Badges = append(pi.Badges, "protected")
+	}
+	if bundles := a.bundles[key]; len(bundles) > 0 {
+		sort.Strings(bundles)
+		for _, b := range bundles {
+			pi.Badges = append(pi.Badges, "bundle:"+b)
+		}
+	}
+	switch n := a.usedBy[key]; n {
+	case 0:
+	case 1:
+		pi.Badges = append(pi.Badges, "1 profile")
+	default:
+		pi.Badges = append(pi.Badges, fmt.Sprintf("%d profiles", n))
+	}
+	return pi
+}
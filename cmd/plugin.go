@@ -13,7 +13,10 @@ var pluginCmd = &cobra.Command{
 
   ccp plugin add    →  ccp source add
   ccp plugin list   →  ccp source list
-  ccp plugin update →  ccp source update`,
+  ccp plugin update →  ccp source update
+
+'ccp plugin adopt' is not deprecated: it imports plugins installed with
+Claude Code's /plugin command into the hub.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		printDeprecationNotice("plugin", "source")
 	},
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/picker"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+var (
+	pluginAdoptSelect bool
+	pluginAdoptDryRun bool
+	pluginAdoptForce  bool
+)
+
+var pluginAdoptCmd = &cobra.Command{
+	Use:   "adopt [plugin[@marketplace]...]",
+	Short: "Import plugins installed with Claude Code's /plugin into the hub",
+	Long: `Copy the skills, agents, commands and hooks of plugins installed through
+Claude Code's /plugin command into the hub, so they can be listed, searched,
+pruned and linked per profile like any other hub item.
+
+Plugins are found in each profile's plugins/installed_plugins.json and read
+from the shared plugin store (~/.ccp/store/plugins/cache). Adopted directory
+items get a source.yaml pointing back at the plugin; every adopted item is
+recorded in hub/plugins/<name>/plugin.yaml.
+
+A plugin's hooks/ directory becomes one hook item, <plugin>-hooks. Files its
+hook commands reference outside hooks/ (e.g. ${CLAUDE_PLUGIN_ROOT}/scripts/)
+are copied into the item as well.
+
+Examples:
+  ccp plugin adopt                    # Adopt every installed plugin
+  ccp plugin adopt code-review        # Adopt one plugin
+  ccp plugin adopt --select           # Choose components interactively
+  ccp plugin adopt --dry-run          # Show what would be adopted`,
+	// Not deprecated like the rest of 'ccp plugin'
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	RunE:             runPluginAdopt,
+}
+
+func init() {
+	pluginAdoptCmd.Flags().BoolVarP(&pluginAdoptSelect, "select", "s", false, "Interactively select which components to adopt")
+	pluginAdoptCmd.Flags().BoolVarP(&pluginAdoptDryRun, "dry-run", "n", false, "Show what would be adopted without making changes")
+	pluginAdoptCmd.Flags().BoolVarP(&pluginAdoptForce, "force", "f", false, "Replace hub items that already exist")
+	pluginCmd.AddCommand(pluginAdoptCmd)
+}
+
+func runPluginAdopt(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	installedFiles, err := filepath.Glob(filepath.Join(paths.ProfilesDir, "*", "plugins", "installed_plugins.json"))
+	if err != nil {
+		return err
+	}
+	plugins, err := hub.ScanPluginStore(paths.StorePluginsDir(), installedFiles)
+	if err != nil {
+		return fmt.Errorf("failed to read installed plugins: %w", err)
+	}
+
+	if len(args) > 0 {
+		var selected []hub.StorePlugin
+		for _, arg := range args {
+			i := slices.IndexFunc(plugins, func(p hub.StorePlugin) bool { return p.ID() == arg || p.Name == arg })
+			if i == -1 {
+				return fmt.Errorf("plugin not installed: %s", arg)
+			}
+			selected = append(selected, plugins[i])
+		}
+		plugins = selected
+	}
+
+	if len(plugins) == 0 {
+		fmt.Println("No plugins installed through Claude Code's /plugin found")
+		return nil
+	}
+
+	if pluginAdoptSelect {
+		var items []picker.Item
+		for _, p := range plugins {
+			for _, comp := range p.Components {
+				items = append(items, picker.Item{
+					ID:       p.ID() + ":" + string(comp.Type) + "/" + comp.Name,
+					Label:    fmt.Sprintf("[%s] %s (%s)", comp.Type, comp.Name, p.Name),
+					Selected: true,
+				})
+			}
+		}
+		selected, err := picker.Run("Select components to adopt", items)
+		if err != nil {
+			return fmt.Errorf("picker error: %w", err)
+		}
+		if selected == nil {
+			fmt.Println("Adoption cancelled")
+			return nil
+		}
+		for i := range plugins {
+			p := &plugins[i]
+			p.Components = slices.DeleteFunc(p.Components, func(comp hub.PluginComponent) bool {
+				return !slices.Contains(selected, p.ID()+":"+string(comp.Type)+"/"+comp.Name)
+			})
+		}
+	}
+
+	total := 0
+	for _, p := range plugins {
+		fmt.Printf("%s (v%s)\n", p.ID(), p.Version)
+		if len(p.Components) == 0 {
+			fmt.Println("  no skills, agents, commands or hooks")
+			continue
+		}
+
+		adopted := make(map[config.HubItemType][]string)
+		for _, comp := range p.Components {
+			dst := paths.HubItemPath(comp.Type, comp.Name)
+			exists := false
+			if _, err := os.Lstat(dst); err == nil {
+				exists = true
+				if !pluginAdoptForce {
+					fmt.Printf("  - %s/%s: already in hub (use --force to replace)\n", comp.Type, comp.Name)
+					continue
+				}
+			}
+			if pluginAdoptDryRun {
+				fmt.Printf("  + %s/%s\n", comp.Type, comp.Name)
+				continue
+			}
+			if exists {
+				if err := os.RemoveAll(dst); err != nil {
+					return err
+				}
+			}
+			if err := adoptPluginComponent(p, comp, dst); err != nil {
+				fmt.Printf("  ⚠ %s/%s: %v\n", comp.Type, comp.Name, err)
+				continue
+			}
+			fmt.Printf("  + %s/%s\n", comp.Type, comp.Name)
+			adopted[comp.Type] = append(adopted[comp.Type], comp.Name)
+			total++
+		}
+
+		if pluginAdoptDryRun || len(adopted) == 0 {
+			continue
+		}
+		if err := savePluginAdoption(paths, p, adopted); err != nil {
+			fmt.Printf("Warning: failed to save plugin manifest: %v\n", err)
+		}
+	}
+
+	if pluginAdoptDryRun {
+		fmt.Println()
+		fmt.Println("Dry run - no changes made")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Printf("Adopted %d items into the hub\n", total)
+	if total > 0 {
+		fmt.Println("Link them to a profile with: ccp link <profile> <type>/<name>")
+		fmt.Println("Then disable the plugin in Claude Code (/plugin) to avoid loading it twice.")
+	}
+	return nil
+}
+
+// pluginRootRef matches the first path segment after ${CLAUDE_PLUGIN_ROOT}/
+var pluginRootRef = regexp.MustCompile(`\$\{CLAUDE_PLUGIN_ROOT\}/([^/\s"'$;|&]+)`)
+
+// adoptPluginComponent copies one plugin component to dst and, for directory
+// items, records where it came from in source.yaml
+func adoptPluginComponent(p hub.StorePlugin, comp hub.PluginComponent, dst string) error {
+	src := filepath.Join(p.Path, comp.Path)
+	if err := source.CopyTree(src, dst); err != nil {
+		return err
+	}
+
+	if comp.Type == config.HubHooks {
+		if err := rebaseAdoptedHooks(p.Path, dst); err != nil {
+			os.RemoveAll(dst)
+			return err
+		}
+	}
+
+	if info, err := os.Stat(dst); err != nil || !info.IsDir() {
+		return nil // file items are tracked by the plugin manifest only
+	}
+	manifest := hub.NewPluginSource(p.Name, p.Owner, p.Repo, p.Version)
+	manifest.Plugin.Marketplace = p.Marketplace
+	return manifest.Save(dst)
+}
+
+// rebaseAdoptedHooks makes a plugin's hooks.json work from the hook item
+// directory: ${CLAUDE_PLUGIN_ROOT}/hooks/ becomes ${CLAUDE_PLUGIN_ROOT}/, and
+// other plugin directories the commands use are copied next to it
+func rebaseAdoptedHooks(pluginDir, hookDir string) error {
+	path := filepath.Join(hookDir, "hooks.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	text := strings.ReplaceAll(string(data), "${CLAUDE_PLUGIN_ROOT}/hooks/", "${CLAUDE_PLUGIN_ROOT}/")
+
+	for _, match := range pluginRootRef.FindAllStringSubmatch(string(data), -1) {
+		dir := match[1]
+		if dir == "hooks" {
+			continue
+		}
+		dst := filepath.Join(hookDir, dir)
+		if _, err := os.Lstat(dst); err == nil {
+			continue
+		}
+		src := filepath.Join(pluginDir, dir)
+		if _, err := os.Stat(src); err != nil {
+			continue // left for 'ccp hub lint' to report
+		}
+		if err := source.CopyTree(src, dst); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(path, []byte(text), 0644)
+}
+
+// savePluginAdoption records adopted items in hub/plugins/<name>/plugin.yaml,
+// keeping items adopted by an earlier run
+func savePluginAdoption(paths *config.Paths, p hub.StorePlugin, adopted map[config.HubItemType][]string) error {
+	manifest, err := hub.LoadPluginManifest(paths.PluginsDir(), p.Name)
+	if err != nil {
+		manifest = hub.NewPluginManifest(p.Name, p.Description, p.Version,
+			hub.GitHubSource{Owner: p.Owner, Repo: p.Repo, Commit: p.Commit}, hub.ComponentList{})
+	} else {
+		manifest.Description = p.Description
+		manifest.Version = p.Version
+		manifest.GitHub = hub.GitHubSource{Owner: p.Owner, Repo: p.Repo, Commit: p.Commit}
+	}
+
+	add := func(list []string, names []string) []string {
+		for _, name := range names {
+			if !slices.Contains(list, name) {
+				list = append(list, name)
+			}
+		}
+		return list
+	}
+	manifest.Components.Skills = add(manifest.Components.Skills, adopted[config.HubSkills])
+	manifest.Components.Agents = add(manifest.Components.Agents, adopted[config.HubAgents])
+	manifest.Components.Commands = add(manifest.Components.Commands, adopted[config.HubCommands])
+	manifest.Components.Hooks = add(manifest.Components.Hooks, adopted[config.HubHooks])
+	return manifest.Save(paths.PluginsDir())
+}
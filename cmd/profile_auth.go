@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/profile"
+)
+
+var (
+	authIsolate bool
+	authShare   bool
+)
+
+var profileAuthCmd = &cobra.Command{
+	Use:   "auth <name>",
+	Short: "Show or change how a profile's login is kept",
+	Long: `Show which account a profile is logged in with, or switch its
+credentials (.credentials.json) between isolated and shared.
+
+Profiles are isolated by default: each keeps its own login, so a work and a
+personal account can live side by side. Shared profiles link to one login
+in ~/.ccp/profiles/shared.
+
+--share makes the profile's login the shared one when there is none yet; a
+different shared login is never overwritten. --isolate gives the profile a
+copy of the shared login (log in again from it to switch accounts). Files
+are kept at mode 0600 and tokens are never printed.
+
+Examples:
+  ccp profile auth work             # Show mode and account
+  ccp profile auth personal --isolate
+  ccp profile auth dev --share`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProfileNames,
+	RunE:              runProfileAuth,
+}
+
+func init() {
+	profileAuthCmd.Flags().BoolVar(&authIsolate, "isolate", false, "Give the profile its own login")
+	profileAuthCmd.Flags().BoolVar(&authShare, "share", false, "Use the login shared between profiles")
+	profileCmd.AddCommand(profileAuthCmd)
+}
+
+func runProfileAuth(cmd *cobra.Command, args []string) error {
+	profileName := args[0]
+	if authIsolate && authShare {
+		return fmt.Errorf("--isolate and --share are mutually exclusive")
+	}
+
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	mgr := profile.NewManager(paths)
+	if !mgr.Exists(profileName) {
+		return fmt.Errorf("profile not found: %s", profileName)
+	}
+
+	before := mgr.CredentialsMode(paths.ProfileDir(profileName))
+	switch {
+	case authShare:
+		if err := mgr.ShareCredentials(profileName); err != nil {
+			return err
+		}
+	case authIsolate:
+		if err := mgr.IsolateCredentials(profileName); err != nil {
+			return err
+		}
+	}
+
+	account, err := mgr.GetAccount(profileName)
+	if err != nil {
+		return err
+	}
+	if account.Mode != before {
+		fmt.Printf("Credentials of '%s' are now %s\n", profileName, account.Mode)
+	}
+
+	fmt.Printf("Profile:     %s\n", profileName)
+	fmt.Printf("Credentials: %s\n", account.Mode)
+	if account.LoggedIn {
+		fmt.Printf("Account:     %s\n", account.Identity())
+	} else {
+		fmt.Println("Account:     not logged in (run claude with this profile to log in)")
+	}
+	return nil
+}
@@ -97,7 +97,7 @@ func runProfileCheck(cmd *cobra.Command, args []string) error {
 	}
 
 	if items, ok := byType[profile.DriftMismatched]; ok {
-		fmt.Println("Mismatched (symlink points to wrong target):")
+		fmt.Println("Mismatched (wrong symlink target, or symlink/copy mode differs):")
 		for _, item := range items {
 			fmt.Printf("  - %s/%s\n", item.ItemType, item.ItemName)
 			fmt.Printf("      expected: %s\n", item.Expected)
@@ -106,6 +106,22 @@ func runProfileCheck(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
+	if items, ok := byType[profile.DriftStale]; ok {
+		fmt.Println("Stale (copy differs from hub item):")
+		for _, item := range items {
+			fmt.Printf("  - %s/%s (%s)\n", item.ItemType, item.ItemName, item.Actual)
+		}
+		fmt.Println()
+	}
+
+	if items, ok := byType[profile.DriftMemory]; ok {
+		fmt.Println("Memory (generated region of CLAUDE.md differs from snippets):")
+		for _, item := range items {
+			fmt.Printf("  - %s (%s)\n", item.ItemName, item.Actual)
+		}
+		fmt.Println()
+	}
+
 	fmt.Printf("Run 'ccp profile fix %s' to reconcile\n", profileName)
 
 	// Exit with non-zero code to indicate drift
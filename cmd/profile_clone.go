@@ -66,6 +66,7 @@ func runProfileClone(cmd *cobra.Command, args []string) error {
 	for _, itemType := range config.AllHubItemTypes() {
 		manifest.SetHubItems(itemType, source.Manifest.GetHubItems(itemType))
 	}
+	manifest.Env = source.Manifest.Env
 
 	// Create the profile
 	p, err := mgr.Create(newName, manifest)
@@ -73,6 +74,14 @@ func runProfileClone(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create profile: %w", err)
 	}
 
+	// A clone of a profile on the shared login uses it too; isolated
+	// credentials are never copied
+	if mgr.CredentialsMode(source.Path) == config.ShareModeShared {
+		if err := mgr.ShareCredentials(newName); err != nil {
+			fmt.Printf("Warning: failed to share credentials: %v\n", err)
+		}
+	}
+
 	fmt.Printf("Cloned %s -> %s\n", sourceName, newName)
 	fmt.Printf("Location: %s\n", p.Path)
 
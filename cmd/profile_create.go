@@ -17,11 +17,13 @@ var (
 	createHooks       []string
 	createRules       []string
 	createCommands    []string
+	createMemory      []string
 	createFrom        string
 	createInteractive bool
 	createEmpty       bool
 	createDescription string
 	createTemplate    string
+	createMaterialize string
 )
 
 var profileCreateCmd = &cobra.Command{
@@ -34,7 +36,8 @@ Examples:
   ccp profile create quickfix --skills=debugging-core,git-basics
   ccp profile create dev --interactive
   ccp profile create minimal --from=default
-  ccp profile create empty-profile --empty`,
+  ccp profile create empty-profile --empty
+  ccp profile create devcontainer --from=default --materialize=copy`,
 	Args: cobra.ExactArgs(1),
 	RunE: runProfileCreate,
 }
@@ -44,11 +47,13 @@ func init() {
 	profileCreateCmd.Flags().StringSliceVar(&createHooks, "hooks", nil, "Hooks to include")
 	profileCreateCmd.Flags().StringSliceVar(&createRules, "rules", nil, "Rules to include")
 	profileCreateCmd.Flags().StringSliceVar(&createCommands, "commands", nil, "Commands to include")
+	profileCreateCmd.Flags().StringSliceVar(&createMemory, "memory", nil, "Memory snippets to render into CLAUDE.md")
 	profileCreateCmd.Flags().StringVar(&createFrom, "from", "", "Copy configuration from existing profile")
 	profileCreateCmd.Flags().BoolVarP(&createInteractive, "interactive", "i", false, "Interactive picker mode")
 	profileCreateCmd.Flags().BoolVarP(&createEmpty, "empty", "e", false, "Create empty profile without hub items")
 	profileCreateCmd.Flags().StringVarP(&createDescription, "description", "d", "", "Profile description")
 	profileCreateCmd.Flags().StringVar(&createTemplate, "template", "", "Settings template to use")
+	profileCreateCmd.Flags().StringVar(&createMaterialize, "materialize", "", "How hub items are placed: symlink (default) or copy")
 	profileCmd.AddCommand(profileCreateCmd)
 }
 
@@ -83,6 +88,14 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 		manifest.SettingsTemplate = createTemplate
 	}
 
+	// Validate and assign materialize mode
+	if err := profile.ValidateMaterialize(createMaterialize); err != nil {
+		return err
+	}
+	if createMaterialize == profile.MaterializeCopy {
+		manifest.Materialize = createMaterialize
+	}
+
 	// If --from is specified, copy from existing profile
 	if createFrom != "" {
 		sourceProfile, err := mgr.Get(createFrom)
@@ -97,6 +110,7 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 		for _, itemType := range config.AllHubItemTypes() {
 			manifest.SetHubItems(itemType, sourceProfile.Manifest.GetHubItems(itemType))
 		}
+		manifest.Env = sourceProfile.Manifest.Env
 
 		// Copy template if not overridden by flags
 		if createTemplate == "" && sourceProfile.Manifest.SettingsTemplate != "" {
@@ -121,10 +135,13 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 	if len(createCommands) > 0 {
 		manifest.Hub.Commands = createCommands
 	}
+	if len(createMemory) > 0 {
+		manifest.Hub.Memory = createMemory
+	}
 
 	// Interactive mode
 	hasAnyFlags := len(createSkills) > 0 || len(createHooks) > 0 || len(createRules) > 0 ||
-		len(createCommands) > 0 || createFrom != "" || createEmpty || createTemplate != ""
+		len(createCommands) > 0 || len(createMemory) > 0 || createFrom != "" || createEmpty || createTemplate != ""
 
 	if createInteractive || !hasAnyFlags {
 		// Scan hub for available items
@@ -133,6 +150,7 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to scan hub: %w", err)
 		}
+		annotations := loadHubItemAnnotations(paths, h)
 
 		// Build tabs for the tabbed picker
 		var tabs []picker.Tab
@@ -151,11 +169,7 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 			}
 
 			for _, item := range items {
-				pickerItems = append(pickerItems, picker.Item{
-					ID:       item.Name,
-					Label:    item.Name,
-					Selected: currentSelected[item.Name],
-				})
+				pickerItems = append(pickerItems, annotations.pickerItem(item, currentSelected[item.Name]))
 			}
 
 			tabs = append(tabs, picker.Tab{
@@ -194,6 +208,14 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create profile: %w", err)
 	}
 
+	// A profile created from one on the shared login uses it too; isolated
+	// credentials are never copied
+	if createFrom != "" && mgr.CredentialsMode(paths.ProfileDir(createFrom)) == config.ShareModeShared {
+		if err := mgr.ShareCredentials(profileName); err != nil {
+			fmt.Printf("Warning: failed to share credentials: %v\n", err)
+		}
+	}
+
 	fmt.Printf("Created profile: %s\n", p.Name)
 	fmt.Printf("Location: %s\n", p.Path)
 
@@ -206,7 +228,11 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 	if len(summaryParts) > 0 {
-		fmt.Printf("Linked: %s\n", strings.Join(summaryParts, ", "))
+		if manifest.CopyMode() {
+			fmt.Printf("Copied: %s\n", strings.Join(summaryParts, ", "))
+		} else {
+			fmt.Printf("Linked: %s\n", strings.Join(summaryParts, ", "))
+		}
 	}
 
 	fmt.Println()
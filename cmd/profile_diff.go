@@ -1,35 +1,52 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/profile"
 )
 
+var profileDiffJSONPatch bool
+
+// profileDirPlaceholder stands for each profile's directory in settings diffs
+const profileDirPlaceholder = "<profile>"
+
 var profileDiffCmd = &cobra.Command{
 	Use:    "diff <profile-a> [profile-b]",
 	Hidden: true,
 	Short:  "Compare two profiles",
-	Long: `Show differences between two profiles.
+	Long: `Show differences between two profiles: the hub items they link and their
+fully generated settings (template, bundles, fragment and hub hooks).
+
+Settings differences are listed by path, e.g. permissions.allow[3], as
+additions (+), removals (-) and changes (~). With --json-patch, only the
+settings differences are printed, as an RFC 6902 JSON patch from the first
+profile to the second.
 
 If only one profile is specified, compares against the active profile.
 
 Examples:
   ccp profile diff dev prod
-  ccp profile diff minimal  # compares to active profile`,
+  ccp profile diff minimal  # compares to active profile
+  ccp profile diff dev prod --json-patch`,
 	Args:              cobra.RangeArgs(1, 2),
 	ValidArgsFunction: completeProfileNames,
 	RunE:              runProfileDiff,
 }
 
 func init() {
+	profileDiffCmd.Flags().BoolVar(&profileDiffJSONPatch, "json-patch", false, "Print settings differences as a JSON patch")
 	profileCmd.AddCommand(profileDiffCmd)
 }
 
@@ -75,6 +92,24 @@ func runProfileDiff(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("profile not found: %s", profileB)
 	}
 
+	settingsA, err := profile.GenerateSettings(a.Manifest, paths, a.Path)
+	if err != nil {
+		return fmt.Errorf("failed to generate settings for %s: %w", profileA, err)
+	}
+	settingsB, err := profile.GenerateSettings(b.Manifest, paths, b.Path)
+	if err != nil {
+		return fmt.Errorf("failed to generate settings for %s: %w", profileB, err)
+	}
+	// Hook commands point into each profile's own directory; compare them
+	// with a common placeholder so shared hooks don't show up as changed
+	changes := profile.CompareSettings(
+		profile.ReplaceProfileDir(settingsA, a.Path, profileDirPlaceholder),
+		profile.ReplaceProfileDir(settingsB, b.Path, profileDirPlaceholder))
+
+	if profileDiffJSONPatch {
+		return printJSONPatch(changes)
+	}
+
 	fmt.Printf("Comparing: %s vs %s\n\n", profileA, profileB)
 
 	hasDiff := false
@@ -101,6 +136,13 @@ func runProfileDiff(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(changes) > 0 {
+		hasDiff = true
+		fmt.Println("=== settings ===")
+		printSettingsChanges(changes)
+		fmt.Println()
+	}
+
 	if !hasDiff {
 		fmt.Println("Profiles are identical")
 	}
@@ -108,6 +150,46 @@ func runProfileDiff(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+var (
+	diffAddStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	diffRemoveStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	diffReplaceStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+)
+
+// printSettingsChanges lists settings differences by path, colored when
+// stdout is a terminal
+func printSettingsChanges(changes []profile.SettingsChange) {
+	for _, c := range changes {
+		switch c.Op {
+		case "add":
+			fmt.Println(diffAddStyle.Render(fmt.Sprintf("  + %s: %s", c.Path, compactJSON(c.New))))
+		case "remove":
+			fmt.Println(diffRemoveStyle.Render(fmt.Sprintf("  - %s: %s", c.Path, compactJSON(c.Old))))
+		default:
+			fmt.Println(diffReplaceStyle.Render(fmt.Sprintf("  ~ %s: %s → %s", c.Path, compactJSON(c.Old), compactJSON(c.New))))
+		}
+	}
+}
+
+// printJSONPatch prints settings differences as an RFC 6902 JSON patch
+func printJSONPatch(changes []profile.SettingsChange) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(profile.JSONPatch(changes))
+}
+
+// compactJSON renders a settings value on one line
+func compactJSON(v interface{}) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
 func diffSlices(a, b []string) (onlyInA, onlyInB, inBoth []string) {
 	setA := make(map[string]bool)
 	setB := make(map[string]bool)
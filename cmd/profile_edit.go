@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,7 +12,6 @@ import (
 	"github.com/samhoang/ccp/internal/hub"
 	"github.com/samhoang/ccp/internal/picker"
 	"github.com/samhoang/ccp/internal/profile"
-	"github.com/samhoang/ccp/internal/symlink"
 )
 
 var (
@@ -19,12 +19,16 @@ var (
 	editAddHooks       []string
 	editAddRules       []string
 	editAddCommands    []string
+	editAddMemory      []string
 	editRemoveSkills   []string
 	editRemoveHooks    []string
 	editRemoveRules    []string
 	editRemoveCommands []string
+	editRemoveMemory   []string
 	editInteractive    bool
 	editTemplate       string
+	editMaterialize    string
+	editDryRun         bool
 )
 
 var profileEditCmd = &cobra.Command{
@@ -39,7 +43,9 @@ Examples:
   ccp profile edit default -i                         # Interactive edit
   ccp profile edit default --add-skills=git-basics   # Add a skill
   ccp profile edit default --remove-hooks=session-start  # Remove a hook
-  ccp profile edit default --add-skills=a,b --remove-rules=c`,
+  ccp profile edit default --add-skills=a,b --remove-rules=c
+  ccp profile edit default --add-hooks=lint --dry-run  # Preview settings changes
+  ccp profile edit default --materialize=copy          # Copy hub items instead of linking`,
 	Args:              cobra.MaximumNArgs(1),
 	ValidArgsFunction: completeProfileNames,
 	RunE:              runProfileEdit,
@@ -51,15 +57,19 @@ func init() {
 	profileEditCmd.Flags().StringSliceVar(&editAddHooks, "add-hooks", nil, "Hooks to add")
 	profileEditCmd.Flags().StringSliceVar(&editAddRules, "add-rules", nil, "Rules to add")
 	profileEditCmd.Flags().StringSliceVar(&editAddCommands, "add-commands", nil, "Commands to add")
+	profileEditCmd.Flags().StringSliceVar(&editAddMemory, "add-memory", nil, "Memory snippets to add")
 
 	// Remove flags
 	profileEditCmd.Flags().StringSliceVar(&editRemoveSkills, "remove-skills", nil, "Skills to remove")
 	profileEditCmd.Flags().StringSliceVar(&editRemoveHooks, "remove-hooks", nil, "Hooks to remove")
 	profileEditCmd.Flags().StringSliceVar(&editRemoveRules, "remove-rules", nil, "Rules to remove")
 	profileEditCmd.Flags().StringSliceVar(&editRemoveCommands, "remove-commands", nil, "Commands to remove")
+	profileEditCmd.Flags().StringSliceVar(&editRemoveMemory, "remove-memory", nil, "Memory snippets to remove")
 
 	profileEditCmd.Flags().BoolVarP(&editInteractive, "interactive", "i", false, "Interactive picker mode")
 	profileEditCmd.Flags().StringVar(&editTemplate, "template", "", "Set settings template")
+	profileEditCmd.Flags().StringVar(&editMaterialize, "materialize", "", "Set how hub items are placed: symlink or copy")
+	profileEditCmd.Flags().BoolVarP(&editDryRun, "dry-run", "n", false, "Show how the generated settings would change without saving")
 
 	profileCmd.AddCommand(profileEditCmd)
 }
@@ -99,6 +109,13 @@ func runProfileEdit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("profile not found: %s", profileName)
 	}
 
+	var before map[string]interface{}
+	if editDryRun {
+		if before, err = profile.ProjectSettings(p.Manifest, paths, p.Path, nil); err != nil {
+			return fmt.Errorf("failed to generate settings: %w", err)
+		}
+	}
+
 	// Handle template changes
 	if editTemplate != "" {
 		tmplMgr := hub.NewTemplateManager(paths.HubDir)
@@ -109,11 +126,23 @@ func runProfileEdit(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Set settings template: %s\n", editTemplate)
 	}
 
+	// Handle materialize mode changes; the sync below converts existing items
+	if editMaterialize != "" {
+		if err := profile.ValidateMaterialize(editMaterialize); err != nil {
+			return err
+		}
+		p.Manifest.Materialize = ""
+		if editMaterialize == profile.MaterializeCopy {
+			p.Manifest.Materialize = editMaterialize
+		}
+		fmt.Printf("Set materialize mode: %s\n", editMaterialize)
+	}
+
 	// Check if any flags were provided
 	hasFlags := len(editAddSkills) > 0 || len(editAddHooks) > 0 || len(editAddRules) > 0 ||
-		len(editAddCommands) > 0 ||
+		len(editAddCommands) > 0 || len(editAddMemory) > 0 ||
 		len(editRemoveSkills) > 0 || len(editRemoveHooks) > 0 || len(editRemoveRules) > 0 ||
-		len(editRemoveCommands) > 0 || editTemplate != ""
+		len(editRemoveCommands) > 0 || len(editRemoveMemory) > 0 || editTemplate != "" || editMaterialize != ""
 
 	if editInteractive || !hasFlags {
 		// Interactive mode
@@ -127,6 +156,23 @@ func runProfileEdit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if editDryRun {
+		after, err := profile.ProjectSettings(p.Manifest, paths, p.Path, nil)
+		if err != nil {
+			return fmt.Errorf("failed to generate settings: %w", err)
+		}
+		changes := profile.CompareSettings(before, after)
+		fmt.Println()
+		if len(changes) == 0 {
+			fmt.Println("Generated settings would not change")
+		} else {
+			fmt.Println("Settings changes:")
+			printSettingsChanges(changes)
+		}
+		fmt.Println("\nDry run - no changes made")
+		return nil
+	}
+
 	// Sync the profile
 	fmt.Println("\nSyncing profile...")
 	if err := syncProfileEdit(paths, p); err != nil {
@@ -144,6 +190,7 @@ func runInteractiveEdit(paths *config.Paths, p *profile.Profile) error {
 	if err != nil {
 		return fmt.Errorf("failed to scan hub: %w", err)
 	}
+	annotations := loadHubItemAnnotations(paths, h)
 
 	// Build tabs for the tabbed picker
 	var tabs []picker.Tab
@@ -161,11 +208,7 @@ func runInteractiveEdit(paths *config.Paths, p *profile.Profile) error {
 		}
 
 		for _, item := range items {
-			pickerItems = append(pickerItems, picker.Item{
-				ID:       item.Name,
-				Label:    item.Name,
-				Selected: currentSelected[item.Name],
-			})
+			pickerItems = append(pickerItems, annotations.pickerItem(item, currentSelected[item.Name]))
 		}
 
 		tabs = append(tabs, picker.Tab{
@@ -196,6 +239,10 @@ func runInteractiveEdit(paths *config.Paths, p *profile.Profile) error {
 		}
 	}
 
+	if editDryRun {
+		return nil
+	}
+
 	// Save manifest
 	manifestPath := profile.ManifestPath(p.Path)
 	if err := p.Manifest.Save(manifestPath); err != nil {
@@ -212,6 +259,7 @@ func runFlagEdit(paths *config.Paths, p *profile.Profile) error {
 		config.HubHooks:    editAddHooks,
 		config.HubRules:    editAddRules,
 		config.HubCommands: editAddCommands,
+		config.HubMemory:   editAddMemory,
 	}
 
 	for itemType, items := range addItems {
@@ -233,6 +281,7 @@ func runFlagEdit(paths *config.Paths, p *profile.Profile) error {
 		config.HubHooks:    editRemoveHooks,
 		config.HubRules:    editRemoveRules,
 		config.HubCommands: editRemoveCommands,
+		config.HubMemory:   editRemoveMemory,
 	}
 
 	for itemType, items := range removeItems {
@@ -245,6 +294,10 @@ func runFlagEdit(paths *config.Paths, p *profile.Profile) error {
 		}
 	}
 
+	if editDryRun {
+		return nil
+	}
+
 	// Save manifest
 	manifestPath := profile.ManifestPath(p.Path)
 	if err := p.Manifest.Save(manifestPath); err != nil {
@@ -255,9 +308,10 @@ func runFlagEdit(paths *config.Paths, p *profile.Profile) error {
 }
 
 func syncProfileEdit(paths *config.Paths, p *profile.Profile) error {
-	symMgr := symlink.New()
+	// An unlinked statusline must be taken out of settings.json
+	statuslineRemoved := false
 
-	// Sync hub item symlinks
+	// Sync hub item symlinks (or copies)
 	for _, itemType := range config.AllHubItemTypes() {
 		itemDir := filepath.Join(p.Path, string(itemType))
 
@@ -284,11 +338,8 @@ func syncProfileEdit(paths *config.Paths, p *profile.Profile) error {
 
 		for _, entry := range entries {
 			if !manifestLinks[entry.Name()] {
-				linkPath := filepath.Join(itemDir, entry.Name())
-				isLink, _ := symMgr.IsSymlink(linkPath)
-				if isLink {
-					os.Remove(linkPath)
-				}
+				profile.RemoveItem(p.Path, filepath.Join(itemDir, entry.Name()))
+				statuslineRemoved = statuslineRemoved || itemType == config.HubStatuslines
 			}
 		}
 
@@ -306,24 +357,21 @@ func syncProfileEdit(paths *config.Paths, p *profile.Profile) error {
 				continue
 			}
 
-			// Check if symlink already exists and is correct
-			isLink, _ := symMgr.IsSymlink(profileItemPath)
-			if isLink {
-				target, err := symMgr.ReadLink(profileItemPath)
-				if err == nil && target == hubItemPath {
-					continue
-				}
-				os.Remove(profileItemPath)
-			}
-
-			if err := symMgr.Create(profileItemPath, hubItemPath); err != nil {
-				fmt.Printf("Warning: failed to create symlink for %s/%s: %v\n", itemType, itemName, err)
+			if _, err := profile.EnsureItem(p.Path, p.Manifest, profileItemPath, hubItemPath, false); errors.Is(err, profile.ErrCopyModified) {
+				fmt.Printf("Kept %s/%s: %v\n", itemType, itemName, err)
+			} else if err != nil {
+				fmt.Printf("Warning: failed to %s %s/%s: %v\n", materializeVerb(p.Manifest), itemType, itemName, err)
 			}
 		}
 	}
 
-	// Regenerate settings.json for hooks and templates
-	if len(p.Manifest.Hub.Hooks) > 0 || p.Manifest.SettingsTemplate != "" {
+	if err := renderProfileMemory(paths, p, ""); err != nil {
+		return err
+	}
+
+	// Regenerate settings.json for hooks, statuslines and templates
+	if len(p.Manifest.Hub.Hooks) > 0 || len(p.Manifest.Hub.Bundles) > 0 || len(p.Manifest.Hub.Statuslines) > 0 ||
+		statuslineRemoved || p.Manifest.SettingsTemplate != "" {
 		if err := profile.RegenerateSettings(paths, p.Path, p.Manifest); err != nil {
 			return fmt.Errorf("failed to regenerate settings.json: %w", err)
 		}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/migration"
+	"github.com/samhoang/ccp/internal/profile"
+)
+
+var (
+	ejectRoot               string
+	ejectIncludeShared      bool
+	ejectIncludeCredentials bool
+)
+
+var profileEjectCmd = &cobra.Command{
+	Use:   "eject <name> <dir>",
+	Short: "Copy a profile into a standalone config directory",
+	Long: `Copy a profile into a directory that works without ccp, e.g. for CI
+runners, containers or a teammate's machine.
+
+Symlinks to hub items and the plugin store are resolved to real files,
+ccp's own files (profile.toml, settings-fragment.json) are left out, and
+paths into the profile in settings.json and plugin metadata are rewritten
+to the new location. Use --root when the directory will be used at a
+different path than it is written to.
+
+Shared data (history, projects, todos, ...) and .credentials.json are left
+out unless asked for. The profile itself is not changed.
+
+Examples:
+  ccp profile eject dev ./claude-config
+  ccp profile eject dev ./ci/claude --root='$HOME/.claude'
+  CLAUDE_CONFIG_DIR=./claude-config claude`,
+	Args: cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeProfileNames(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	},
+	RunE: runProfileEject,
+}
+
+func init() {
+	profileEjectCmd.Flags().StringVar(&ejectRoot, "root", "", "Path the directory will be used at (default: <dir>)")
+	profileEjectCmd.Flags().BoolVar(&ejectIncludeShared, "include-shared", false, "Include shared data directories")
+	profileEjectCmd.Flags().BoolVar(&ejectIncludeCredentials, "include-credentials", false, "Include .credentials.json")
+	profileCmd.AddCommand(profileEjectCmd)
+}
+
+func runProfileEject(cmd *cobra.Command, args []string) error {
+	profileName, destDir := args[0], args[1]
+
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	mgr := profile.NewManager(paths)
+	if !mgr.Exists(profileName) {
+		return fmt.Errorf("profile not found: %s", profileName)
+	}
+
+	ejector := migration.NewEjector(paths)
+	result, err := ejector.Eject(profileName, destDir, migration.EjectOptions{
+		Root:               ejectRoot,
+		IncludeShared:      ejectIncludeShared,
+		IncludeCredentials: ejectIncludeCredentials,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Ejected profile '%s' to %s\n", profileName, result.Dir)
+	for _, rel := range result.Rewritten {
+		fmt.Printf("  ✓ Rewrote paths in %s -> %s\n", rel, result.Root)
+	}
+	if len(result.SkippedShared) > 0 {
+		fmt.Printf("  Skipped shared data: %s (use --include-shared)\n", strings.Join(result.SkippedShared, ", "))
+	}
+	if result.SkippedCredentials {
+		fmt.Println("  Skipped .credentials.json (use --include-credentials)")
+	}
+
+	fmt.Println()
+	fmt.Println("Use it with:")
+	fmt.Printf("  export CLAUDE_CONFIG_DIR=\"%s\"\n", result.Root)
+	return nil
+}
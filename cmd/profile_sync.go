@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,7 +12,6 @@ import (
 
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/profile"
-	"github.com/samhoang/ccp/internal/symlink"
 )
 
 var profileSyncCmd = &cobra.Command{
@@ -42,7 +42,7 @@ var (
 
 func init() {
 	profileSyncCmd.Flags().BoolVar(&syncAll, "all", false, "Sync all profiles")
-	profileSyncCmd.Flags().BoolVarP(&syncForce, "force", "f", false, "Apply settings changes without confirmation")
+	profileSyncCmd.Flags().BoolVarP(&syncForce, "force", "f", false, "Apply settings changes without confirmation and replace copies modified in the profile")
 	profileCmd.AddCommand(profileSyncCmd)
 }
 
@@ -59,21 +59,7 @@ func runProfileSync(cmd *cobra.Command, args []string) error {
 	mgr := profile.NewManager(paths)
 
 	if syncAll {
-		// Sync all profiles
-		profiles, err := mgr.List()
-		if err != nil {
-			return fmt.Errorf("failed to list profiles: %w", err)
-		}
-
-		for _, p := range profiles {
-			fmt.Printf("Syncing profile: %s\n", p.Name)
-			if err := syncProfile(paths, p, syncForce || syncAll); err != nil {
-				fmt.Fprintf(os.Stderr, "  Warning: %v\n", err)
-			} else {
-				fmt.Println("  Done")
-			}
-		}
-		return nil
+		return syncAllProfiles(paths)
 	}
 
 	// Get target profile
@@ -109,9 +95,62 @@ func runProfileSync(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func syncProfile(paths *config.Paths, p *profile.Profile, force bool) error {
-	symMgr := symlink.New()
+// materializeVerb and materializeAction describe placing a hub item in a
+// profile of the manifest's materialize mode
+func materializeVerb(m *profile.Manifest) string {
+	if m.CopyMode() {
+		return "copy"
+	}
+	return "link"
+}
+
+func materializeAction(m *profile.Manifest) string {
+	if m.CopyMode() {
+		return "Copying"
+	}
+	return "Linking"
+}
+
+// renderProfileMemory renders the profile's memory snippets into CLAUDE.md,
+// warning first when the generated region was edited by hand
+func renderProfileMemory(paths *config.Paths, p *profile.Profile, indent string) error {
+	state, err := profile.CheckMemory(paths, p.Path, p.Manifest)
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %w", profile.MemoryFile, err)
+	}
+	if state == profile.MemoryCurrent {
+		return nil
+	}
+	if state == profile.MemoryEdited {
+		fmt.Printf("%sWarning: discarding hand edits in the generated region of %s (edit outside the ccp:memory block instead)\n", indent, profile.MemoryFile)
+	}
+	if _, err := profile.RenderMemory(paths, p.Path, p.Manifest); err != nil {
+		return fmt.Errorf("failed to render %s: %w", profile.MemoryFile, err)
+	}
+	fmt.Printf("%sRendered %d memory snippets into %s\n", indent, len(p.Manifest.Hub.Memory), profile.MemoryFile)
+	return nil
+}
+
+// syncAllProfiles syncs every profile, applying settings changes without
+// confirmation; failures are reported as warnings
+func syncAllProfiles(paths *config.Paths) error {
+	profiles, err := profile.NewManager(paths).List()
+	if err != nil {
+		return fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	for _, p := range profiles {
+		fmt.Printf("Syncing profile: %s\n", p.Name)
+		if err := syncProfile(paths, p, true); err != nil {
+			fmt.Fprintf(os.Stderr, "  Warning: %v\n", err)
+		} else {
+			fmt.Println("  Done")
+		}
+	}
+	return nil
+}
 
+func syncProfile(paths *config.Paths, p *profile.Profile, force bool) error {
 	// Sync hub item symlinks
 	for _, itemType := range config.AllHubItemTypes() {
 		itemDir := filepath.Join(p.Path, string(itemType))
@@ -140,10 +179,8 @@ func syncProfile(paths *config.Paths, p *profile.Profile, force bool) error {
 		for _, entry := range entries {
 			if !manifestLinks[entry.Name()] {
 				linkPath := filepath.Join(itemDir, entry.Name())
-				isLink, _ := symMgr.IsSymlink(linkPath)
-				if isLink {
+				if removed, _ := profile.RemoveItem(p.Path, linkPath); removed {
 					fmt.Printf("  Removing unlinked %s: %s\n", itemType, entry.Name())
-					os.Remove(linkPath)
 				}
 			}
 		}
@@ -163,27 +200,31 @@ func syncProfile(paths *config.Paths, p *profile.Profile, force bool) error {
 				continue
 			}
 
-			// Check if symlink already exists and is correct
-			isLink, _ := symMgr.IsSymlink(profileItemPath)
-			if isLink {
-				target, err := symMgr.ReadLink(profileItemPath)
-				if err == nil && target == hubItemPath {
-					continue // Already correct
-				}
-				// Wrong target, remove and recreate
-				os.Remove(profileItemPath)
-			}
-
-			fmt.Printf("  Linking %s: %s\n", itemType, itemName)
-			if err := symMgr.Create(profileItemPath, hubItemPath); err != nil {
-				fmt.Printf("  Warning: failed to create symlink for %s/%s: %v\n", itemType, itemName, err)
+			// Symlinks are recreated when they point elsewhere, copies
+			// refreshed when the hub changed; copies edited in the profile
+			// are only replaced with --force
+			changed, err := profile.EnsureItem(p.Path, p.Manifest, profileItemPath, hubItemPath, force)
+			if errors.Is(err, profile.ErrCopyModified) {
+				fmt.Printf("  Kept %s: %s (modified in profile; use --force or 'ccp profile fix' to replace it)\n", itemType, itemName)
+			} else if err != nil {
+				fmt.Printf("  Warning: failed to %s %s/%s: %v\n", materializeVerb(p.Manifest), itemType, itemName, err)
+			} else if changed {
+				fmt.Printf("  %s %s: %s\n", materializeAction(p.Manifest), itemType, itemName)
 			}
 		}
 	}
 
+	if err := renderProfileMemory(paths, p, "  "); err != nil {
+		return err
+	}
+
 	// Regenerate settings.json
 	hasFragment := profile.FragmentExists(p.Path)
-	hasSources := len(p.Manifest.Hub.Hooks) > 0 || p.Manifest.SettingsTemplate != "" || hasFragment
+	hasMachine := false
+	if machine, err := config.LoadMachineConfig(paths.CcpDir); err == nil {
+		hasMachine = len(machine.SettingsOverlays(p.Name)) > 0
+	}
+	hasSources := len(p.Manifest.Hub.Hooks) > 0 || len(p.Manifest.Hub.Statuslines) > 0 || p.Manifest.SettingsTemplate != "" || hasFragment || hasMachine
 
 	if hasSources {
 		changed, err := profile.SettingsChanged(paths, p.Path, p.Manifest)
@@ -216,9 +257,15 @@ func syncProfile(paths *config.Paths, p *profile.Profile, force bool) error {
 			if hasFragment {
 				fmt.Println("  Applied settings fragment")
 			}
+			if hasMachine {
+				fmt.Printf("  Applied %s overrides\n", config.MachineFile)
+			}
 			if len(p.Manifest.Hub.Hooks) > 0 {
 				fmt.Printf("  Configured %d hub hooks\n", len(p.Manifest.Hub.Hooks))
 			}
+			if n := len(p.Manifest.Hub.Statuslines); n > 0 {
+				fmt.Printf("  Configured statusline: %s\n", p.Manifest.Hub.Statuslines[n-1])
+			}
 		} else {
 			fmt.Println("  Settings up to date")
 		}
@@ -12,6 +12,7 @@ import (
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/hub"
 	"github.com/samhoang/ccp/internal/picker"
+	"github.com/samhoang/ccp/internal/profile"
 	"github.com/samhoang/ccp/internal/source"
 )
 
@@ -40,6 +41,9 @@ var projectAddCmd = &cobra.Command{
 	Long: `Copy hub items from the ccp hub into the current project's .claude/ directory.
 
 Items are copied (not symlinked), so they become local to the project.
+Hooks are also registered in .claude/settings.json with commands relative to
+$CLAUDE_PROJECT_DIR; 'ccp project remove' unregisters them again. Other hooks
+in settings.json are left untouched.
 
 Examples:
   ccp project add skills/coding agents/reviewer   # Copy specific items
@@ -166,6 +170,9 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 }
 
 func runProjectAddDirect(paths *config.Paths, claudeDir string, items []string) error {
+	var added []string
+	defer func() { trackProjectItems(paths, claudeDir, added, false) }()
+
 	for _, ref := range items {
 		itemType, itemName, err := parseItemRef(ref)
 		if err != nil {
@@ -177,25 +184,51 @@ func runProjectAddDirect(paths *config.Paths, claudeDir string, items []string)
 			return fmt.Errorf("hub item not found: %s/%s", itemType, itemName)
 		}
 
-		dstPath := filepath.Join(claudeDir, string(itemType), itemName)
+		if err := copyProjectItem(paths, claudeDir, itemType, itemName); err != nil {
+			return err
+		}
+
+		fmt.Printf("Added %s/%s to %s\n", itemType, itemName, claudeDir)
+		added = append(added, fmt.Sprintf("%s/%s", itemType, itemName))
+	}
+
+	return nil
+}
 
-		// Warn if overwriting
-		if _, err := os.Stat(dstPath); err == nil {
-			fmt.Printf("Warning: overwriting existing %s/%s\n", itemType, itemName)
-			if err := os.RemoveAll(dstPath); err != nil {
-				return fmt.Errorf("failed to remove existing item: %w", err)
+// copyProjectItem copies a hub item into the project's .claude/ directory,
+// replacing an existing copy. Hook items are also registered in the
+// project's settings.json with $CLAUDE_PROJECT_DIR-relative commands.
+func copyProjectItem(paths *config.Paths, claudeDir string, itemType config.HubItemType, name string) error {
+	srcPath := filepath.Join(paths.HubDir, string(itemType), name)
+	dstPath := filepath.Join(claudeDir, string(itemType), name)
+
+	// Warn if overwriting
+	if _, err := os.Stat(dstPath); err == nil {
+		fmt.Printf("Warning: overwriting existing %s/%s\n", itemType, name)
+		if itemType == config.HubHooks {
+			if _, err := profile.RemoveProjectHooks(claudeDir, name); err != nil {
+				fmt.Printf("Warning: failed to unregister old %s from settings.json: %v\n", name, err)
 			}
 		}
-
-		if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
-			return fmt.Errorf("failed to create directory: %w", err)
+		if err := os.RemoveAll(dstPath); err != nil {
+			return fmt.Errorf("failed to remove existing item: %w", err)
 		}
+	}
 
-		if err := source.CopyTree(srcPath, dstPath); err != nil {
-			return fmt.Errorf("failed to copy %s/%s: %w", itemType, itemName, err)
-		}
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
 
-		fmt.Printf("Added %s/%s to %s\n", itemType, itemName, claudeDir)
+	if err := source.CopyTree(srcPath, dstPath); err != nil {
+		return fmt.Errorf("failed to copy %s/%s: %w", itemType, name, err)
+	}
+
+	if itemType == config.HubHooks {
+		if changed, err := profile.AddProjectHooks(claudeDir, name); err != nil {
+			fmt.Printf("Warning: failed to register %s in settings.json: %v\n", name, err)
+		} else if changed {
+			fmt.Printf("Registered hooks/%s in %s\n", name, filepath.Join(claudeDir, "settings.json"))
+		}
 	}
 
 	return nil
@@ -207,6 +240,7 @@ func runProjectAddInteractive(paths *config.Paths, claudeDir string) error {
 	if err != nil {
 		return fmt.Errorf("failed to scan hub: %w", err)
 	}
+	annotations := loadHubItemAnnotations(paths, h)
 
 	// Build tabs for the picker, excluding settings-templates
 	var tabs []picker.Tab
@@ -218,10 +252,7 @@ func runProjectAddInteractive(paths *config.Paths, claudeDir string) error {
 
 		var pickerItems []picker.Item
 		for _, item := range items {
-			pickerItems = append(pickerItems, picker.Item{
-				ID:    item.Name,
-				Label: item.Name,
-			})
+			pickerItems = append(pickerItems, annotations.pickerItem(item, false))
 		}
 
 		tabs = append(tabs, picker.Tab{
@@ -247,6 +278,8 @@ func runProjectAddInteractive(paths *config.Paths, claudeDir string) error {
 	}
 
 	// Copy selected items
+	var added []string
+	defer func() { trackProjectItems(paths, claudeDir, added, false) }()
 	copied := 0
 	for _, itemType := range projectHubItemTypes {
 		names, ok := selections[string(itemType)]
@@ -254,26 +287,12 @@ func runProjectAddInteractive(paths *config.Paths, claudeDir string) error {
 			continue
 		}
 		for _, name := range names {
-			srcPath := filepath.Join(paths.HubDir, string(itemType), name)
-			dstPath := filepath.Join(claudeDir, string(itemType), name)
-
-			// Warn if overwriting
-			if _, err := os.Stat(dstPath); err == nil {
-				fmt.Printf("Warning: overwriting existing %s/%s\n", itemType, name)
-				if err := os.RemoveAll(dstPath); err != nil {
-					return fmt.Errorf("failed to remove existing item: %w", err)
-				}
-			}
-
-			if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
-				return fmt.Errorf("failed to create directory: %w", err)
-			}
-
-			if err := source.CopyTree(srcPath, dstPath); err != nil {
-				return fmt.Errorf("failed to copy %s/%s: %w", itemType, name, err)
+			if err := copyProjectItem(paths, claudeDir, itemType, name); err != nil {
+				return err
 			}
 
 			fmt.Printf("Added %s/%s\n", itemType, name)
+			added = append(added, fmt.Sprintf("%s/%s", itemType, name))
 			copied++
 		}
 	}
@@ -337,6 +356,13 @@ func runProjectRemove(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	var removed []string
+	defer func() {
+		if paths, err := config.ResolvePaths(); err == nil && paths.IsInitialized() {
+			trackProjectItems(paths, claudeDir, removed, true)
+		}
+	}()
+
 	for _, ref := range args {
 		itemType, itemName, err := parseItemRef(ref)
 		if err != nil {
@@ -348,12 +374,45 @@ func runProjectRemove(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("item not found: %s/%s in %s", itemType, itemName, claudeDir)
 		}
 
+		if itemType == config.HubHooks {
+			if changed, err := profile.RemoveProjectHooks(claudeDir, itemName); err != nil {
+				fmt.Printf("Warning: failed to unregister %s from settings.json: %v\n", itemName, err)
+			} else if changed {
+				fmt.Printf("Unregistered hooks/%s from %s\n", itemName, filepath.Join(claudeDir, "settings.json"))
+			}
+		}
+
 		if err := os.RemoveAll(itemPath); err != nil {
 			return fmt.Errorf("failed to remove %s/%s: %w", itemType, itemName, err)
 		}
 
 		fmt.Printf("Removed %s/%s from %s\n", itemType, itemName, claudeDir)
+		removed = append(removed, fmt.Sprintf("%s/%s", itemType, itemName))
 	}
 
 	return nil
 }
+
+// trackProjectItems records hub items copied into a project (or, with
+// remove, forgets items removed from it) so that 'ccp hub prune' keeps them
+// and 'ccp hub why' can report the project
+func trackProjectItems(paths *config.Paths, claudeDir string, items []string, remove bool) {
+	if len(items) == 0 {
+		return
+	}
+	dir, err := filepath.Abs(filepath.Dir(claudeDir))
+	if err == nil {
+		var projects *config.Projects
+		if projects, err = config.LoadProjects(paths.CcpDir); err == nil {
+			if remove {
+				projects.Untrack(dir, items...)
+			} else {
+				projects.Track(dir, items...)
+			}
+			err = projects.Save(paths.CcpDir)
+		}
+	}
+	if err != nil {
+		fmt.Printf("Warning: failed to track project items: %v\n", err)
+	}
+}
@@ -146,6 +146,7 @@ func TestProjectAddDirect(t *testing.T) {
 	}
 
 	paths := &config.Paths{
+		CcpDir: t.TempDir(),
 		HubDir: hubDir,
 	}
 
@@ -166,6 +167,22 @@ func TestProjectAddDirect(t *testing.T) {
 	if string(data) != "# Test Skill" {
 		t.Errorf("copied content = %q, want %q", string(data), "# Test Skill")
 	}
+
+	// The project is tracked so prune keeps the hub item
+	projects, err := config.LoadProjects(paths.CcpDir)
+	if err != nil {
+		t.Fatalf("LoadProjects: %v", err)
+	}
+	tracked := projects.Projects[filepath.Dir(claudeDir)]
+	if len(tracked.Items) != 1 || tracked.Items[0] != "skills/test-skill" {
+		t.Errorf("tracked items = %v, want [skills/test-skill]", tracked.Items)
+	}
+
+	trackProjectItems(paths, claudeDir, []string{"skills/test-skill"}, true)
+	projects, _ = config.LoadProjects(paths.CcpDir)
+	if len(projects.Projects) != 0 {
+		t.Errorf("project still tracked after removing its last item: %v", projects.Projects)
+	}
 }
 
 func TestProjectAddDirect_OverwriteExisting(t *testing.T) {
@@ -175,7 +192,7 @@ func TestProjectAddDirect_OverwriteExisting(t *testing.T) {
 	os.MkdirAll(skillDir, 0755)
 	os.WriteFile(filepath.Join(skillDir, "skill.md"), []byte("# Updated Skill"), 0644)
 
-	paths := &config.Paths{HubDir: hubDir}
+	paths := &config.Paths{CcpDir: t.TempDir(), HubDir: hubDir}
 
 	// Pre-create existing item in project
 	claudeDir := filepath.Join(t.TempDir(), ".claude")
@@ -197,7 +214,7 @@ func TestProjectAddDirect_OverwriteExisting(t *testing.T) {
 
 func TestProjectAddDirect_HubItemNotFound(t *testing.T) {
 	hubDir := t.TempDir()
-	paths := &config.Paths{HubDir: hubDir}
+	paths := &config.Paths{CcpDir: t.TempDir(), HubDir: hubDir}
 	claudeDir := filepath.Join(t.TempDir(), ".claude")
 
 	err := runProjectAddDirect(paths, claudeDir, []string{"skills/nonexistent"})
@@ -208,7 +225,7 @@ func TestProjectAddDirect_HubItemNotFound(t *testing.T) {
 
 func TestProjectAddDirect_InvalidType(t *testing.T) {
 	hubDir := t.TempDir()
-	paths := &config.Paths{HubDir: hubDir}
+	paths := &config.Paths{CcpDir: t.TempDir(), HubDir: hubDir}
 	claudeDir := filepath.Join(t.TempDir(), ".claude")
 
 	err := runProjectAddDirect(paths, claudeDir, []string{"settings-templates/foo"})
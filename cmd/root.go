@@ -11,6 +11,9 @@ import (
 
 var Version = "dev"
 
+// offline disables network access for sources and registries (also CCP_OFFLINE=1)
+var offline bool
+
 var rootCmd = &cobra.Command{
 	Use:   "ccp",
 	Short: "Claude Code Profile manager",
@@ -54,4 +57,6 @@ func Execute() {
 
 func init() {
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Resolve installs and searches only from the local cache")
+	cobra.OnInitialize(initSourceCache)
 }
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 
@@ -12,14 +15,22 @@ import (
 )
 
 var runCmd = &cobra.Command{
-	Use:    "run <profile> -- <command> [args...]",
+	Use:    "run <profile> [--with type/name,...] -- <command> [args...]",
 	Hidden: true,
 	Short:  "Run a command with a specific profile active",
-	Long: `Execute a command with CLAUDE_CONFIG_DIR set to the specified profile.
+	Long: `Execute a command with CLAUDE_CONFIG_DIR set to the specified profile and
+the variables of the profile's [env] table set.
+
+With --with, the command runs in a temporary overlay of the profile with
+the given hub items added: the profile's contents are linked, settings are
+generated for the combined items, and the overlay is removed when the
+command exits, including on SIGTERM and SIGHUP. The profile itself is not
+changed.
 
 Examples:
   ccp run minimal -- claude "fix this bug"
   ccp run dev -- npm test
+  ccp run dev --with skills/k8s,agents/sre -- claude
   ccp run quickfix -- bash -c "claude && git commit"`,
 	Args:               cobra.MinimumNArgs(1),
 	DisableFlagParsing: true,
@@ -33,20 +44,29 @@ func init() {
 func runRunCmd(cmd *cobra.Command, args []string) error {
 	// Parse args manually to handle -- separator
 	profileName := ""
+	var withItems []string
 	var cmdArgs []string
 	foundSeparator := false
 
-	for _, arg := range args {
-		if arg == "--" {
-			foundSeparator = true
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if foundSeparator {
+			cmdArgs = append(cmdArgs, arg)
 			continue
 		}
-		if !foundSeparator {
-			if profileName == "" {
-				profileName = arg
+		switch {
+		case arg == "--":
+			foundSeparator = true
+		case arg == "--with":
+			if i+1 >= len(args) {
+				return fmt.Errorf("--with requires a value")
 			}
-		} else {
-			cmdArgs = append(cmdArgs, arg)
+			i++
+			withItems = append(withItems, splitItemList(args[i])...)
+		case strings.HasPrefix(arg, "--with="):
+			withItems = append(withItems, splitItemList(strings.TrimPrefix(arg, "--with="))...)
+		case profileName == "":
+			profileName = arg
 		}
 	}
 
@@ -69,18 +89,70 @@ func runRunCmd(cmd *cobra.Command, args []string) error {
 
 	// Verify profile exists
 	mgr := profile.NewManager(paths)
-	if !mgr.Exists(profileName) {
+	p, err := mgr.Get(profileName)
+	if err != nil {
+		return fmt.Errorf("failed to get profile: %w", err)
+	}
+	if p == nil {
 		return fmt.Errorf("profile not found: %s", profileName)
 	}
 
 	profilePath := paths.ProfileDir(profileName)
 
-	// Execute command with CLAUDE_CONFIG_DIR set
+	// Execute command with the profile's env and CLAUDE_CONFIG_DIR set
 	execCmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
 	execCmd.Stdin = os.Stdin
 	execCmd.Stdout = os.Stdout
 	execCmd.Stderr = os.Stderr
-	execCmd.Env = append(os.Environ(), fmt.Sprintf("CLAUDE_CONFIG_DIR=%s", profilePath))
+	env := append(os.Environ(), profileExecEnv(p.Manifest)...)
+
+	if len(withItems) == 0 {
+		execCmd.Env = append(env, fmt.Sprintf("CLAUDE_CONFIG_DIR=%s", profilePath))
+		return execCmd.Run()
+	}
+
+	overlay, err := mgr.CreateOverlay(profileName, withItems)
+	if err != nil {
+		return err
+	}
+	execCmd.Env = append(env, fmt.Sprintf("CLAUDE_CONFIG_DIR=%s", overlay.Dir))
+	return runInOverlay(execCmd, overlay)
+}
 
-	return execCmd.Run()
+// splitItemList splits a comma-separated --with value
+func splitItemList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// runInOverlay runs execCmd and removes the overlay once it exits. SIGTERM
+// and SIGHUP are passed on to the command instead of ending ccp, so the
+// overlay is removed however the command ends; Ctrl-C already reaches the
+// command through the terminal.
+func runInOverlay(execCmd *exec.Cmd, overlay *profile.Overlay) error {
+	defer overlay.Remove()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer func() {
+		signal.Stop(sigs)
+		close(sigs)
+	}()
+
+	if err := execCmd.Start(); err != nil {
+		return err
+	}
+	go func() {
+		for sig := range sigs {
+			if sig != os.Interrupt {
+				execCmd.Process.Signal(sig)
+			}
+		}
+	}()
+	return execCmd.Wait()
 }
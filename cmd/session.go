@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -11,22 +12,30 @@ import (
 	"github.com/samhoang/ccp/internal/profile"
 )
 
+var sessionWith []string
+
 var sessionCmd = &cobra.Command{
-	Use:    "session <profile>",
+	Use:    "session <profile> [--with type/name,...]",
 	Hidden: true,
 	Short:  "Start a shell session with a profile active",
-	Long: `Start a new shell with CLAUDE_CONFIG_DIR set to the specified profile.
+	Long: `Start a new shell with CLAUDE_CONFIG_DIR set to the specified profile and
+the variables of the profile's [env] table set.
 
 Exit the shell to return to your previous environment.
 
+With --with, the shell runs in a temporary overlay of the profile with the
+given hub items added (see 'ccp run'), removed when the shell exits.
+
 Examples:
   ccp session dev
-  ccp session minimal`,
+  ccp session minimal
+  ccp session dev --with skills/k8s,agents/sre`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSession,
 }
 
 func init() {
+	sessionCmd.Flags().StringSliceVar(&sessionWith, "with", nil, "Hub items to add for this session (type/name)")
 	rootCmd.AddCommand(sessionCmd)
 }
 
@@ -44,7 +53,11 @@ func runSession(cmd *cobra.Command, args []string) error {
 
 	// Verify profile exists
 	mgr := profile.NewManager(paths)
-	if !mgr.Exists(profileName) {
+	p, err := mgr.Get(profileName)
+	if err != nil {
+		return fmt.Errorf("failed to get profile: %w", err)
+	}
+	if p == nil {
 		return fmt.Errorf("profile not found: %s", profileName)
 	}
 
@@ -56,18 +69,33 @@ func runSession(cmd *cobra.Command, args []string) error {
 		shell = "/bin/sh"
 	}
 
+	var overlay *profile.Overlay
+	if len(sessionWith) > 0 {
+		overlay, err = mgr.CreateOverlay(profileName, sessionWith)
+		if err != nil {
+			return err
+		}
+		profilePath = overlay.Dir
+	}
+
 	fmt.Printf("Starting session with profile: %s\n", profileName)
+	if overlay != nil {
+		fmt.Printf("With: %s (temporary, removed on exit)\n", strings.Join(overlay.Items, ", "))
+	}
 	fmt.Printf("Exit the shell to return to your previous environment.\n\n")
 
-	// Execute shell with CLAUDE_CONFIG_DIR set
+	// Execute shell with the profile's env and CLAUDE_CONFIG_DIR set
 	execCmd := exec.Command(shell)
 	execCmd.Stdin = os.Stdin
 	execCmd.Stdout = os.Stdout
 	execCmd.Stderr = os.Stderr
-	execCmd.Env = append(os.Environ(),
+	execCmd.Env = append(append(os.Environ(), profileExecEnv(p.Manifest)...),
 		fmt.Sprintf("CLAUDE_CONFIG_DIR=%s", profilePath),
 		fmt.Sprintf("CCP_SESSION=%s", profileName),
 	)
 
+	if overlay != nil {
+		return runInOverlay(execCmd, overlay)
+	}
 	return execCmd.Run()
 }
@@ -140,9 +140,12 @@ func runSourceAdd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	var commit string
+	var commit, checksum string
 	if gitProvider, ok := provider.(*source.GitProvider); ok {
 		commit = gitProvider.GetCommit(sourceDir)
+	} else if latest, err := provider.CheckLatest(ctx, source.Source{URL: url, Ref: ref}); err == nil {
+		// Baseline for 'ccp hub outdated'
+		checksum = latest.Latest
 	}
 
 	installer := source.NewInstaller(paths, registry)
@@ -160,6 +163,7 @@ func runSourceAdd(cmd *cobra.Command, args []string) error {
 		Path:     sourceDir,
 		Ref:      ref,
 		Commit:   commit,
+		Checksum: checksum,
 	}
 
 	if err := registry.AddSource(sourceID, src); err != nil {
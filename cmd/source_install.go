@@ -6,10 +6,12 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/parallel"
 	"github.com/samhoang/ccp/internal/picker"
 	"github.com/samhoang/ccp/internal/source"
 )
@@ -17,6 +19,8 @@ import (
 var (
 	sourceInstallAll         bool
 	sourceInstallInteractive bool
+	sourceInstallParallel    int
+	sourceInstallTimeout     time.Duration
 )
 
 var sourceInstallCmd = &cobra.Command{
@@ -29,7 +33,7 @@ If the source is not already added, it will be added automatically.
 If no items are specified, interactive selection is shown.
 
 When called without arguments, syncs all sources from ccp.toml:
-- Clones missing sources
+- Clones missing sources (concurrently, --parallel at a time)
 - Reinstalls items listed in registry
 
 Examples:
@@ -44,6 +48,8 @@ Examples:
 func init() {
 	sourceInstallCmd.Flags().BoolVarP(&sourceInstallAll, "all", "a", false, "Install all available items")
 	sourceInstallCmd.Flags().BoolVarP(&sourceInstallInteractive, "interactive", "i", false, "Interactive item selection")
+	sourceInstallCmd.Flags().IntVarP(&sourceInstallParallel, "parallel", "j", parallel.DefaultWorkers, "Number of sources to sync at once")
+	sourceInstallCmd.Flags().DurationVar(&sourceInstallTimeout, "timeout", defaultSourceTimeout, "Time limit per source when syncing")
 	sourceCmd.AddCommand(sourceInstallCmd)
 }
 
@@ -111,6 +117,8 @@ func runSourceInstall(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		fmt.Printf("Installed %s from %s\n", item, sourceID)
+		refreshSearchIndex(paths)
+		reportLint(paths, []string{item})
 		fmt.Println()
 		fmt.Println("Link to profile with:")
 		fmt.Printf("  ccp link <profile> %s\n", item)
@@ -180,6 +188,8 @@ func runSourceInstall(cmd *cobra.Command, args []string) error {
 	for _, item := range installed {
 		fmt.Printf("  - %s\n", item)
 	}
+	refreshSearchIndex(paths)
+	reportLint(paths, installed)
 
 	fmt.Println()
 	fmt.Println("Link to profile with:")
@@ -265,9 +275,12 @@ func addSourceForInstall(identifier, refOverride string, paths *config.Paths, re
 		return err
 	}
 
-	var commit string
+	var commit, checksum string
 	if gitProvider, ok := provider.(*source.GitProvider); ok {
 		commit = gitProvider.GetCommit(sourceDir)
+	} else if latest, err := provider.CheckLatest(ctx, source.Source{URL: url, Ref: ref}); err == nil {
+		// Baseline for 'ccp hub outdated'
+		checksum = latest.Latest
 	}
 
 	registryName := "manual"
@@ -282,6 +295,7 @@ func addSourceForInstall(identifier, refOverride string, paths *config.Paths, re
 		Path:     sourceDir,
 		Ref:      ref,
 		Commit:   commit,
+		Checksum: checksum,
 	}
 
 	if err := registry.AddSource(sourceID, src); err != nil {
@@ -298,7 +312,7 @@ func addSourceForInstall(identifier, refOverride string, paths *config.Paths, re
 
 // runSourceSync syncs all sources from ccp.toml
 // For each source in registry:
-// 1. Clone if missing
+// 1. Clone if missing (concurrently, see sourceTaskOptions)
 // 2. Reinstall items from Installed list
 func runSourceSync() error {
 	paths, err := config.ResolvePaths()
@@ -319,86 +333,84 @@ func runSourceSync() error {
 
 	fmt.Printf("Syncing %d sources from registry...\n\n", len(sources))
 
-	installer := source.NewInstaller(paths, registry)
-	ctx := context.Background()
+	// Clone missing sources in parallel; the registry is only touched below
+	cloned := make([]bool, len(sources))
+	tasks := make([]parallel.Task, len(sources))
+	for i, entry := range sources {
+		tasks[i] = parallel.Task{
+			Name: entry.ID,
+			Run: func(ctx context.Context, report func(string)) (string, error) {
+				sourceDir := paths.SourceDir(entry.ID)
+				if _, err := os.Stat(sourceDir); err == nil {
+					return "source exists", nil
+				}
+				provider := source.GetProvider(entry.Source.Provider)
+				if provider == nil {
+					return "", fmt.Errorf("unknown provider: %s", entry.Source.Provider)
+				}
+				report("cloning from " + entry.Source.URL)
+				opts := source.FetchOptions{
+					Ref:      entry.Source.Ref,
+					Progress: false,
+				}
+				if err := provider.Fetch(ctx, entry.Source.URL, sourceDir, opts); err != nil {
+					return "", fmt.Errorf("clone failed: %w", err)
+				}
+				cloned[i] = true
+				return "cloned", nil
+			},
+		}
+	}
+	outcomes := parallel.Run(context.Background(), tasks, sourceTaskOptions(sourceInstallParallel, sourceInstallTimeout))
+	fmt.Println()
 
+	installer := source.NewInstaller(paths, registry)
 	var totalCloned, totalInstalled int
 
-	for _, entry := range sources {
-		fmt.Printf("Source: %s\n", entry.ID)
-
-		// Check if source directory exists
-		sourceDir := paths.SourceDir(entry.ID)
-		needsClone := false
-
-		if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
-			needsClone = true
+	for i, entry := range sources {
+		if outcomes[i].Err != nil {
+			continue
 		}
-
-		// Clone if missing
-		if needsClone {
-			provider := source.GetProvider(entry.Source.Provider)
-			if provider == nil {
-				fmt.Printf("  ⚠ Unknown provider: %s, skipping\n", entry.Source.Provider)
-				continue
-			}
-
-			fmt.Printf("  Cloning from %s...\n", entry.Source.URL)
-			opts := source.FetchOptions{
-				Ref:      entry.Source.Ref,
-				Progress: false,
-			}
-			if err := provider.Fetch(ctx, entry.Source.URL, sourceDir, opts); err != nil {
-				fmt.Printf("  ⚠ Clone failed: %v\n", err)
-				continue
-			}
-
+		if cloned[i] {
 			// Update path in registry (in case it changed)
 			src := entry.Source
-			src.Path = sourceDir
+			src.Path = paths.SourceDir(entry.ID)
 			registry.UpdateSource(entry.ID, src)
 			totalCloned++
-			fmt.Printf("  ✓ Cloned\n")
-		} else {
-			fmt.Printf("  ✓ Source exists\n")
 		}
 
 		// Reinstall items if any are missing from hub
-		if len(entry.Source.Installed) > 0 {
-			var missing []string
-			for _, item := range entry.Source.Installed {
-				parts := strings.SplitN(item, "/", 2)
-				if len(parts) != 2 {
-					continue
-				}
-				itemPath := paths.HubDir + "/" + item
-				if _, err := os.Stat(itemPath); os.IsNotExist(err) {
-					missing = append(missing, item)
-				}
+		var missing []string
+		for _, item := range entry.Source.Installed {
+			parts := strings.SplitN(item, "/", 2)
+			if len(parts) != 2 {
+				continue
 			}
+			itemPath := paths.HubDir + "/" + item
+			if _, err := os.Stat(itemPath); os.IsNotExist(err) {
+				missing = append(missing, item)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
 
-			if len(missing) > 0 {
-				fmt.Printf("  Installing %d missing items...\n", len(missing))
+		fmt.Printf("%s: installing %d missing items...\n", entry.ID, len(missing))
 
-				// Remove items from registry first so Install can add them back
-				for _, item := range missing {
-					registry.RemoveInstalled(entry.ID, item)
-				}
+		// Remove items from registry first so Install can add them back
+		for _, item := range missing {
+			registry.RemoveInstalled(entry.ID, item)
+		}
 
-				installed, err := installer.Install(entry.ID, missing)
-				if err != nil {
-					fmt.Printf("  ⚠ Install failed: %v\n", err)
-				} else {
-					for _, item := range installed {
-						fmt.Printf("    + %s\n", item)
-					}
-					totalInstalled += len(installed)
-				}
-			} else {
-				fmt.Printf("  ✓ All %d items present\n", len(entry.Source.Installed))
-			}
+		installed, err := installer.Install(entry.ID, missing)
+		if err != nil {
+			fmt.Printf("  ⚠ Install failed: %v\n", err)
+			continue
 		}
-		fmt.Println()
+		for _, item := range installed {
+			fmt.Printf("    + %s\n", item)
+		}
+		totalInstalled += len(installed)
 	}
 
 	if err := registry.Save(); err != nil {
@@ -406,5 +418,8 @@ func runSourceSync() error {
 	}
 
 	fmt.Printf("Sync complete: %d sources cloned, %d items installed\n", totalCloned, totalInstalled)
+	if err := parallel.Errors(outcomes); err != nil {
+		return fmt.Errorf("%d sources failed:\n%w", parallel.FailedCount(outcomes), err)
+	}
 	return nil
 }
@@ -3,28 +3,56 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/parallel"
 	"github.com/samhoang/ccp/internal/source"
 )
 
+var (
+	sourceUpdateParallel int
+	sourceUpdateTimeout  time.Duration
+)
+
 var sourceUpdateCmd = &cobra.Command{
 	Use:   "update [source]",
 	Short: "Update sources",
 	Long: `Update one or all sources to latest version.
 
+Sources are updated concurrently (--parallel at a time), each with its own
+--timeout. One failing source does not stop the others; failures are listed
+at the end.
+
 Examples:
   ccp source update                  # Update all sources
-  ccp source update samhoang/skills  # Update specific source`,
+  ccp source update samhoang/skills  # Update specific source
+  ccp source update -j 16 --timeout 1m`,
 	RunE: runSourceUpdate,
 }
 
 func init() {
+	sourceUpdateCmd.Flags().IntVarP(&sourceUpdateParallel, "parallel", "j", parallel.DefaultWorkers, "Number of sources to update at once")
+	sourceUpdateCmd.Flags().DurationVar(&sourceUpdateTimeout, "timeout", defaultSourceTimeout, "Time limit per source")
 	sourceCmd.AddCommand(sourceUpdateCmd)
 }
 
+// defaultSourceTimeout bounds one source's network operation
+const defaultSourceTimeout = 5 * time.Minute
+
+// sourceTaskOptions configures the worker pool and live progress display
+// shared by the commands that operate on many sources
+func sourceTaskOptions(workers int, timeout time.Duration) parallel.Options {
+	return parallel.Options{
+		Workers:  workers,
+		Timeout:  timeout,
+		Progress: parallel.NewDisplay(os.Stdout),
+	}
+}
+
 func runSourceUpdate(cmd *cobra.Command, args []string) error {
 	paths, err := config.ResolvePaths()
 	if err != nil {
@@ -54,42 +82,43 @@ func runSourceUpdate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	updated := 0
-	for _, entry := range toUpdate {
-		provider := source.GetProvider(entry.Source.Provider)
-		if provider == nil {
-			fmt.Printf("  %s: unknown provider %s\n", entry.ID, entry.Source.Provider)
-			continue
-		}
-
-		fmt.Printf("Updating %s...\n", entry.ID)
-
-		result, err := provider.Update(ctx, entry.Source.Path, source.UpdateOptions{
-			Ref: entry.Source.Ref,
-			URL: entry.Source.URL,
-		})
-		if err != nil {
-			fmt.Printf("  %s: %v\n", entry.ID, err)
-			continue
+	fmt.Printf("Updating %d sources...\n", len(toUpdate))
+	results := make([]*source.UpdateResult, len(toUpdate))
+	tasks := make([]parallel.Task, len(toUpdate))
+	for i, entry := range toUpdate {
+		tasks[i] = parallel.Task{
+			Name: entry.ID,
+			Run: func(ctx context.Context, report func(string)) (string, error) {
+				provider := source.GetProvider(entry.Source.Provider)
+				if provider == nil {
+					return "", fmt.Errorf("unknown provider %s", entry.Source.Provider)
+				}
+				report("fetching")
+				result, err := provider.Update(ctx, entry.Source.Path, source.UpdateOptions{
+					Ref: entry.Source.Ref,
+					URL: entry.Source.URL,
+				})
+				if err != nil {
+					return "", err
+				}
+				results[i] = result
+				if !result.Updated {
+					return "already up to date", nil
+				}
+				return fmt.Sprintf("updated %s -> %s", shortenSHA(result.OldCommit), shortenSHA(result.NewCommit)), nil
+			},
 		}
+	}
+	outcomes := parallel.Run(ctx, tasks, sourceTaskOptions(sourceUpdateParallel, sourceUpdateTimeout))
 
-		if result.Updated {
+	// Registry changes are applied here, after the workers have finished
+	updated := 0
+	for i, entry := range toUpdate {
+		if result := results[i]; result != nil && result.Updated {
 			src := entry.Source
 			src.Commit = result.NewCommit
 			registry.UpdateSource(entry.ID, src)
-
-			oldCommit := result.OldCommit
-			newCommit := result.NewCommit
-			if len(oldCommit) > 7 {
-				oldCommit = oldCommit[:7]
-			}
-			if len(newCommit) > 7 {
-				newCommit = newCommit[:7]
-			}
-			fmt.Printf("  %s: updated %s -> %s\n", entry.ID, oldCommit, newCommit)
 			updated++
-		} else {
-			fmt.Printf("  %s: already up to date\n", entry.ID)
 		}
 	}
 
@@ -100,5 +129,8 @@ func runSourceUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("\nUpdated %d/%d sources\n", updated, len(toUpdate))
+	if err := parallel.Errors(outcomes); err != nil {
+		return fmt.Errorf("%d sources failed:\n%w", parallel.FailedCount(outcomes), err)
+	}
 	return nil
 }
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/profile"
+	"github.com/samhoang/ccp/internal/stats"
+)
+
+var (
+	statsJSON        bool
+	statsUnusedAfter int
+	statsTools       int
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [profile]",
+	Short: "Show which linked hub items are actually used",
+	Long: `Report usage of linked skills, agents and commands from Claude Code
+session transcripts (the JSONL files in the projects/ data dir).
+
+Skill, agent (Task) and slash command invocations are attributed back to
+hub items, with invocation counts and last-used dates. Items linked for
+longer than --unused-after days without a single invocation are flagged.
+Hooks and rules apply implicitly and are not tracked.
+
+Without a profile, all profiles are reported together. Because projects/
+is shared by default, usage includes sessions run under any profile.
+
+Examples:
+  ccp stats
+  ccp stats dev --unused-after 30
+  ccp stats --json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProfileNames,
+	RunE:              runStats,
+}
+
+func init() {
+	statsCmd.Flags().BoolVarP(&statsJSON, "json", "j", false, "Output as JSON")
+	statsCmd.Flags().IntVar(&statsUnusedAfter, "unused-after", int(stats.DefaultUnusedAfter/(24*time.Hour)), "Flag items linked this many days without use")
+	statsCmd.Flags().IntVar(&statsTools, "tools", 10, "Number of most used tools to show (0 = none)")
+	rootCmd.AddCommand(statsCmd)
+}
+
+// linkedItemUsage is the usage of one item linked into one or more profiles
+type linkedItemUsage struct {
+	stats.ItemUsage
+	Profiles []string `json:"profiles"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	if statsTools < 0 {
+		return fmt.Errorf("invalid --tools: %d (must be 0 or more)", statsTools)
+	}
+	if statsUnusedAfter < 0 {
+		return fmt.Errorf("invalid --unused-after: %d (must be 0 or more)", statsUnusedAfter)
+	}
+
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	mgr := profile.NewManager(paths)
+	var profiles []*profile.Profile
+	if len(args) == 1 {
+		p, err := mgr.Get(args[0])
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return fmt.Errorf("profile not found: %s", args[0])
+		}
+		profiles = []*profile.Profile{p}
+	} else {
+		profiles, err = mgr.List()
+		if err != nil {
+			return fmt.Errorf("failed to list profiles: %w", err)
+		}
+	}
+
+	report, items, err := collectItemUsage(paths, profiles)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	minAge := time.Duration(statsUnusedAfter) * 24 * time.Hour
+	var unused []linkedItemUsage
+	for _, item := range items {
+		if item.Unused(minAge, now) {
+			unused = append(unused, item)
+		}
+	}
+	tools := topTools(report, statsTools)
+
+	if statsJSON {
+		if items == nil {
+			items = []linkedItemUsage{}
+		}
+		if unused == nil {
+			unused = []linkedItemUsage{}
+		}
+		output := map[string]interface{}{
+			"sessions":      report.Sessions,
+			"items":         items,
+			"unused":        unused,
+			"tools":         tools,
+			"skipped_lines": report.SkippedLines,
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(output)
+	}
+
+	fmt.Printf("Sessions scanned: %d", report.Sessions)
+	if !report.First.IsZero() {
+		fmt.Printf(" (%s to %s)", report.First.Local().Format("2006-01-02"), report.Last.Local().Format("2006-01-02"))
+	}
+	fmt.Println()
+	if report.SkippedLines > 0 {
+		fmt.Printf("Warning: skipped %d transcript line(s) too long to read\n", report.SkippedLines)
+	}
+	fmt.Println()
+
+	if len(items) == 0 {
+		fmt.Println("No linked skills, agents or commands")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ITEM\tUSES\tLAST USED\tLINKED")
+		for _, item := range items {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", item.Key(), item.Count, formatDate(item.LastUsed, "never"), formatDate(item.LinkedAt, "-"))
+		}
+		w.Flush()
+	}
+
+	if len(tools) > 0 {
+		fmt.Println()
+		fmt.Println("Top tools:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, t := range tools {
+			fmt.Fprintf(w, "  %s\t%d\t%s\n", t.Name, t.Count, formatDate(t.LastUsed, ""))
+		}
+		w.Flush()
+	}
+
+	if len(unused) > 0 {
+		fmt.Println()
+		fmt.Printf("Unused for %d+ days since linking:\n", statsUnusedAfter)
+		for _, item := range unused {
+			fmt.Printf("  - %s (linked %s in %s)\n", item.Key(), formatDate(item.LinkedAt, "-"), strings.Join(item.Profiles, ", "))
+		}
+		fmt.Println()
+		fmt.Println("Unlink with: ccp unlink <profile> <type/name>")
+	}
+
+	return nil
+}
+
+// collectItemUsage scans the transcripts visible to the given profiles and
+// returns the usage of every skill, agent and command linked into them,
+// directly or through a bundle, sorted by type then name
+func collectItemUsage(paths *config.Paths, profiles []*profile.Profile) (*stats.Report, []linkedItemUsage, error) {
+	dirs := []string{paths.SharedDataDir(config.DataProjects)}
+	for _, p := range profiles {
+		dirs = append(dirs, filepath.Join(p.Path, string(config.DataProjects)))
+	}
+	report, err := stats.ScanDirs(dirs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read session transcripts: %w", err)
+	}
+
+	byKey := make(map[string]*linkedItemUsage)
+	track := func(p *profile.Profile, itemType config.HubItemType, name string) {
+		if !stats.Trackable(itemType) {
+			return
+		}
+		linkedAt := stats.LinkTime(p.Path, itemType, name)
+		key := string(itemType) + "/" + name
+		entry, ok := byKey[key]
+		if !ok {
+			entry = &linkedItemUsage{ItemUsage: report.ForItem(itemType, name, linkedAt)}
+			byKey[key] = entry
+		} else if !linkedAt.IsZero() && (entry.LinkedAt.IsZero() || linkedAt.Before(entry.LinkedAt)) {
+			entry.LinkedAt = linkedAt
+		}
+		entry.Profiles = append(entry.Profiles, p.Name)
+	}
+
+	for _, p := range profiles {
+		for _, itemType := range config.AllHubItemTypes() {
+			for _, name := range p.Manifest.GetHubItems(itemType) {
+				track(p, itemType, name)
+			}
+		}
+		for _, bundleName := range p.Manifest.Hub.Bundles {
+			bundle, err := hub.LoadBundle(paths.BundlesDir(), bundleName)
+			if err != nil {
+				continue
+			}
+			for _, member := range bundle.Members.AllComponents() {
+				track(p, config.HubItemType(member.Type), member.Name)
+			}
+		}
+	}
+
+	items := make([]linkedItemUsage, 0, len(byKey))
+	for _, entry := range byKey {
+		items = append(items, *entry)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].Name < items[j].Name
+	})
+	return report, items, nil
+}
+
+// toolUsage is one row of the top tools table
+type toolUsage struct {
+	Name string `json:"name"`
+	stats.Usage
+}
+
+// topTools returns the n most invoked tools
+func topTools(report *stats.Report, n int) []toolUsage {
+	var tools []toolUsage
+	for name, u := range report.Usage[stats.KindTool] {
+		tools = append(tools, toolUsage{Name: name, Usage: *u})
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		if tools[i].Count != tools[j].Count {
+			return tools[i].Count > tools[j].Count
+		}
+		return tools[i].Name < tools[j].Name
+	})
+	if len(tools) > n {
+		tools = tools[:n]
+	}
+	return tools
+}
+
+func formatDate(t time.Time, zero string) string {
+	if t.IsZero() {
+		return zero
+	}
+	return t.Local().Format("2006-01-02")
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/hub"
 	"github.com/samhoang/ccp/internal/profile"
+	"github.com/samhoang/ccp/internal/source"
 )
 
 var statusJSON bool
@@ -24,8 +27,10 @@ var statusCmd = &cobra.Command{
 
 Shows:
 - Active profile
-- Hub item counts
+- Hub item counts, with an updates badge from recent 'ccp hub outdated' checks
 - Profile health (drift, broken symlinks)
+- Credentials of each profile (isolated or shared) and the account they
+  belong to; secrets are never printed
 - Overall system health`,
 	RunE: runStatus,
 }
@@ -73,6 +78,13 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	issues := checkHealth(paths)
 
+	// Updates badge from cached 'ccp hub outdated' checks; never hits the network
+	var updates []string
+	var updatesChecked time.Time
+	if registry, err := source.LoadRegistry(paths.RegistryPath()); err == nil {
+		updates, updatesChecked = source.LoadLatestCache(paths.CacheDir()).Outdated(registry, source.LatestTTL)
+	}
+
 	// JSON output
 	if statusJSON {
 		type hubCounts struct {
@@ -81,6 +93,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			Hooks             int `json:"hooks"`
 			Rules             int `json:"rules"`
 			Commands          int `json:"commands"`
+			OutputStyles      int `json:"output_styles"`
+			Statuslines       int `json:"statuslines"`
+			Memory            int `json:"memory"`
 			SettingsTemplates int `json:"settings_templates"`
 			Total             int `json:"total"`
 		}
@@ -90,6 +105,8 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			Active      bool   `json:"active"`
 			HasDrift    bool   `json:"has_drift,omitempty"`
 			BrokenLinks int    `json:"broken_links,omitempty"`
+			Credentials string `json:"credentials"`
+			Account     string `json:"account,omitempty"`
 		}
 
 		output := map[string]interface{}{
@@ -106,6 +123,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 				Hooks:             len(h.GetItems(config.HubHooks)),
 				Rules:             len(h.GetItems(config.HubRules)),
 				Commands:          len(h.GetItems(config.HubCommands)),
+				OutputStyles:      len(h.GetItems(config.HubOutputStyles)),
+				Statuslines:       len(h.GetItems(config.HubStatuslines)),
+				Memory:            len(h.GetItems(config.HubMemory)),
 				SettingsTemplates: len(h.GetItems(config.HubSettingsTemplates)),
 				Total:             h.ItemCount(),
 			}
@@ -119,11 +139,17 @@ func runStatus(cmd *cobra.Command, args []string) error {
 					Active:      p.name == activeProfile,
 					HasDrift:    p.hasDrift,
 					BrokenLinks: p.brokenLinks,
+					Credentials: string(p.credentials),
+					Account:     p.account,
 				})
 			}
 			output["profiles"] = profileList
 		}
 
+		if len(updates) > 0 {
+			output["updates_available"] = updates
+		}
+
 		if len(issues) > 0 {
 			output["issues"] = issues
 		}
@@ -156,6 +182,10 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		w.Flush()
 		fmt.Printf("  Total: %d items\n", h.ItemCount())
 	}
+	if len(updates) > 0 {
+		fmt.Printf("  [updates available] %d sources: %s (checked %s ago, run 'ccp source update')\n",
+			len(updates), strings.Join(updates, ", "), formatAge(time.Since(updatesChecked)))
+	}
 	fmt.Println()
 
 	// Profiles summary
@@ -175,7 +205,11 @@ func runStatus(cmd *cobra.Command, args []string) error {
 			if p.brokenLinks > 0 {
 				status = fmt.Sprintf("broken:%d", p.brokenLinks)
 			}
-			fmt.Fprintf(w, "  %s\t[%s]\n", p.name, status)
+			login := fmt.Sprintf("%s, not logged in", p.credentials)
+			if p.account != "" {
+				login = fmt.Sprintf("%s: %s", p.credentials, p.account)
+			}
+			fmt.Fprintf(w, "  %s\t[%s]\t%s\n", p.name, status, login)
 		}
 		w.Flush()
 		fmt.Printf("  Total: %d profiles\n", len(profiles))
@@ -195,14 +229,28 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatAge renders d coarsely: 5m, 3h, 2d
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
 type profileInfo struct {
 	name        string
 	hasDrift    bool
 	brokenLinks int
+	credentials config.ShareMode
+	account     string // login identity, never a secret
 }
 
 func listAllProfiles(paths *config.Paths) ([]profileInfo, error) {
 	var profiles []profileInfo
+	mgr := profile.NewManager(paths)
 
 	entries, err := os.ReadDir(paths.ProfilesDir)
 	if err != nil {
@@ -226,6 +274,11 @@ func listAllProfiles(paths *config.Paths) ([]profileInfo, error) {
 			p.hasDrift = true
 		}
 
+		p.credentials = mgr.CredentialsMode(profileDir)
+		if account, err := mgr.GetAccount(entry.Name()); err == nil {
+			p.account = account.Identity()
+		}
+
 		profiles = append(profiles, p)
 	}
 
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/profile"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync ~/.ccp across machines through a git remote",
+	Long: `Version the portable parts of ~/.ccp in a git repository and share them
+between machines.
+
+Synced: hub items and settings templates, profile manifests, settings
+fragments and CLAUDE.md, and ccp.toml (the source list).
+Not synced: shared and isolated session data, source clones, the plugin
+store, caches, projects.toml and machine.toml.
+
+~/.ccp/machine.toml holds tweaks for this host only and is applied on top of
+every generated settings.json:
+
+  [settings.env]
+  HTTPS_PROXY = "http://proxy.corp:3128"
+
+  [profiles.work.settings]
+  model = "sonnet"
+
+Examples:
+  ccp sync init git@github.com:me/ccp-config.git
+  ccp sync push
+  ccp sync pull`,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+}
+
+// applyPulledConfig rebuilds what sync leaves out after new configuration
+// arrived: missing profile directories, source clones and profile links
+// and settings
+func applyPulledConfig(paths *config.Paths) error {
+	mgr := profile.NewManager(paths)
+	profiles, err := mgr.List()
+	if err != nil {
+		return fmt.Errorf("failed to list profiles: %w", err)
+	}
+	for _, p := range profiles {
+		if err := mgr.Restore(p.Name); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to restore profile %s: %v\n", p.Name, err)
+		}
+	}
+
+	fmt.Println()
+	if err := runSourceSync(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	fmt.Println()
+	return syncAllProfiles(paths)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/gitsync"
+)
+
+var syncInitCmd = &cobra.Command{
+	Use:   "init <remote>",
+	Short: "Put ~/.ccp under git and connect it to a remote",
+	Long: `Initialize ~/.ccp as a git repository with <remote> as origin.
+
+If the remote is empty, this machine's configuration is committed and pushed.
+If it already holds a configuration from another machine, that is checked
+out: files present on both sides take the remote version (the local copies
+are backed up to ~/.ccp.pre-sync-<timestamp>), files only present here are
+kept for the next 'ccp sync push', and sources and profiles are rebuilt as
+after 'ccp sync pull'.
+
+If ~/.ccp is already a git repository, only the remote is (re)set.
+
+Examples:
+  ccp sync init git@github.com:me/ccp-config.git`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSyncInit,
+}
+
+func init() {
+	syncCmd.AddCommand(syncInitCmd)
+}
+
+func runSyncInit(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	if !paths.IsInitialized() {
+		return fmt.Errorf("ccp not initialized: run 'ccp init' first")
+	}
+
+	remote := args[0]
+	result, err := gitsync.New(paths.CcpDir).Init(remote)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case result.Existing:
+		fmt.Printf("✓ Remote set to %s (branch %s)\n", remote, result.Branch)
+		fmt.Println("  Run 'ccp sync pull' or 'ccp sync push' to sync")
+		return nil
+	case !result.Adopted:
+		fmt.Printf("✓ Pushed this machine's configuration to %s (branch %s)\n", remote, result.Branch)
+		return nil
+	}
+
+	fmt.Printf("✓ Checked out configuration from %s (branch %s)\n", remote, result.Branch)
+	if len(result.Replaced) > 0 {
+		fmt.Printf("⚠ %d local files replaced by the remote version:\n", len(result.Replaced))
+		for _, f := range result.Replaced {
+			fmt.Printf("  - %s\n", f)
+		}
+		fmt.Printf("  Previous versions saved to %s\n", result.BackupDir)
+	}
+	fmt.Println("  Local-only items are kept and uploaded by 'ccp sync push'")
+
+	return applyPulledConfig(paths)
+}
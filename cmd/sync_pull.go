@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/gitsync"
+)
+
+var syncPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Pull configuration pushed from other machines",
+	Long: `Pull changes to ~/.ccp from the remote, then rebuild what is not synced:
+
+  - profiles that only arrived as a manifest get their directories and
+    shared data links
+  - sources from ccp.toml are cloned and reinstalled ('ccp install')
+  - all profiles are synced ('ccp profile sync --all'), applying machine.toml
+
+Uncommitted local changes are kept and stay local until 'ccp sync push'.
+
+Examples:
+  ccp sync pull`,
+	Args: cobra.NoArgs,
+	RunE: runSyncPull,
+}
+
+func init() {
+	syncCmd.AddCommand(syncPullCmd)
+}
+
+func runSyncPull(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	result, err := gitsync.New(paths.CcpDir).Pull()
+	if err != nil {
+		return err
+	}
+
+	if len(result.Changed) == 0 {
+		fmt.Println("Already up to date")
+		return nil
+	}
+
+	fmt.Printf("✓ Pulled %d changed files\n", len(result.Changed))
+	return applyPulledConfig(paths)
+}
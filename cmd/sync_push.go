@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/gitsync"
+)
+
+var syncPushMessage string
+
+var syncPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Commit local changes to ~/.ccp and push them",
+	Long: `Commit changes to the synced parts of ~/.ccp and push them to the remote.
+
+Changes other machines pushed meanwhile are pulled first and local commits
+are replayed on top. Conflicting edits abort the push and leave ~/.ccp as it
+was; resolve them with git in ~/.ccp.
+
+Examples:
+  ccp sync push
+  ccp sync push -m "Add review skills"`,
+	Args: cobra.NoArgs,
+	RunE: runSyncPush,
+}
+
+func init() {
+	syncPushCmd.Flags().StringVarP(&syncPushMessage, "message", "m", "", "Commit message (default: \"ccp sync from <host>\")")
+	syncCmd.AddCommand(syncPushCmd)
+}
+
+func runSyncPush(cmd *cobra.Command, args []string) error {
+	paths, err := config.ResolvePaths()
+	if err != nil {
+		return err
+	}
+
+	result, err := gitsync.New(paths.CcpDir).Push(syncPushMessage)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case result.Pushed:
+		fmt.Println("✓ Pushed local changes")
+	case result.Committed:
+		fmt.Println("✓ Committed local changes (remote already had them)")
+	default:
+		fmt.Println("Already up to date")
+	}
+	return nil
+}
@@ -7,17 +7,35 @@ import (
 
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/profile"
+)
+
+var (
+	templateEditDryRun    bool
+	templateEditJSONPatch bool
 )
 
 var templateEditCmd = &cobra.Command{
-	Use:               "edit <name>",
-	Short:             "Edit a settings template in $EDITOR",
+	Use:   "edit <name>",
+	Short: "Edit a settings template in $EDITOR",
+	Long: `Edit a settings template in $EDITOR.
+
+With --dry-run the edit is not saved; instead the change to the generated
+settings of every profile using the template is shown. --json-patch prints
+the change to the template itself as an RFC 6902 JSON patch.
+
+Examples:
+  ccp template edit default
+  ccp template edit default --dry-run
+  ccp template edit default --dry-run --json-patch`,
 	Args:              cobra.ExactArgs(1),
 	ValidArgsFunction: completeTemplateNames,
 	RunE:              runTemplateEdit,
 }
 
 func init() {
+	templateEditCmd.Flags().BoolVarP(&templateEditDryRun, "dry-run", "n", false, "Show how profiles' settings would change without saving")
+	templateEditCmd.Flags().BoolVar(&templateEditJSONPatch, "json-patch", false, "With --dry-run, print the template change as a JSON patch")
 	templateCmd.AddCommand(templateEditCmd)
 }
 
@@ -43,6 +61,10 @@ func runTemplateEdit(cmd *cobra.Command, args []string) error {
 	// Remove hooks if present
 	delete(settings, "hooks")
 
+	if templateEditDryRun {
+		return previewTemplateEdit(paths, t, settings)
+	}
+
 	t.Settings = settings
 	if err := mgr.Save(t); err != nil {
 		return fmt.Errorf("failed to save template: %w", err)
@@ -51,3 +73,52 @@ func runTemplateEdit(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Updated template: %s (%d settings keys)\n", name, len(settings))
 	return nil
 }
+
+// previewTemplateEdit shows what saving settings as template t would change
+// in the generated settings of each profile using it
+func previewTemplateEdit(paths *config.Paths, t *hub.Template, settings map[string]interface{}) error {
+	if templateEditJSONPatch {
+		return printJSONPatch(profile.CompareSettings(t.Settings, settings))
+	}
+
+	profiles, err := profile.NewManager(paths).List()
+	if err != nil {
+		return fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	proj := &profile.SettingsProjection{Templates: map[string]map[string]interface{}{t.Name: settings}}
+	users := 0
+	for _, p := range profiles {
+		if p.Manifest == nil || p.Manifest.SettingsTemplate != t.Name {
+			continue
+		}
+		users++
+		before, err := profile.ProjectSettings(p.Manifest, paths, p.Path, nil)
+		if err != nil {
+			fmt.Printf("Warning: %s: %v\n", p.Name, err)
+			continue
+		}
+		after, err := profile.ProjectSettings(p.Manifest, paths, p.Path, proj)
+		if err != nil {
+			fmt.Printf("Warning: %s: %v\n", p.Name, err)
+			continue
+		}
+		changes := profile.CompareSettings(before, after)
+		fmt.Printf("=== %s ===\n", p.Name)
+		if len(changes) == 0 {
+			fmt.Println("  no change")
+		} else {
+			printSettingsChanges(changes)
+		}
+		fmt.Println()
+	}
+
+	if users == 0 {
+		fmt.Printf("No profile uses template '%s'. Template changes:\n", t.Name)
+		printSettingsChanges(profile.CompareSettings(t.Settings, settings))
+		fmt.Println()
+	}
+
+	fmt.Println("Dry run - no changes made")
+	return nil
+}
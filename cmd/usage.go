@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"text/tabwriter"
@@ -12,7 +11,7 @@ import (
 
 	"github.com/samhoang/ccp/internal/config"
 	"github.com/samhoang/ccp/internal/hub"
-	"github.com/samhoang/ccp/internal/profile"
+	"github.com/samhoang/ccp/internal/refs"
 )
 
 var usageCmd = &cobra.Command{
@@ -22,7 +21,9 @@ var usageCmd = &cobra.Command{
 	Long: `Display which hub items are used by which profiles.
 
 Helps identify:
-- Orphaned items (not used by any profile)
+- Orphaned items (not referenced by any profile, bundle, project,
+  source or plugin)
+- Items only used outside profiles
 - Shared items (used by multiple profiles)
 - Missing items (referenced but not in hub)`,
 	RunE: runUsage,
@@ -49,47 +50,25 @@ func runUsage(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to scan hub: %w", err)
 	}
 
+	graph, err := refs.Build(paths)
+	if err != nil {
+		return err
+	}
+
 	// Build usage map: item -> profiles
 	usage := make(map[string][]string)
 	missing := make(map[string][]string)
-
-	// Initialize with all hub items
 	for _, item := range h.AllItems() {
 		key := fmt.Sprintf("%s/%s", item.Type, item.Name)
-		usage[key] = []string{}
+		usage[key] = graph.Names(key, refs.KindProfile)
 	}
-
-	// Scan profiles
-	entries, err := os.ReadDir(paths.ProfilesDir)
-	if err != nil {
-		return fmt.Errorf("failed to read profiles: %w", err)
-	}
-
-	for _, entry := range entries {
-		if !entry.IsDir() || entry.Name() == "shared" {
+	for _, key := range graph.Keys() {
+		if _, exists := usage[key]; exists || strings.HasPrefix(key, string(config.HubBundles)+"/") {
 			continue
 		}
-
-		profileName := entry.Name()
-		profileDir := filepath.Join(paths.ProfilesDir, profileName)
-		manifestPath := profile.ManifestPath(profileDir)
-
-		manifest, err := profile.LoadManifest(manifestPath)
-		if err != nil {
-			continue
-		}
-
-		for _, itemType := range config.AllHubItemTypes() {
-			items := manifest.GetHubItems(itemType)
-			for _, itemName := range items {
-				key := fmt.Sprintf("%s/%s", itemType, itemName)
-				if _, exists := usage[key]; exists {
-					usage[key] = append(usage[key], profileName)
-				} else {
-					// Item in manifest but not in hub
-					missing[key] = append(missing[key], profileName)
-				}
-			}
+		// Item in manifest but not in hub
+		if profiles := graph.Names(key, refs.KindProfile); len(profiles) > 0 {
+			missing[key] = profiles
 		}
 	}
 
@@ -100,13 +79,16 @@ func runUsage(cmd *cobra.Command, args []string) error {
 	}
 	sort.Strings(keys)
 
-	// Find orphans and shared
+	// Find orphans, items used outside profiles, and shared
 	var orphans []string
+	var external []string
 	var shared []string
 
 	for _, key := range keys {
 		profiles := usage[key]
-		if len(profiles) == 0 {
+		if len(profiles) == 0 && len(graph.Refs(key)) > 0 {
+			external = append(external, key)
+		} else if len(profiles) == 0 {
 			orphans = append(orphans, key)
 		} else if len(profiles) > 1 {
 			shared = append(shared, key)
@@ -117,13 +99,22 @@ func runUsage(cmd *cobra.Command, args []string) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
 	if len(orphans) > 0 {
-		fmt.Println("=== Orphaned Items (not used by any profile) ===")
+		fmt.Println("=== Orphaned Items (not referenced anywhere) ===")
 		for _, item := range orphans {
 			fmt.Printf("  %s\n", item)
 		}
 		fmt.Println()
 	}
 
+	if len(external) > 0 {
+		fmt.Println("=== Used Outside Profiles ===")
+		for _, item := range external {
+			fmt.Fprintf(w, "  %s\t(%s)\n", item, graph.Reason(item))
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
 	if len(missing) > 0 {
 		fmt.Println("=== Missing Items (referenced but not in hub) ===")
 		for item, profiles := range missing {
@@ -145,6 +136,7 @@ func runUsage(cmd *cobra.Command, args []string) error {
 	fmt.Println("=== Summary ===")
 	fmt.Printf("  Hub items: %d\n", h.ItemCount())
 	fmt.Printf("  Orphaned:  %d\n", len(orphans))
+	fmt.Printf("  External:  %d\n", len(external))
 	fmt.Printf("  Missing:   %d\n", len(missing))
 	fmt.Printf("  Shared:    %d\n", len(shared))
 
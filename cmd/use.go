@@ -185,20 +185,25 @@ func switchToProfile(mgr *profile.Manager, paths *config.Paths, profileName stri
 	}
 
 	// Project mode: auto-detect environment file
-	return updateProjectEnv(profilePath, profileName)
+	return updateProjectEnv(profilePath, p)
 }
 
-func updateProjectEnv(profilePath, profileName string) error {
-	// Environment variables to set
-	envVars := map[string]string{
-		"CLAUDE_CONFIG_DIR": profilePath,
+func updateProjectEnv(profilePath string, p *profile.Profile) error {
+	profileName := p.Name
+
+	// Environment variables to set, with the profile's own in the syntax of
+	// the file they are written to
+	projectEnv := func(format string) map[string]string {
+		envVars := profileFileEnv(p.Manifest, format)
+		envVars["CLAUDE_CONFIG_DIR"] = profilePath
 		// Enable loading CLAUDE.md from additional directories (for --add-dir usage)
-		"CLAUDE_CODE_ADDITIONAL_DIRECTORIES_CLAUDE_MD": "1",
+		envVars["CLAUDE_CODE_ADDITIONAL_DIRECTORIES_CLAUDE_MD"] = "1"
+		return envVars
 	}
 
 	// 1. Check for mise.toml
 	if _, err := os.Stat("mise.toml"); err == nil {
-		if err := updateMiseTomlMulti(envVars); err != nil {
+		if err := updateMiseTomlMulti(projectEnv("mise")); err != nil {
 			return err
 		}
 		fmt.Printf("Profile '%s' configured for this project\n", profileName)
@@ -207,7 +212,7 @@ func updateProjectEnv(profilePath, profileName string) error {
 
 	// 2. Check for .envrc
 	if _, err := os.Stat(".envrc"); err == nil {
-		if err := updateEnvrcMulti(envVars); err != nil {
+		if err := updateEnvrcMulti(projectEnv("direnv")); err != nil {
 			return err
 		}
 		fmt.Printf("Profile '%s' configured for this project\n", profileName)
@@ -223,10 +228,7 @@ func updateProjectEnv(profilePath, profileName string) error {
 		fmt.Scanln(&response)
 		if response == "" || response == "y" || response == "Y" {
 			// Create minimal mise.toml with env vars
-			content := "[env]\n"
-			for k, v := range envVars {
-				content += fmt.Sprintf("%s = \"%s\"\n", k, v)
-			}
+			content := updateMiseContent("", projectEnv("mise"))
 			if err := os.WriteFile("mise.toml", []byte(content), 0644); err != nil {
 				return fmt.Errorf("failed to create mise.toml: %w", err)
 			}
@@ -239,8 +241,9 @@ func updateProjectEnv(profilePath, profileName string) error {
 	// 4. Fallback: print shell exports
 	fmt.Printf("No mise.toml or .envrc found.\n\n")
 	fmt.Printf("Add to your shell or run:\n")
-	for k, v := range envVars {
-		fmt.Printf("  export %s=\"%s\"\n", k, v)
+	envVars := projectEnv("shell")
+	for _, k := range sortedEnvKeys(envVars) {
+		fmt.Printf("  export %s=\"%s\"\n", k, envVars[k])
 	}
 	return nil
 }
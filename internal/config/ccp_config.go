@@ -19,6 +19,9 @@ type CcpConfig struct {
 	// Default registry for searches
 	DefaultRegistry string `toml:"default_registry"`
 
+	// Package cache settings
+	Cache CacheConfig `toml:"cache"`
+
 	// Installed sources (replaces registry.toml)
 	Sources map[string]SourceConfig `toml:"sources,omitempty"`
 }
@@ -57,6 +60,13 @@ type SkillsShConfig struct {
 	Limit int `toml:"limit"`
 }
 
+// CacheConfig holds package cache settings
+type CacheConfig struct {
+	// Refresh git mirrors on every online install and update, not only on
+	// 'ccp cache fill'
+	Mirror bool `toml:"mirror"`
+}
+
 // DefaultCcpConfig returns default configuration
 func DefaultCcpConfig() *CcpConfig {
 	return &CcpConfig{
@@ -10,6 +10,9 @@ const (
 	HookPostToolUse       HookType = "PostToolUse"
 	HookStop              HookType = "Stop"
 	HookSubagentStop      HookType = "SubagentStop"
+	HookNotification      HookType = "Notification"
+	HookPreCompact        HookType = "PreCompact"
+	HookSessionEnd        HookType = "SessionEnd"
 )
 
 // AllHookTypes returns all valid hook types
@@ -21,6 +24,9 @@ func AllHookTypes() []HookType {
 		HookPostToolUse,
 		HookStop,
 		HookSubagentStop,
+		HookNotification,
+		HookPreCompact,
+		HookSessionEnd,
 	}
 }
 
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// MachineFile holds per-host tweaks; 'ccp sync' never shares it
+const MachineFile = "machine.toml"
+
+// MachineConfig represents ~/.ccp/machine.toml, applied on top of the synced
+// configuration of this host only
+type MachineConfig struct {
+	// Settings is merged over the generated settings.json of every profile
+	Settings map[string]interface{} `toml:"settings,omitempty"`
+
+	// Profiles holds overrides for single profiles, keyed by profile name
+	Profiles map[string]MachineProfile `toml:"profiles,omitempty"`
+}
+
+// MachineProfile holds the host-specific overrides of one profile
+type MachineProfile struct {
+	// Settings is merged over the profile's settings after the host-wide ones
+	Settings map[string]interface{} `toml:"settings,omitempty"`
+}
+
+// LoadMachineConfig loads ~/.ccp/machine.toml; a missing file yields no overrides
+func LoadMachineConfig(ccpDir string) (*MachineConfig, error) {
+	m := &MachineConfig{}
+	data, err := os.ReadFile(filepath.Join(ccpDir, MachineFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, err
+	}
+	if err := toml.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SettingsOverlays returns the settings overrides for a profile in the order
+// they apply: host-wide first, then the profile's own
+func (m *MachineConfig) SettingsOverlays(profileName string) []map[string]interface{} {
+	var overlays []map[string]interface{}
+	if len(m.Settings) > 0 {
+		overlays = append(overlays, m.Settings)
+	}
+	if p, ok := m.Profiles[profileName]; ok && len(p.Settings) > 0 {
+		overlays = append(overlays, p.Settings)
+	}
+	return overlays
+}
@@ -26,6 +26,9 @@ const (
 	HubHooks             HubItemType = "hooks"
 	HubRules             HubItemType = "rules"
 	HubCommands          HubItemType = "commands"
+	HubOutputStyles      HubItemType = "output-styles"
+	HubStatuslines       HubItemType = "statuslines" // statusLine commands, wired into settings.json when linked
+	HubMemory            HubItemType = "memory"      // CLAUDE.md snippets, rendered rather than read by Claude Code
 	HubSettingsTemplates HubItemType = "settings-templates"
 
 	// HubBundles is a composite item type: an atomic, non-separable group of
@@ -40,7 +43,7 @@ const (
 // Note: HubBundles is intentionally excluded — it is a composite type handled
 // separately (see scanner.Scan and profile.LinkHubBundle).
 func AllHubItemTypes() []HubItemType {
-	return []HubItemType{HubSkills, HubAgents, HubHooks, HubRules, HubCommands, HubSettingsTemplates}
+	return []HubItemType{HubSkills, HubAgents, HubHooks, HubRules, HubCommands, HubOutputStyles, HubStatuslines, HubMemory, HubSettingsTemplates}
 }
 
 // DataItemType represents data directories that can be shared or isolated
@@ -55,8 +58,26 @@ const (
 	DataSessionEnv  DataItemType = "session-env"
 	DataProjects    DataItemType = "projects"
 	DataPlans       DataItemType = "plans"
+
+	// DataCredentials is the login of a config directory, a single file
+	// rather than a directory. It is deliberately NOT part of
+	// AllDataItemTypes(): it is isolated unless a profile opts into the
+	// shared login (see profile.Manager.ShareCredentials), and the directory
+	// loops must never link it to shared by default.
+	DataCredentials DataItemType = "credentials"
 )
 
+// CredentialsFile is where Claude Code stores the login of a config directory
+const CredentialsFile = ".credentials.json"
+
+// EntryName returns the name of the data item inside a config directory
+func (t DataItemType) EntryName() string {
+	if t == DataCredentials {
+		return CredentialsFile
+	}
+	return string(t)
+}
+
 // AllDataItemTypes returns all data item types
 func AllDataItemTypes() []DataItemType {
 	return []DataItemType{
@@ -76,6 +97,7 @@ func DefaultDataConfig() map[DataItemType]ShareMode {
 		DataSessionEnv:  ShareModeIsolated,
 		DataProjects:    ShareModeShared,
 		DataPlans:       ShareModeIsolated,
+		DataCredentials: ShareModeIsolated,
 	}
 }
 
@@ -170,7 +192,13 @@ func (p *Paths) HubItemPath(itemType HubItemType, name string) string {
 
 // SharedDataDir returns the shared data directory for a specific data type
 func (p *Paths) SharedDataDir(dataType DataItemType) string {
-	return filepath.Join(p.SharedDir, string(dataType))
+	return filepath.Join(p.SharedDir, dataType.EntryName())
+}
+
+// SharedCredentialsPath returns the credentials file shared by profiles
+// that use the shared login
+func (p *Paths) SharedCredentialsPath() string {
+	return p.SharedDataDir(DataCredentials)
 }
 
 // PluginsDir returns the plugins tracking directory
@@ -232,6 +260,16 @@ func (p *Paths) SourceDir(sourceID string) string {
 	return filepath.Join(p.SourcesDir(), safeName)
 }
 
+// CacheDir returns the directory for rebuildable caches (search index, etc.)
+func (p *Paths) CacheDir() string {
+	return filepath.Join(p.CcpDir, "cache")
+}
+
+// SearchIndexPath returns the path to the hub full-text search index
+func (p *Paths) SearchIndexPath() string {
+	return filepath.Join(p.CacheDir(), "search-index.json")
+}
+
 // StorePluginsDir returns the shared plugins store directory
 func (p *Paths) StorePluginsDir() string {
 	return filepath.Join(p.StoreDir, "plugins")
@@ -57,11 +57,11 @@ func TestPathsHubItemPath(t *testing.T) {
 
 func TestAllHubItemTypes(t *testing.T) {
 	types := AllHubItemTypes()
-	if len(types) != 6 {
-		t.Errorf("AllHubItemTypes() returned %d types, want 6", len(types))
+	if len(types) != 9 {
+		t.Errorf("AllHubItemTypes() returned %d types, want 9", len(types))
 	}
 
-	expected := []HubItemType{HubSkills, HubAgents, HubHooks, HubRules, HubCommands, HubSettingsTemplates}
+	expected := []HubItemType{HubSkills, HubAgents, HubHooks, HubRules, HubCommands, HubOutputStyles, HubStatuslines, HubMemory, HubSettingsTemplates}
 	for i, typ := range types {
 		if typ != expected[i] {
 			t.Errorf("types[%d] = %q, want %q", i, typ, expected[i])
@@ -79,6 +79,18 @@ func TestDefaultDataConfig(t *testing.T) {
 	if config[DataHistory] != ShareModeIsolated {
 		t.Errorf("DataHistory should be isolated")
 	}
+
+	if config[DataCredentials] != ShareModeIsolated {
+		t.Errorf("DataCredentials should be isolated")
+	}
+	for _, dataType := range AllDataItemTypes() {
+		if dataType == DataCredentials {
+			t.Errorf("DataCredentials must not be shared with the data directories")
+		}
+	}
+	if DataCredentials.EntryName() != CredentialsFile || DataTasks.EntryName() != "tasks" {
+		t.Errorf("EntryName() = %q, %q", DataCredentials.EntryName(), DataTasks.EntryName())
+	}
 }
 
 func TestPathsIsInitialized(t *testing.T) {
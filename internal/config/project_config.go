@@ -0,0 +1,201 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigNames are the file names of per-directory profile configs,
+// in lookup order
+var ProjectConfigNames = []string{".ccp.yaml", ".ccp.yml"}
+
+// ProjectConfig is a .ccp.yaml file selecting the profile for a directory
+// tree. Rules are tried in order against the working directory (relative to
+// the file); the first match wins, otherwise Profile applies. When the chosen
+// profile does not exist, Fallbacks are tried in order.
+//
+//	profile: dev
+//	rules:
+//	  - match: "services/*"
+//	    profile: backend
+//	  - match: "**/frontend"
+//	    profile: web
+//	fallbacks: [default]
+type ProjectConfig struct {
+	Profile   string        `yaml:"profile,omitempty"`
+	Rules     []ProjectRule `yaml:"rules,omitempty"`
+	Fallbacks []string      `yaml:"fallbacks,omitempty"`
+
+	// Path is the file the config was loaded from
+	Path string `yaml:"-"`
+}
+
+// ProjectRule maps a glob over directories below the config file to a
+// profile. Patterns use '/' separators; '*' and '?' match within one path
+// segment and '**' matches any number of segments. A rule also applies to
+// every directory below a matching one.
+type ProjectRule struct {
+	Match   string `yaml:"match"`
+	Profile string `yaml:"profile"`
+}
+
+// ProjectResolution is the outcome of resolving a directory's profile
+type ProjectResolution struct {
+	Profile    string // resolved, existing profile ("" when none applies)
+	ConfigPath string // .ccp.yaml that decided
+	Rule       string // matching rule pattern, "" for the default profile
+	Requested  string // profile named by the config before fallbacks
+}
+
+// LoadProjectConfig reads and validates a .ccp.yaml file
+func LoadProjectConfig(configPath string) (*ProjectConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	var cfg ProjectConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	for i, rule := range cfg.Rules {
+		if rule.Match == "" || rule.Profile == "" {
+			return nil, fmt.Errorf("%s: rule %d needs both match and profile", configPath, i+1)
+		}
+		if _, err := path.Match(strings.ReplaceAll(rule.Match, "**", "*"), ""); err != nil {
+			return nil, fmt.Errorf("%s: rule %d: invalid pattern %q", configPath, i+1, rule.Match)
+		}
+	}
+	cfg.Path = configPath
+	return &cfg, nil
+}
+
+// FindProjectConfig returns the nearest project config file in dir or its
+// parents, or "" when there is none
+func FindProjectConfig(dir string) string {
+	for {
+		for _, name := range ProjectConfigNames {
+			candidate := filepath.Join(dir, name)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ResolveProjectProfile determines the profile for dir from the nearest
+// .ccp.yaml. A config that names no profile for dir (no matching rule and no
+// default) defers to the next config further up. exists reports whether a
+// profile is available; missing profiles fall through to the config's
+// fallbacks. Returns an empty resolution when no config applies.
+func ResolveProjectProfile(dir string, exists func(string) bool) (*ProjectResolution, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	search := dir
+	for {
+		configPath := FindProjectConfig(search)
+		if configPath == "" {
+			return &ProjectResolution{}, nil
+		}
+		cfg, err := LoadProjectConfig(configPath)
+		if err != nil {
+			return nil, err
+		}
+
+		requested, rule := cfg.profileFor(dir)
+		if requested != "" {
+			res := &ProjectResolution{ConfigPath: configPath, Rule: rule, Requested: requested}
+			for _, candidate := range append([]string{requested}, cfg.Fallbacks...) {
+				if exists(candidate) {
+					res.Profile = candidate
+					return res, nil
+				}
+			}
+			return res, fmt.Errorf("profile not found: %s (from %s, no fallback available)", requested, configPath)
+		}
+
+		// Nothing here for dir: keep looking above this config's directory
+		configDir := filepath.Dir(configPath)
+		parent := filepath.Dir(configDir)
+		if parent == configDir {
+			return &ProjectResolution{}, nil
+		}
+		search = parent
+	}
+}
+
+// profileFor returns the profile the config assigns to dir and the rule
+// pattern that selected it
+func (c *ProjectConfig) profileFor(dir string) (string, string) {
+	rel, err := filepath.Rel(filepath.Dir(c.Path), dir)
+	if err == nil && !strings.HasPrefix(rel, "..") {
+		rel = filepath.ToSlash(rel)
+		for _, rule := range c.Rules {
+			if matchDirGlob(rule.Match, rel) {
+				return rule.Profile, rule.Match
+			}
+		}
+	}
+	return c.Profile, ""
+}
+
+// matchDirGlob reports whether rel or one of its ancestors (relative to the
+// config directory) matches pattern
+func matchDirGlob(pattern, rel string) bool {
+	pattern = strings.Trim(pattern, "/")
+	if rel == "." {
+		rel = ""
+	}
+	segments := strings.Split(rel, "/")
+	if rel == "" {
+		segments = nil
+	}
+	for n := len(segments); n >= 0; n-- {
+		if matchSegments(splitPattern(pattern), segments[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitPattern(pattern string) []string {
+	if pattern == "" || pattern == "." {
+		return nil
+	}
+	return strings.Split(pattern, "/")
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// "**" pattern segment consumes zero or more path segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchDirGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		rel     string
+		want    bool
+	}{
+		{"services/*", "services/api", true},
+		{"services/*", "services/api/src", true}, // below a matching dir
+		{"services/*", "services", false},
+		{"services/*", "web/services/api", false},
+		{"**/frontend", "apps/web/frontend/src", true},
+		{"**/frontend", "frontend", true},
+		{"docs", ".", false},
+		{"**", ".", true},
+		{"a?c/*", "abc/x", true},
+	}
+	for _, tt := range tests {
+		if got := matchDirGlob(tt.pattern, tt.rel); got != tt.want {
+			t.Errorf("matchDirGlob(%q, %q) = %v, want %v", tt.pattern, tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestResolveProjectProfile(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"services/api/src", "docs", "vendor/lib", "other"} {
+		if err := os.MkdirAll(filepath.Join(root, "repo", dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeConfig(filepath.Join(root, ".ccp.yaml"), "profile: personal\n")
+	writeConfig(filepath.Join(root, "repo", ".ccp.yaml"), `profile: dev
+rules:
+  - match: "services/*"
+    profile: backend
+  - match: docs
+    profile: writer
+fallbacks: [dev, personal]
+`)
+	// A nested config with rules only defers to its parents elsewhere
+	writeConfig(filepath.Join(root, "repo", "vendor", ".ccp.yml"), "rules:\n  - match: nothing\n    profile: x\n")
+
+	existing := map[string]bool{"dev": true, "backend": true, "personal": true}
+	exists := func(name string) bool { return existing[name] }
+
+	tests := []struct {
+		dir  string
+		want string
+		rule string
+	}{
+		{"repo", "dev", ""},
+		{"repo/services/api/src", "backend", "services/*"},
+		{"repo/docs", "dev", "docs"}, // writer missing -> first fallback
+		{"repo/vendor/lib", "dev", ""},
+		{".", "personal", ""},
+	}
+	for _, tt := range tests {
+		res, err := ResolveProjectProfile(filepath.Join(root, tt.dir), exists)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.dir, err)
+		}
+		if res.Profile != tt.want || res.Rule != tt.rule {
+			t.Errorf("%s: got profile %q rule %q, want %q rule %q", tt.dir, res.Profile, res.Rule, tt.want, tt.rule)
+		}
+	}
+
+	delete(existing, "dev")
+	delete(existing, "personal")
+	if _, err := ResolveProjectProfile(filepath.Join(root, "repo"), exists); err == nil {
+		t.Error("expected error when neither profile nor fallbacks exist")
+	}
+}
+
+func TestLoadProjectConfigValidatesRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ccp.yaml")
+	os.WriteFile(path, []byte("rules:\n  - match: \"[\"\n    profile: x\n"), 0644)
+	if _, err := LoadProjectConfig(path); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+	os.WriteFile(path, []byte("rules:\n  - match: services\n"), 0644)
+	if _, err := LoadProjectConfig(path); err == nil {
+		t.Error("expected error for rule without profile")
+	}
+}
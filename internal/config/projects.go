@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ProjectsFile records hub items copied into projects by 'ccp project add'
+const ProjectsFile = "projects.toml"
+
+// Projects tracks which hub items each project received, keyed by project root
+type Projects struct {
+	Projects map[string]TrackedProject `toml:"projects"`
+}
+
+// TrackedProject is a project that had hub items copied into its .claude/
+type TrackedProject struct {
+	Items   []string  `toml:"items"` // hub item keys, e.g. "skills/foo"
+	Updated time.Time `toml:"updated"`
+}
+
+// LoadProjects loads ~/.ccp/projects.toml; a missing file yields no projects
+func LoadProjects(ccpDir string) (*Projects, error) {
+	p := &Projects{Projects: make(map[string]TrackedProject)}
+	data, err := os.ReadFile(filepath.Join(ccpDir, ProjectsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return p, nil
+		}
+		return nil, err
+	}
+	if err := toml.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	if p.Projects == nil {
+		p.Projects = make(map[string]TrackedProject)
+	}
+	return p, nil
+}
+
+// Save writes projects.toml to disk
+func (p *Projects) Save(ccpDir string) error {
+	data, err := toml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(ccpDir, ProjectsFile), data, 0644)
+}
+
+// Track records that items were copied into the project at dir
+func (p *Projects) Track(dir string, items ...string) {
+	project := p.Projects[dir]
+	for _, item := range items {
+		if !slices.Contains(project.Items, item) {
+			project.Items = append(project.Items, item)
+		}
+	}
+	sort.Strings(project.Items)
+	project.Updated = time.Now()
+	p.Projects[dir] = project
+}
+
+// Untrack forgets items removed from the project at dir, and the project
+// itself once it has none left
+func (p *Projects) Untrack(dir string, items ...string) {
+	project, ok := p.Projects[dir]
+	if !ok {
+		return
+	}
+	project.Items = slices.DeleteFunc(project.Items, func(item string) bool {
+		return slices.Contains(items, item)
+	})
+	if len(project.Items) == 0 {
+		delete(p.Projects, dir)
+		return
+	}
+	project.Updated = time.Now()
+	p.Projects[dir] = project
+}
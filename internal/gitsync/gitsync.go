@@ -0,0 +1,410 @@
+// Package gitsync versions the portable parts of ~/.ccp in a git repository
+// so the same hub, templates and profiles can be shared across machines.
+package gitsync
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// DefaultBranch is used when ~/.ccp is put under version control by ccp
+const DefaultBranch = "main"
+
+// ErrNotSetUp is returned by Push and Pull before 'ccp sync init'
+var ErrNotSetUp = fmt.Errorf("sync not set up: run 'ccp sync init <remote>' first")
+
+// ignoreMarker starts the block of .gitignore rules written by ccp
+const ignoreMarker = "# ccp sync: machine-local state"
+
+// ignoreRules keeps rebuildable and host-specific state out of the repo.
+// Profiles are whitelisted: only the manifest (profile.toml, or the legacy
+// profile.yaml), settings fragment and CLAUDE.md are portable; symlinks, generated settings.json, credentials
+// and session data are recreated or stay on each machine.
+var ignoreRules = []string{
+	"/sources/",
+	"/store/",
+	"/cache/",
+	"/registry.toml",
+	"/" + config.ProjectsFile,
+	"/" + config.MachineFile,
+	"/profiles/shared/",
+	"/profiles/*/*",
+	"!/profiles/*/profile.toml",
+	"!/profiles/*/profile.yaml",
+	"!/profiles/*/settings-fragment.json",
+	"!/profiles/*/CLAUDE.md",
+}
+
+// Repo is a ccp directory versioned for 'ccp sync'
+type Repo struct {
+	dir      string
+	identity []string // -c flags when git has no user configured
+}
+
+// InitResult describes what Init did
+type InitResult struct {
+	Branch    string
+	Existing  bool     // ~/.ccp was already a git repository; only the remote was set
+	Adopted   bool     // the remote already held a configuration, now checked out
+	Replaced  []string // local files replaced by the remote version when adopting
+	BackupDir string   // where the replaced files were saved
+}
+
+// PushResult describes what Push did
+type PushResult struct {
+	Committed bool // local changes were committed
+	Pushed    bool // commits were sent to the remote
+}
+
+// PullResult describes what Pull did
+type PullResult struct {
+	Changed []string // files changed by the pull, relative to the ccp dir
+}
+
+// New returns the sync repository rooted at ccpDir
+func New(ccpDir string) *Repo {
+	return &Repo{dir: ccpDir}
+}
+
+// IsInitialized reports whether the ccp directory is a git repository
+func (r *Repo) IsInitialized() bool {
+	_, err := os.Stat(filepath.Join(r.dir, ".git"))
+	return err == nil
+}
+
+// Remote returns the URL of the origin remote
+func (r *Repo) Remote() (string, error) {
+	return r.git("remote", "get-url", "origin")
+}
+
+// Init puts the ccp directory under version control with remote as origin.
+// An empty remote receives the local configuration; a remote that already
+// holds one is checked out, so files present on both sides take the remote
+// version (local copies are saved under BackupDir) while local-only files
+// are kept for the next push.
+func (r *Repo) Init(remote string) (*InitResult, error) {
+	if err := r.writeIgnore(); err != nil {
+		return nil, fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+
+	if r.IsInitialized() {
+		if err := r.setRemote(remote); err != nil {
+			return nil, err
+		}
+		branch, err := r.branch()
+		if err != nil {
+			return nil, err
+		}
+		return &InitResult{Branch: branch, Existing: true}, nil
+	}
+
+	if _, err := r.git("init", "-q", "-b", DefaultBranch); err != nil {
+		return nil, fmt.Errorf("git init failed: %w", err)
+	}
+	if err := r.setRemote(remote); err != nil {
+		return nil, err
+	}
+
+	result := &InitResult{Branch: DefaultBranch}
+	remoteBranch, err := r.remoteDefaultBranch()
+	if err != nil {
+		return nil, err
+	}
+	if remoteBranch == "" {
+		// Empty remote: this machine seeds it
+		if _, err := r.commitAll("ccp sync init from " + hostname()); err != nil {
+			return nil, err
+		}
+		if _, err := r.git("push", "-q", "-u", "origin", DefaultBranch); err != nil {
+			return nil, fmt.Errorf("git push failed: %w", err)
+		}
+		return result, nil
+	}
+
+	if err := r.adopt(remoteBranch, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// adopt checks out the configuration already on the remote
+func (r *Repo) adopt(remoteBranch string, result *InitResult) error {
+	if _, err := r.git("fetch", "-q", "origin", remoteBranch); err != nil {
+		return fmt.Errorf("git fetch failed: %w", err)
+	}
+	if remoteBranch != DefaultBranch {
+		if _, err := r.git("symbolic-ref", "HEAD", "refs/heads/"+remoteBranch); err != nil {
+			return err
+		}
+	}
+	upstream := "origin/" + remoteBranch
+	if _, err := r.git("reset", "-q", upstream); err != nil {
+		return fmt.Errorf("git reset failed: %w", err)
+	}
+
+	// Tracked files whose local content differs from the remote
+	out, err := r.git("diff", "--name-only", "--diff-filter=M")
+	if err != nil {
+		return err
+	}
+	if out != "" {
+		result.Replaced = strings.Split(out, "\n")
+		result.BackupDir = r.dir + ".pre-sync-" + time.Now().Format("20060102-150405")
+		for _, rel := range result.Replaced {
+			if err := backupFile(filepath.Join(r.dir, rel), filepath.Join(result.BackupDir, rel)); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", rel, err)
+			}
+		}
+	}
+	if _, err := r.git("checkout", "-q", "--", "."); err != nil {
+		return fmt.Errorf("git checkout failed: %w", err)
+	}
+	if _, err := r.git("branch", "-q", "--set-upstream-to="+upstream); err != nil {
+		return err
+	}
+
+	result.Branch = remoteBranch
+	result.Adopted = true
+	return nil
+}
+
+// Push commits local changes and sends them to the remote, first replaying
+// them on top of what other machines pushed
+func (r *Repo) Push(message string) (*PushResult, error) {
+	if !r.IsInitialized() {
+		return nil, ErrNotSetUp
+	}
+	branch, err := r.branch()
+	if err != nil {
+		return nil, err
+	}
+	if message == "" {
+		message = "ccp sync from " + hostname()
+	}
+
+	result := &PushResult{}
+	if result.Committed, err = r.commitAll(message); err != nil {
+		return nil, err
+	}
+
+	onRemote, err := r.git("ls-remote", "--heads", "origin", branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach remote: %w", err)
+	}
+	if onRemote != "" {
+		if err := r.rebase(branch); err != nil {
+			return nil, err
+		}
+		ahead, err := r.git("rev-list", "--count", "origin/"+branch+"..HEAD")
+		if err != nil {
+			return nil, err
+		}
+		if ahead == "0" {
+			return result, nil
+		}
+	}
+
+	if _, err := r.git("push", "-q", "-u", "origin", "HEAD:"+branch); err != nil {
+		return nil, fmt.Errorf("git push failed: %w", err)
+	}
+	result.Pushed = true
+	return result, nil
+}
+
+// Pull brings in what other machines pushed. Uncommitted local changes are
+// kept (autostash) and stay local until the next push.
+func (r *Repo) Pull() (*PullResult, error) {
+	if !r.IsInitialized() {
+		return nil, ErrNotSetUp
+	}
+	branch, err := r.branch()
+	if err != nil {
+		return nil, err
+	}
+	before, _ := r.git("rev-parse", "-q", "--verify", "HEAD")
+
+	if err := r.rebase(branch); err != nil {
+		return nil, err
+	}
+
+	after, err := r.git("rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	result := &PullResult{}
+	if before == after {
+		return result, nil
+	}
+	var out string
+	if before == "" {
+		out, err = r.git("ls-files")
+	} else {
+		out, err = r.git("diff", "--name-only", before, after)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if out != "" {
+		result.Changed = strings.Split(out, "\n")
+	}
+	return result, nil
+}
+
+// rebase replays local commits on top of the remote branch. On conflict the
+// rebase is aborted so ~/.ccp is left as it was.
+func (r *Repo) rebase(branch string) error {
+	if _, err := r.git("pull", "-q", "--rebase", "--autostash", "origin", branch); err != nil {
+		conflicts, _ := r.git("diff", "--name-only", "--diff-filter=U")
+		r.git("rebase", "--abort")
+		if conflicts != "" {
+			return fmt.Errorf("conflicting changes to %s: resolve with 'git -C %s pull --rebase'",
+				strings.ReplaceAll(conflicts, "\n", ", "), r.dir)
+		}
+		return fmt.Errorf("git pull failed: %w", err)
+	}
+	return nil
+}
+
+// commitAll stages everything not ignored and commits it; it reports
+// whether there was anything to commit
+func (r *Repo) commitAll(message string) (bool, error) {
+	if _, err := r.git("add", "-A"); err != nil {
+		return false, fmt.Errorf("git add failed: %w", err)
+	}
+	status, err := r.git("status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	if status == "" {
+		return false, nil
+	}
+	if _, err := r.git("commit", "-q", "-m", message); err != nil {
+		return false, fmt.Errorf("git commit failed: %w", err)
+	}
+	return true, nil
+}
+
+func (r *Repo) setRemote(remote string) error {
+	if _, err := r.Remote(); err == nil {
+		_, err = r.git("remote", "set-url", "origin", remote)
+		return err
+	}
+	_, err := r.git("remote", "add", "origin", remote)
+	return err
+}
+
+// branch returns the checked out branch
+func (r *Repo) branch() (string, error) {
+	branch, err := r.git("symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("%s is not on a branch: %w", r.dir, err)
+	}
+	return branch, nil
+}
+
+// remoteDefaultBranch returns the branch origin's HEAD points to (else
+// DefaultBranch or the first branch), or "" when the remote has no commits yet
+func (r *Repo) remoteDefaultBranch() (string, error) {
+	out, err := r.git("ls-remote", "--symref", "origin", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to reach remote: %w", err)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if ref, ok := strings.CutPrefix(line, "ref: refs/heads/"); ok {
+			return strings.Fields(ref)[0], nil
+		}
+	}
+
+	// A bare repository's HEAD may name a branch that was never pushed
+	heads, err := r.git("ls-remote", "--heads", "origin")
+	if err != nil {
+		return "", fmt.Errorf("failed to reach remote: %w", err)
+	}
+	var first string
+	for _, line := range strings.Split(heads, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "refs/heads/")
+		if name == DefaultBranch {
+			return name, nil
+		}
+		if first == "" {
+			first = name
+		}
+	}
+	return first, nil
+}
+
+// writeIgnore adds ccp's rules to .gitignore, keeping any of the user's own
+func (r *Repo) writeIgnore() error {
+	path := filepath.Join(r.dir, ".gitignore")
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if strings.Contains(string(existing), ignoreMarker) {
+		return nil
+	}
+
+	var b strings.Builder
+	b.Write(existing)
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString(ignoreMarker + "\n")
+	for _, rule := range ignoreRules {
+		b.WriteString(rule + "\n")
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// git runs git in the repo and returns its trimmed stdout; failures carry
+// git's stderr
+func (r *Repo) git(args ...string) (string, error) {
+	if r.identity == nil {
+		r.identity = []string{}
+		if out, _ := exec.Command("git", "-C", r.dir, "config", "user.email").Output(); len(strings.TrimSpace(string(out))) == 0 {
+			r.identity = []string{"-c", "user.name=ccp", "-c", "user.email=ccp@" + hostname()}
+		}
+	}
+
+	full := append([]string{"-C", r.dir}, r.identity...)
+	cmd := exec.Command("git", append(full, args...)...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return "", fmt.Errorf("%w: %s", err, detail)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func hostname() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "unknown-host"
+	}
+	return host
+}
+
+func backupFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0644)
+}
@@ -0,0 +1,179 @@
+package gitsync
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// newCcpDir lays out a minimal ~/.ccp with portable and machine-local files,
+// plus any extra files (relative path to content)
+func newCcpDir(t *testing.T, model string, extra map[string]string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), ".ccp")
+	files := map[string]string{
+		"ccp.toml":                           "default_registry = 'skills.sh'\n",
+		"hub/skills/debug/SKILL.md":          "# debug\n",
+		"profiles/default/profile.toml":      "name = 'default'\nmodel = '" + model + "'\n",
+		"profiles/default/settings.json":     "{}\n",
+		"profiles/default/.credentials.json": "secret\n",
+		"sources/owner--repo/README.md":      "clone\n",
+		"machine.toml":                       "[settings]\nmodel = 'local'\n",
+	}
+	for name, content := range extra {
+		files[name] = content
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func newRemote(t *testing.T) string {
+	t.Helper()
+	remote := filepath.Join(t.TempDir(), "ccp-config.git")
+	if out, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare: %v: %s", err, out)
+	}
+	return remote
+}
+
+func TestInitPushPull(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	remote := newRemote(t)
+
+	// Machine A seeds the empty remote
+	dirA := newCcpDir(t, "opus", nil)
+	a := New(dirA)
+	res, err := a.Init(remote)
+	if err != nil {
+		t.Fatalf("Init(A) error = %v", err)
+	}
+	if res.Adopted || res.Branch != DefaultBranch {
+		t.Errorf("Init(A) = %+v, want seeded %s", res, DefaultBranch)
+	}
+	tracked, err := a.git("ls-files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"ccp.toml", "hub/skills/debug/SKILL.md", "profiles/default/profile.toml"} {
+		if !slices.Contains(splitLines(tracked), want) {
+			t.Errorf("%s not tracked; tracked = %q", want, tracked)
+		}
+	}
+	for _, local := range []string{"profiles/default/settings.json", "profiles/default/.credentials.json", "sources/owner--repo/README.md", "machine.toml"} {
+		if slices.Contains(splitLines(tracked), local) {
+			t.Errorf("machine-local %s is tracked", local)
+		}
+	}
+
+	// Machine B adopts it: shared files take the remote version (backed up),
+	// local-only items are kept
+	dirB := newCcpDir(t, "sonnet", map[string]string{"hub/rules/style.md": "be terse\n"})
+	b := New(dirB)
+	res, err = b.Init(remote)
+	if err != nil {
+		t.Fatalf("Init(B) error = %v", err)
+	}
+	if !res.Adopted || !slices.Contains(res.Replaced, "profiles/default/profile.toml") {
+		t.Fatalf("Init(B) = %+v, want adopted with profile.toml replaced", res)
+	}
+	profilePath := filepath.Join("profiles", "default", "profile.toml")
+	if got := readFile(t, filepath.Join(dirB, profilePath)); got != readFile(t, filepath.Join(dirA, profilePath)) {
+		t.Errorf("B profile.toml = %q, want remote version", got)
+	}
+	if got := readFile(t, filepath.Join(res.BackupDir, profilePath)); got != "name = 'default'\nmodel = 'sonnet'\n" {
+		t.Errorf("backup = %q", got)
+	}
+	if got := readFile(t, filepath.Join(dirB, "machine.toml")); got != "[settings]\nmodel = 'local'\n" {
+		t.Errorf("machine.toml touched: %q", got)
+	}
+
+	push, err := b.Push("")
+	if err != nil {
+		t.Fatalf("Push(B) error = %v", err)
+	}
+	if !push.Committed || !push.Pushed {
+		t.Errorf("Push(B) = %+v, want the local rule committed and pushed", push)
+	}
+	if push, err := b.Push(""); err != nil || push.Committed || push.Pushed {
+		t.Errorf("second Push(B) = %+v, %v; want nothing to do", push, err)
+	}
+
+	pull, err := a.Pull()
+	if err != nil {
+		t.Fatalf("Pull(A) error = %v", err)
+	}
+	if !slices.Contains(pull.Changed, "hub/rules/style.md") {
+		t.Errorf("Pull(A) changed = %v, want hub/rules/style.md", pull.Changed)
+	}
+	if pull, err := a.Pull(); err != nil || len(pull.Changed) != 0 {
+		t.Errorf("second Pull(A) = %+v, %v; want up to date", pull, err)
+	}
+}
+
+func TestPushNotSetUp(t *testing.T) {
+	if _, err := New(t.TempDir()).Push(""); err != ErrNotSetUp {
+		t.Errorf("Push() error = %v, want ErrNotSetUp", err)
+	}
+}
+
+func TestWriteIgnoreKeepsUserRules(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.bak"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r := New(dir)
+	if err := r.writeIgnore(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.writeIgnore(); err != nil {
+		t.Fatal(err)
+	}
+	lines := splitLines(readFile(t, filepath.Join(dir, ".gitignore")))
+	if lines[0] != "*.bak" || lines[1] != ignoreMarker || len(lines) != 2+len(ignoreRules) {
+		t.Errorf(".gitignore = %q", lines)
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	if _, err := r.git("init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	// Both manifest formats sync; generated settings stay local
+	for path, ignored := range map[string]bool{
+		"profiles/dev/profile.toml":    false,
+		"profiles/legacy/profile.yaml": false,
+		"profiles/dev/settings.json":   true,
+	} {
+		_, err := r.git("check-ignore", "-q", path)
+		if got := err == nil; got != ignored {
+			t.Errorf("%s ignored = %v, want %v", path, got, ignored)
+		}
+	}
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimRight(s, "\n"), "\n")
+}
@@ -1,8 +1,17 @@
 package hub
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -10,6 +19,11 @@ import (
 // BundleManifestFile is the metadata file stored at the root of every bundle.
 const BundleManifestFile = "bundle.yaml"
 
+// BundleSettingsFile is an optional settings.json fragment stored next to
+// bundle.yaml. It is merged into the settings of every profile linking the
+// bundle (permissions, env, model, ...).
+const BundleSettingsFile = "settings.json"
+
 // Bundle is an atomic, non-separable group of hub items (skills, agents,
 // hooks, rules, commands) stored together under hub/bundles/<name>/ and
 // installed/linked/removed as a single unit. Because members live inside the
@@ -21,6 +35,136 @@ type Bundle struct {
 	Description string        `yaml:"description,omitempty"`
 	Version     string        `yaml:"version,omitempty"`
 	Members     ComponentList `yaml:"members"`
+
+	// Origins records where each member was copied from, keyed by the
+	// member's "type/name". Bundles created before origins were tracked have
+	// none; 'ccp bundle update' fills them in.
+	Origins map[string]MemberOrigin `yaml:"origins,omitempty"`
+}
+
+// MemberOrigin is the hub item a bundle member was copied from and the state
+// of that item at copy time.
+type MemberOrigin struct {
+	Item     string    `yaml:"item"`             // hub item key, e.g. "skills/foo"
+	Source   string    `yaml:"source,omitempty"` // upstream of the hub item, if tracked
+	Commit   string    `yaml:"commit,omitempty"` // upstream commit at copy time
+	Hash     string    `yaml:"hash"`             // ContentHash of the copied member
+	SyncedAt time.Time `yaml:"synced_at"`
+}
+
+// MemberKey returns the Origins key for a bundle member.
+func MemberKey(ref ComponentRef) string {
+	return ref.Type + "/" + ref.Name
+}
+
+// Origin returns the recorded origin of a member, or nil if none is known.
+func (b *Bundle) Origin(ref ComponentRef) *MemberOrigin {
+	origin, ok := b.Origins[MemberKey(ref)]
+	if !ok {
+		return nil
+	}
+	return &origin
+}
+
+// SetOrigin records the origin of a member.
+func (b *Bundle) SetOrigin(ref ComponentRef, origin MemberOrigin) {
+	if b.Origins == nil {
+		b.Origins = make(map[string]MemberOrigin)
+	}
+	b.Origins[MemberKey(ref)] = origin
+}
+
+// BumpVersion increments a "major.minor.patch" version. With minor set the
+// minor component is bumped and patch reset. An empty or unparsable version
+// starts over at 1.0.0 so the next version is always well-formed.
+func BumpVersion(version string, minor bool) string {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	nums := make([]int, 3)
+	valid := len(parts) == 3
+	for i := 0; valid && i < 3; i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 {
+			valid = false
+		}
+		nums[i] = n
+	}
+	if !valid {
+		nums = []int{1, 0, 0}
+	}
+	if minor {
+		nums[1]++
+		nums[2] = 0
+	} else {
+		nums[2]++
+	}
+	return fmt.Sprintf("%d.%d.%d", nums[0], nums[1], nums[2])
+}
+
+// FileHashes returns the sha256 of every regular file under path, keyed by
+// slash-separated path relative to it (a single file maps under its base
+// name). source.yaml is skipped: it carries timestamps, not content.
+func FileHashes(path string) (map[string]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(map[string]string)
+	if !info.IsDir() {
+		sum, err := hashFile(path)
+		if err != nil {
+			return nil, err
+		}
+		hashes[filepath.Base(path)] = sum
+		return hashes, nil
+	}
+
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !d.Type().IsRegular() || d.Name() == "source.yaml" {
+			return nil
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		sum, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		hashes[filepath.ToSlash(rel)] = sum
+		return nil
+	})
+	return hashes, err
+}
+
+// ContentHash returns a single digest over FileHashes, stable across copies
+// of the same content.
+func ContentHash(path string) (string, error) {
+	hashes, err := FileHashes(path)
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%s\n", name, hashes[name])
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
 }
 
 // LoadBundle reads bundle.yaml from hub/bundles/<name>/.
@@ -53,6 +197,24 @@ func (b *Bundle) Save(bundlesDir string) error {
 	return os.WriteFile(filepath.Join(dir, BundleManifestFile), data, 0644)
 }
 
+// LoadBundleSettings reads the settings fragment of a bundle. Returns nil
+// when the bundle has none.
+func LoadBundleSettings(bundlesDir, name string) (map[string]interface{}, error) {
+	path := filepath.Join(bundlesDir, name, BundleSettingsFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return settings, nil
+}
+
 // ListBundles returns all bundles found in bundlesDir. A missing directory is
 // not an error — it simply means no bundles have been created yet.
 func ListBundles(bundlesDir string) ([]*Bundle, error) {
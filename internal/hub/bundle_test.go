@@ -100,3 +100,55 @@ func TestListBundlesMissingDir(t *testing.T) {
 		t.Errorf("expected nil bundles, got %v", bundles)
 	}
 }
+
+func TestBumpVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		minor   bool
+		want    string
+	}{
+		{"1.0.0", false, "1.0.1"},
+		{"1.2.9", false, "1.2.10"},
+		{"1.2.3", true, "1.3.0"},
+		{"v2.0.0", false, "2.0.1"},
+		{"", false, "1.0.1"},
+		{"latest", true, "1.1.0"},
+	}
+	for _, tt := range tests {
+		if got := BumpVersion(tt.version, tt.minor); got != tt.want {
+			t.Errorf("BumpVersion(%q, %v) = %q, want %q", tt.version, tt.minor, got, tt.want)
+		}
+	}
+}
+
+func TestContentHashIgnoresSourceManifest(t *testing.T) {
+	a := filepath.Join(t.TempDir(), "skill")
+	b := filepath.Join(t.TempDir(), "skill")
+	for _, dir := range []string{a, b} {
+		if err := os.MkdirAll(filepath.Join(dir, "refs"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("# skill"), 0644)
+		os.WriteFile(filepath.Join(dir, "refs", "notes.md"), []byte("notes"), 0644)
+	}
+	os.WriteFile(filepath.Join(b, "source.yaml"), []byte("type: github\n"), 0644)
+
+	ha, err := ContentHash(a)
+	if err != nil {
+		t.Fatalf("ContentHash: %v", err)
+	}
+	hb, _ := ContentHash(b)
+	if ha != hb {
+		t.Error("source.yaml should not affect the content hash")
+	}
+
+	os.WriteFile(filepath.Join(b, "refs", "notes.md"), []byte("changed"), 0644)
+	if hb, _ = ContentHash(b); ha == hb {
+		t.Error("content change should change the hash")
+	}
+
+	hashes, _ := FileHashes(b)
+	if len(hashes) != 2 || hashes["refs/notes.md"] == "" {
+		t.Errorf("FileHashes = %v, want SKILL.md and refs/notes.md", hashes)
+	}
+}
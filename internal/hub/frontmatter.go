@@ -0,0 +1,159 @@
+package hub
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Frontmatter is the parsed YAML header of a Markdown hub item (SKILL.md,
+// agent and command files). Key line numbers are kept so lint diagnostics can
+// point at the offending field.
+type Frontmatter struct {
+	Fields   map[string]interface{}
+	Body     string
+	BodyLine int // 1-based line in the file where Body starts
+
+	lines map[string]int
+}
+
+// ParseFrontmatter splits a Markdown file into its YAML frontmatter and body.
+// Returns (nil, nil) when the file has no frontmatter, and an error when the
+// block is unterminated or is not valid YAML.
+func ParseFrontmatter(data []byte) (*Frontmatter, error) {
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") && content != "---" {
+		return nil, nil
+	}
+	rest := strings.TrimPrefix(content, "---")
+	rest = strings.TrimPrefix(rest, "\n")
+
+	var front, body string
+	if strings.HasPrefix(rest, "---") {
+		front, body = "", strings.TrimPrefix(rest, "---")
+	} else {
+		var found bool
+		front, body, found = strings.Cut(rest, "\n---")
+		if !found {
+			return nil, fmt.Errorf("unterminated frontmatter: missing closing ---")
+		}
+	}
+	// Drop the remainder of the closing fence line
+	if idx := strings.IndexByte(body, '\n'); idx >= 0 {
+		body = body[idx+1:]
+	} else {
+		body = ""
+	}
+
+	fm := &Frontmatter{
+		Fields: make(map[string]interface{}),
+		Body:   body,
+		lines:  make(map[string]int),
+	}
+	// Opening fence + frontmatter lines + closing fence
+	fm.BodyLine = strings.Count(front, "\n") + 4
+	if front == "" {
+		fm.BodyLine = 3
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(front), &node); err != nil {
+		return nil, fmt.Errorf("invalid frontmatter YAML: %w", err)
+	}
+	if len(node.Content) == 0 {
+		return fm, nil
+	}
+	mapping := node.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid frontmatter: expected key/value mapping")
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, val := mapping.Content[i], mapping.Content[i+1]
+		var v interface{}
+		if err := val.Decode(&v); err != nil {
+			return nil, fmt.Errorf("invalid frontmatter value for %s: %w", key.Value, err)
+		}
+		fm.Fields[key.Value] = v
+		// yaml lines are relative to the frontmatter block, which starts on
+		// line 2 of the file (after the opening fence)
+		fm.lines[key.Value] = key.Line + 1
+	}
+
+	return fm, nil
+}
+
+// String returns a scalar field as a trimmed string, or "" when absent.
+func (f *Frontmatter) String(key string) string {
+	if f == nil {
+		return ""
+	}
+	switch v := f.Fields[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case nil:
+		return ""
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
+
+// Has reports whether the frontmatter declares the given key.
+func (f *Frontmatter) Has(key string) bool {
+	if f == nil {
+		return false
+	}
+	_, ok := f.Fields[key]
+	return ok
+}
+
+// Line returns the 1-based file line of a frontmatter key, or 1 when unknown.
+func (f *Frontmatter) Line(key string) int {
+	if f == nil {
+		return 1
+	}
+	if line, ok := f.lines[key]; ok {
+		return line
+	}
+	return 1
+}
+
+// List returns a field that may be written either as a YAML list or as a
+// comma separated string (e.g. "Read, Grep, Glob"). Commas inside
+// parentheses do not split, so "Bash(git add:*, git commit:*)" stays whole.
+func (f *Frontmatter) List(key string) []string {
+	if f == nil {
+		return nil
+	}
+	var out []string
+	switch v := f.Fields[key].(type) {
+	case string:
+		// Split on commas outside parentheses so "Bash(git add:*, git commit:*)"
+		// stays a single entry
+		depth, start := 0, 0
+		for i, r := range v + "," {
+			switch r {
+			case '(':
+				depth++
+			case ')':
+				if depth > 0 {
+					depth--
+				}
+			case ',':
+				if depth == 0 {
+					if part := strings.TrimSpace(v[start:min(i, len(v))]); part != "" {
+						out = append(out, part)
+					}
+					start = i + 1
+				}
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if s := strings.TrimSpace(fmt.Sprint(item)); s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
@@ -2,8 +2,10 @@ package hub
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
@@ -157,6 +159,68 @@ func SaveHooksJSON(hookDir string, hooksJSON *config.HooksJSON) error {
 	return os.WriteFile(hooksPath, data, 0644)
 }
 
+// StatusLineFile describes a directory statusline item
+const StatusLineFile = "statusline.json"
+
+// StatusLine is a statusline item in the shape of Claude Code's statusLine
+// setting. ${CLAUDE_PLUGIN_ROOT} in Command stands for the item: its
+// directory, or the script itself for a single-file item.
+type StatusLine struct {
+	Type    string `json:"type,omitempty"`
+	Command string `json:"command"`
+	Padding *int   `json:"padding,omitempty"`
+}
+
+// GetStatusLine reads the statusline item at itemPath. A file is run as the
+// command; a directory is described by statusline.json or, without one, runs
+// its statusline.* script (or its only file).
+func GetStatusLine(itemPath string) (*StatusLine, error) {
+	info, err := os.Stat(itemPath)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return &StatusLine{Type: "command", Command: "${CLAUDE_PLUGIN_ROOT}"}, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(itemPath, StatusLineFile))
+	if err == nil {
+		var sl StatusLine
+		if err := json.Unmarshal(data, &sl); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", StatusLineFile, err)
+		}
+		if sl.Command == "" {
+			return nil, fmt.Errorf("%s has no command", StatusLineFile)
+		}
+		if sl.Type == "" {
+			sl.Type = "command"
+		}
+		return &sl, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(itemPath)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") || e.Name() == "source.yaml" {
+			continue
+		}
+		if strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())) == "statusline" {
+			return &StatusLine{Type: "command", Command: "${CLAUDE_PLUGIN_ROOT}/" + e.Name()}, nil
+		}
+		files = append(files, e.Name())
+	}
+	if len(files) != 1 {
+		return nil, fmt.Errorf("no %s or statusline script found", StatusLineFile)
+	}
+	return &StatusLine{Type: "command", Command: "${CLAUDE_PLUGIN_ROOT}/" + files[0]}, nil
+}
+
 // hooksJSONToManifest converts the first hook entry from HooksJSON to HookManifest
 // This provides backward compatibility when reading hooks.json as HookManifest
 func hooksJSONToManifest(hooksJSON *config.HooksJSON, hookName string) (*HookManifest, error) {
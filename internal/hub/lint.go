@@ -0,0 +1,680 @@
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// Severity classifies a lint diagnostic
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a single lint finding, addressed by file and line
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+}
+
+// String formats the diagnostic as file:line: severity: message [rule]
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s [%s]", d.File, d.Line, d.Severity, d.Message, d.Rule)
+}
+
+// HasErrors reports whether any diagnostic is an error
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxDescriptionLength is the longest description Claude Code accepts in
+// skill and agent frontmatter
+const MaxDescriptionLength = 1024
+
+// maxNameLength is the longest skill/agent name Claude Code accepts
+const maxNameLength = 64
+
+var (
+	itemNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	markdownLink    = regexp.MustCompile(`!?\[[^\]]*\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	pluginRootRef   = regexp.MustCompile(`\$\{CLAUDE_PLUGIN_ROOT\}/([^\s"';|&]+)`)
+)
+
+// modelAliases are the shorthand model values accepted in frontmatter;
+// full model IDs (claude-*) are accepted as well
+var modelAliases = map[string]bool{
+	"sonnet":  true,
+	"opus":    true,
+	"haiku":   true,
+	"inherit": true,
+}
+
+// knownTools lists Claude Code's built-in tool names. MCP tools (mcp__*) are
+// accepted without being listed.
+var knownTools = map[string]bool{
+	"Agent":           true,
+	"AskUserQuestion": true,
+	"Bash":            true,
+	"BashOutput":      true,
+	"Edit":            true,
+	"ExitPlanMode":    true,
+	"Glob":            true,
+	"Grep":            true,
+	"KillShell":       true,
+	"LS":              true,
+	"MultiEdit":       true,
+	"NotebookEdit":    true,
+	"NotebookRead":    true,
+	"Read":            true,
+	"SlashCommand":    true,
+	"Skill":           true,
+	"Task":            true,
+	"TodoWrite":       true,
+	"WebFetch":        true,
+	"WebSearch":       true,
+	"Write":           true,
+}
+
+// knownHookCommandTypes are the handler types a hooks.json entry may declare
+var knownHookCommandTypes = map[string]bool{
+	"command": true,
+	"prompt":  true,
+}
+
+// LintHub lints every leaf item and every bundle member in the hub
+func LintHub(h *Hub) []Diagnostic {
+	var diags []Diagnostic
+	for _, item := range h.AllItems() {
+		diags = append(diags, LintItem(item)...)
+	}
+	for _, b := range h.Bundles {
+		diags = append(diags, LintBundle(filepath.Join(h.Path, string(config.HubBundles), b.Name), b)...)
+	}
+	return diags
+}
+
+// LintBundle lints each member stored inside a bundle directory
+func LintBundle(bundleDir string, b *Bundle) []Diagnostic {
+	var diags []Diagnostic
+	for _, member := range b.Members.AllComponents() {
+		path := filepath.Join(bundleDir, member.Type, member.Name)
+		info, err := os.Stat(path)
+		if err != nil {
+			diags = append(diags, Diagnostic{
+				File:     filepath.Join(bundleDir, BundleManifestFile),
+				Line:     1,
+				Severity: SeverityError,
+				Rule:     "bundle-member",
+				Message:  fmt.Sprintf("member %s/%s does not exist", member.Type, member.Name),
+			})
+			continue
+		}
+		diags = append(diags, LintItem(Item{
+			Name:  member.Name,
+			Type:  config.HubItemType(member.Type),
+			Path:  path,
+			IsDir: info.IsDir(),
+		})...)
+	}
+	return diags
+}
+
+// LintItem validates a single hub item according to its type
+func LintItem(item Item) []Diagnostic {
+	switch item.Type {
+	case config.HubSkills:
+		return lintSkill(item)
+	case config.HubAgents:
+		return lintAgent(item)
+	case config.HubCommands:
+		return lintCommand(item)
+	case config.HubRules:
+		return lintRule(item)
+	case config.HubHooks:
+		return lintHook(item)
+	case config.HubOutputStyles:
+		return lintOutputStyle(item)
+	case config.HubStatuslines:
+		return lintStatusline(item)
+	case config.HubMemory:
+		return lintMemory(item)
+	case config.HubSettingsTemplates:
+		return lintSettingsTemplate(item)
+	}
+	return nil
+}
+
+// MarkdownPath returns the Markdown file that carries an item's frontmatter:
+// SKILL.md for skill directories, the file itself for file items, and
+// <name>.md or README.md inside other directories. Returns "" when none exists.
+func MarkdownPath(item Item) string {
+	if !item.IsDir {
+		if strings.EqualFold(filepath.Ext(item.Path), ".md") {
+			return item.Path
+		}
+		return ""
+	}
+	candidates := []string{"SKILL.md", item.Name + ".md", "README.md"}
+	if item.Type != config.HubSkills {
+		candidates = candidates[1:]
+	}
+	for _, name := range candidates {
+		p := filepath.Join(item.Path, name)
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return ""
+}
+
+func lintSkill(item Item) []Diagnostic {
+	if !item.IsDir {
+		return []Diagnostic{{
+			File: item.Path, Line: 1, Severity: SeverityError, Rule: "skill-layout",
+			Message: "skills must be a directory containing SKILL.md",
+		}}
+	}
+	mdPath := filepath.Join(item.Path, "SKILL.md")
+	data, err := os.ReadFile(mdPath)
+	if err != nil {
+		return []Diagnostic{{
+			File: mdPath, Line: 1, Severity: SeverityError, Rule: "skill-layout",
+			Message: "missing SKILL.md",
+		}}
+	}
+
+	fm, diags := parseForLint(mdPath, data, true)
+	if fm == nil {
+		return diags
+	}
+	diags = append(diags, lintNameField(mdPath, fm, item.Name, true)...)
+	diags = append(diags, lintDescription(mdPath, fm, true)...)
+	diags = append(diags, lintTools(mdPath, fm, "allowed-tools")...)
+	diags = append(diags, lintModel(mdPath, fm)...)
+	diags = append(diags, lintLinks(mdPath, fm.Body, fm.BodyLine)...)
+	return diags
+}
+
+func lintAgent(item Item) []Diagnostic {
+	mdPath := MarkdownPath(item)
+	if mdPath == "" {
+		return []Diagnostic{{
+			File: item.Path, Line: 1, Severity: SeverityError, Rule: "agent-layout",
+			Message: "agents must be a Markdown file with frontmatter",
+		}}
+	}
+	data, err := os.ReadFile(mdPath)
+	if err != nil {
+		return []Diagnostic{{File: mdPath, Line: 1, Severity: SeverityError, Rule: "read", Message: err.Error()}}
+	}
+
+	fm, diags := parseForLint(mdPath, data, true)
+	if fm == nil {
+		return diags
+	}
+	diags = append(diags, lintNameField(mdPath, fm, itemBaseName(item.Name), true)...)
+	diags = append(diags, lintDescription(mdPath, fm, true)...)
+	diags = append(diags, lintTools(mdPath, fm, "tools")...)
+	diags = append(diags, lintModel(mdPath, fm)...)
+	return diags
+}
+
+func lintCommand(item Item) []Diagnostic {
+	mdPath := MarkdownPath(item)
+	if mdPath == "" {
+		if item.IsDir {
+			// Command namespaces are directories of Markdown files
+			return lintMarkdownTree(item.Path, lintCommandFile)
+		}
+		return []Diagnostic{{
+			File: item.Path, Line: 1, Severity: SeverityError, Rule: "command-layout",
+			Message: "commands must be Markdown files",
+		}}
+	}
+	return lintCommandFile(mdPath)
+}
+
+func lintCommandFile(mdPath string) []Diagnostic {
+	data, err := os.ReadFile(mdPath)
+	if err != nil {
+		return []Diagnostic{{File: mdPath, Line: 1, Severity: SeverityError, Rule: "read", Message: err.Error()}}
+	}
+	fm, diags := parseForLint(mdPath, data, false)
+	if fm == nil {
+		return append(diags, lintLinks(mdPath, string(data), 1)...)
+	}
+	diags = append(diags, lintDescription(mdPath, fm, false)...)
+	diags = append(diags, lintTools(mdPath, fm, "allowed-tools")...)
+	diags = append(diags, lintModel(mdPath, fm)...)
+	diags = append(diags, lintLinks(mdPath, fm.Body, fm.BodyLine)...)
+	return diags
+}
+
+func lintRule(item Item) []Diagnostic {
+	if item.IsDir {
+		return lintMarkdownTree(item.Path, lintRuleFile)
+	}
+	return lintRuleFile(item.Path)
+}
+
+func lintRuleFile(mdPath string) []Diagnostic {
+	data, err := os.ReadFile(mdPath)
+	if err != nil {
+		return []Diagnostic{{File: mdPath, Line: 1, Severity: SeverityError, Rule: "read", Message: err.Error()}}
+	}
+	fm, diags := parseForLint(mdPath, data, false)
+	if fm == nil {
+		return append(diags, lintLinks(mdPath, string(data), 1)...)
+	}
+	return append(diags, lintLinks(mdPath, fm.Body, fm.BodyLine)...)
+}
+
+// lintMarkdownTree applies a per-file linter to every .md file below dir
+func lintMarkdownTree(dir string, lintFile func(string) []Diagnostic) []Diagnostic {
+	var diags []Diagnostic
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.EqualFold(filepath.Ext(path), ".md") {
+			diags = append(diags, lintFile(path)...)
+		}
+		return nil
+	})
+	return diags
+}
+
+func lintMemory(item Item) []Diagnostic {
+	if item.IsDir || !strings.EqualFold(filepath.Ext(item.Path), ".md") {
+		return []Diagnostic{{
+			File: item.Path, Line: 1, Severity: SeverityError, Rule: "memory-layout",
+			Message: "memory snippets must be a single .md file",
+		}}
+	}
+	data, err := os.ReadFile(item.Path)
+	if err != nil {
+		return []Diagnostic{{File: item.Path, Line: 1, Severity: SeverityError, Rule: "read", Message: err.Error()}}
+	}
+	fm, diags := parseForLint(item.Path, data, false)
+	if fm == nil {
+		return append(diags, lintLinks(item.Path, string(data), 1)...)
+	}
+	if p := fm.String("priority"); p != "" {
+		if _, err := strconv.Atoi(p); err != nil {
+			diags = append(diags, Diagnostic{
+				File: item.Path, Line: fm.Line("priority"), Severity: SeverityError, Rule: "memory-priority",
+				Message: fmt.Sprintf("priority must be an integer, got %q", p),
+			})
+		}
+	}
+	return append(diags, lintLinks(item.Path, fm.Body, fm.BodyLine)...)
+}
+
+func lintOutputStyle(item Item) []Diagnostic {
+	if item.IsDir || !strings.EqualFold(filepath.Ext(item.Path), ".md") {
+		return []Diagnostic{{
+			File: item.Path, Line: 1, Severity: SeverityError, Rule: "output-style-layout",
+			Message: "output styles must be a single .md file",
+		}}
+	}
+	data, err := os.ReadFile(item.Path)
+	if err != nil {
+		return []Diagnostic{{File: item.Path, Line: 1, Severity: SeverityError, Rule: "read", Message: err.Error()}}
+	}
+	fm, diags := parseForLint(item.Path, data, false)
+	if fm == nil {
+		return append(diags, lintLinks(item.Path, string(data), 1)...)
+	}
+	diags = append(diags, lintDescription(item.Path, fm, false)...)
+	return append(diags, lintLinks(item.Path, fm.Body, fm.BodyLine)...)
+}
+
+func lintStatusline(item Item) []Diagnostic {
+	file := item.Path
+	if item.IsDir {
+		if _, err := os.Stat(filepath.Join(item.Path, StatusLineFile)); err == nil {
+			file = filepath.Join(item.Path, StatusLineFile)
+		}
+	}
+	sl, err := GetStatusLine(item.Path)
+	if err != nil {
+		return []Diagnostic{{File: file, Line: 1, Severity: SeverityError, Rule: "statusline-layout", Message: err.Error()}}
+	}
+
+	scripts := []string{item.Path}
+	if item.IsDir {
+		scripts = nil
+		for _, m := range pluginRootRef.FindAllStringSubmatch(sl.Command, -1) {
+			scripts = append(scripts, filepath.Join(item.Path, m[1]))
+		}
+	}
+	var diags []Diagnostic
+	for _, script := range scripts {
+		info, err := os.Stat(script)
+		if err != nil {
+			diags = append(diags, Diagnostic{
+				File: file, Line: 1, Severity: SeverityError, Rule: "statusline-script",
+				Message: fmt.Sprintf("script not found: %s", filepath.Base(script)),
+			})
+		} else if info.Mode()&0111 == 0 && strings.HasPrefix(strings.TrimSpace(sl.Command), "${CLAUDE_PLUGIN_ROOT}") {
+			diags = append(diags, Diagnostic{
+				File: script, Line: 1, Severity: SeverityWarning, Rule: "statusline-exec",
+				Message: "script is run directly but is not executable",
+			})
+		}
+	}
+	return diags
+}
+
+func lintHook(item Item) []Diagnostic {
+	if !item.IsDir {
+		return []Diagnostic{{
+			File: item.Path, Line: 1, Severity: SeverityWarning, Rule: "hook-layout",
+			Message: "hooks should be a directory containing hooks.json",
+		}}
+	}
+
+	jsonPath := filepath.Join(item.Path, "hooks.json")
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		if _, yErr := os.Stat(filepath.Join(item.Path, "hook.yaml")); yErr == nil {
+			return []Diagnostic{{
+				File: filepath.Join(item.Path, "hook.yaml"), Line: 1, Severity: SeverityWarning, Rule: "hook-legacy",
+				Message: "legacy hook.yaml format; migrate to hooks.json",
+			}}
+		}
+		return []Diagnostic{{
+			File: jsonPath, Line: 1, Severity: SeverityError, Rule: "hook-layout",
+			Message: "missing hooks.json",
+		}}
+	}
+	return LintHooksJSON(jsonPath, data, item.Path)
+}
+
+// LintHooksJSON validates the structure of a hooks.json document. hookDir is
+// used to resolve ${CLAUDE_PLUGIN_ROOT} script references.
+func LintHooksJSON(file string, data []byte, hookDir string) []Diagnostic {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []Diagnostic{jsonDiagnostic(file, data, err)}
+	}
+	if _, ok := raw["hooks"]; !ok {
+		return []Diagnostic{{
+			File: file, Line: 1, Severity: SeverityError, Rule: "hooks-json",
+			Message: `missing top-level "hooks" object`,
+		}}
+	}
+
+	var hooksJSON config.HooksJSON
+	if err := json.Unmarshal(data, &hooksJSON); err != nil {
+		return []Diagnostic{jsonDiagnostic(file, data, err)}
+	}
+
+	known := make(map[config.HookType]bool)
+	for _, t := range config.AllHookTypes() {
+		known[t] = true
+	}
+
+	// Sort event names for stable output
+	events := make([]string, 0, len(hooksJSON.Hooks))
+	for event := range hooksJSON.Hooks {
+		events = append(events, string(event))
+	}
+	sort.Strings(events)
+
+	var diags []Diagnostic
+	for _, event := range events {
+		line := lineOfString(data, `"`+event+`"`)
+		if !known[config.HookType(event)] {
+			diags = append(diags, Diagnostic{
+				File: file, Line: line, Severity: SeverityError, Rule: "hook-event",
+				Message: fmt.Sprintf("unknown hook event %q", event),
+			})
+		}
+		for i, entry := range hooksJSON.Hooks[config.HookType(event)] {
+			if len(entry.Hooks) == 0 {
+				diags = append(diags, Diagnostic{
+					File: file, Line: line, Severity: SeverityError, Rule: "hook-entry",
+					Message: fmt.Sprintf("%s[%d] has no hooks", event, i),
+				})
+			}
+			for j, cmd := range entry.Hooks {
+				if !knownHookCommandTypes[cmd.Type] {
+					diags = append(diags, Diagnostic{
+						File: file, Line: line, Severity: SeverityError, Rule: "hook-type",
+						Message: fmt.Sprintf("%s[%d].hooks[%d] has invalid type %q (want command or prompt)", event, i, j, cmd.Type),
+					})
+				}
+				if cmd.Type == "command" && strings.TrimSpace(cmd.Command) == "" {
+					diags = append(diags, Diagnostic{
+						File: file, Line: line, Severity: SeverityError, Rule: "hook-command",
+						Message: fmt.Sprintf("%s[%d].hooks[%d] has an empty command", event, i, j),
+					})
+				}
+				for _, m := range pluginRootRef.FindAllStringSubmatch(cmd.Command, -1) {
+					if _, err := os.Stat(filepath.Join(hookDir, m[1])); err != nil {
+						diags = append(diags, Diagnostic{
+							File: file, Line: lineOfString(data, m[0]), Severity: SeverityError, Rule: "hook-script",
+							Message: fmt.Sprintf("script not found: %s", m[1]),
+						})
+					}
+				}
+			}
+		}
+	}
+	return diags
+}
+
+func lintSettingsTemplate(item Item) []Diagnostic {
+	path := item.Path
+	if item.IsDir {
+		path = filepath.Join(item.Path, "settings.json")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []Diagnostic{{File: path, Line: 1, Severity: SeverityError, Rule: "template-layout", Message: "missing settings.json"}}
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return []Diagnostic{jsonDiagnostic(path, data, err)}
+	}
+	return nil
+}
+
+// parseForLint parses frontmatter and reports parse problems. When required
+// is set, a missing frontmatter block is an error.
+func parseForLint(file string, data []byte, required bool) (*Frontmatter, []Diagnostic) {
+	fm, err := ParseFrontmatter(data)
+	if err != nil {
+		return nil, []Diagnostic{{File: file, Line: 1, Severity: SeverityError, Rule: "frontmatter", Message: err.Error()}}
+	}
+	if fm == nil && required {
+		return nil, []Diagnostic{{File: file, Line: 1, Severity: SeverityError, Rule: "frontmatter", Message: "missing YAML frontmatter"}}
+	}
+	return fm, nil
+}
+
+func lintNameField(file string, fm *Frontmatter, expected string, required bool) []Diagnostic {
+	name := fm.String("name")
+	if name == "" {
+		if !required {
+			return nil
+		}
+		return []Diagnostic{{File: file, Line: 1, Severity: SeverityError, Rule: "name-required", Message: "frontmatter is missing name"}}
+	}
+
+	var diags []Diagnostic
+	line := fm.Line("name")
+	if len(name) > maxNameLength || !itemNamePattern.MatchString(name) {
+		diags = append(diags, Diagnostic{
+			File: file, Line: line, Severity: SeverityError, Rule: "name-format",
+			Message: fmt.Sprintf("name %q must be lowercase letters, digits and hyphens (max %d chars)", name, maxNameLength),
+		})
+	}
+	if expected != "" && name != expected {
+		diags = append(diags, Diagnostic{
+			File: file, Line: line, Severity: SeverityWarning, Rule: "name-mismatch",
+			Message: fmt.Sprintf("name %q does not match item name %q", name, expected),
+		})
+	}
+	return diags
+}
+
+func lintDescription(file string, fm *Frontmatter, required bool) []Diagnostic {
+	desc := fm.String("description")
+	if desc == "" {
+		if !required {
+			return nil
+		}
+		return []Diagnostic{{File: file, Line: 1, Severity: SeverityError, Rule: "description-required", Message: "frontmatter is missing description"}}
+	}
+	if len(desc) > MaxDescriptionLength {
+		return []Diagnostic{{
+			File: file, Line: fm.Line("description"), Severity: SeverityError, Rule: "description-length",
+			Message: fmt.Sprintf("description is %d chars (max %d)", len(desc), MaxDescriptionLength),
+		}}
+	}
+	return nil
+}
+
+func lintTools(file string, fm *Frontmatter, key string) []Diagnostic {
+	if !fm.Has(key) {
+		return nil
+	}
+	var diags []Diagnostic
+	for _, tool := range fm.List(key) {
+		name := tool
+		if idx := strings.IndexByte(name, '('); idx >= 0 {
+			if !strings.HasSuffix(name, ")") {
+				diags = append(diags, Diagnostic{
+					File: file, Line: fm.Line(key), Severity: SeverityError, Rule: "tools",
+					Message: fmt.Sprintf("malformed tool pattern %q", tool),
+				})
+				continue
+			}
+			name = name[:idx]
+		}
+		if knownTools[name] || strings.HasPrefix(name, "mcp__") {
+			continue
+		}
+		diags = append(diags, Diagnostic{
+			File: file, Line: fm.Line(key), Severity: SeverityWarning, Rule: "tools",
+			Message: fmt.Sprintf("unknown tool %q in %s", name, key),
+		})
+	}
+	return diags
+}
+
+func lintModel(file string, fm *Frontmatter) []Diagnostic {
+	model := fm.String("model")
+	if model == "" || modelAliases[model] || strings.HasPrefix(model, "claude-") {
+		return nil
+	}
+	return []Diagnostic{{
+		File: file, Line: fm.Line("model"), Severity: SeverityError, Rule: "model",
+		Message: fmt.Sprintf("invalid model %q (want sonnet, opus, haiku, inherit or a claude-* model ID)", model),
+	}}
+}
+
+// lintLinks reports relative Markdown links whose target file does not exist.
+// startLine is the file line on which body begins.
+func lintLinks(file, body string, startLine int) []Diagnostic {
+	var diags []Diagnostic
+	baseDir := filepath.Dir(file)
+	inFence := false
+	for i, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, m := range markdownLink.FindAllStringSubmatch(line, -1) {
+			target := m[1]
+			if isExternalLink(target) {
+				continue
+			}
+			if idx := strings.IndexAny(target, "#?"); idx >= 0 {
+				target = target[:idx]
+			}
+			if target == "" {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(baseDir, filepath.FromSlash(target))); err != nil {
+				diags = append(diags, Diagnostic{
+					File: file, Line: startLine + i, Severity: SeverityWarning, Rule: "broken-link",
+					Message: fmt.Sprintf("referenced file not found: %s", m[1]),
+				})
+			}
+		}
+	}
+	return diags
+}
+
+func isExternalLink(target string) bool {
+	if strings.HasPrefix(target, "#") || strings.HasPrefix(target, "/") || strings.HasPrefix(target, "~") {
+		return true
+	}
+	return strings.Contains(target, "://") || strings.HasPrefix(target, "mailto:") || strings.Contains(target, "${")
+}
+
+// jsonDiagnostic converts a JSON decode error into a diagnostic with the line
+// of the offending byte when the decoder reports one.
+func jsonDiagnostic(file string, data []byte, err error) Diagnostic {
+	line := 1
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line = lineAtOffset(data, syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		line = lineAtOffset(data, typeErr.Offset)
+	}
+	return Diagnostic{File: file, Line: line, Severity: SeverityError, Rule: "json", Message: "invalid JSON: " + err.Error()}
+}
+
+func lineAtOffset(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return strings.Count(string(data[:offset]), "\n") + 1
+}
+
+func lineOfString(data []byte, needle string) int {
+	idx := strings.Index(string(data), needle)
+	if idx < 0 {
+		return 1
+	}
+	return lineAtOffset(data, int64(idx))
+}
+
+// itemBaseName strips a Markdown extension from file-based item names
+func itemBaseName(name string) string {
+	return strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+}
@@ -0,0 +1,228 @@
+package hub
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+func writeLintFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func findRule(diags []Diagnostic, rule string) *Diagnostic {
+	for i := range diags {
+		if diags[i].Rule == rule {
+			return &diags[i]
+		}
+	}
+	return nil
+}
+
+func TestParseFrontmatter(t *testing.T) {
+	data := []byte("---\nname: my-skill\ndescription: Does things\nallowed-tools: Read, Bash(git add:*, git commit:*)\n---\n# Body\n")
+	fm, err := ParseFrontmatter(data)
+	if err != nil {
+		t.Fatalf("ParseFrontmatter: %v", err)
+	}
+	if fm.String("name") != "my-skill" {
+		t.Errorf("name = %q", fm.String("name"))
+	}
+	if fm.Line("description") != 3 {
+		t.Errorf("description line = %d, want 3", fm.Line("description"))
+	}
+	if fm.BodyLine != 6 {
+		t.Errorf("BodyLine = %d, want 6", fm.BodyLine)
+	}
+	tools := fm.List("allowed-tools")
+	if len(tools) != 2 || tools[1] != "Bash(git add:*, git commit:*)" {
+		t.Errorf("tools = %v", tools)
+	}
+
+	if fm, err := ParseFrontmatter([]byte("# No frontmatter\n")); fm != nil || err != nil {
+		t.Errorf("expected nil, nil for no frontmatter; got %v, %v", fm, err)
+	}
+	if _, err := ParseFrontmatter([]byte("---\nname: x\n# never closed\n")); err == nil {
+		t.Error("expected error for unterminated frontmatter")
+	}
+	if _, err := ParseFrontmatter([]byte("---\nname: [unclosed\n---\n")); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestLintSkill(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "skills", "my-skill")
+	writeLintFile(t, filepath.Join(dir, "SKILL.md"), strings.Join([]string{
+		"---",
+		"name: other-name",
+		"description: Helps",
+		"allowed-tools: Read, Frobnicate, mcp__github__create_issue",
+		"model: gpt-4",
+		"---",
+		"See [reference](reference.md) and [docs](docs/missing.md).",
+		"",
+	}, "\n"))
+	writeLintFile(t, filepath.Join(dir, "reference.md"), "ok")
+
+	diags := LintItem(Item{Name: "my-skill", Type: config.HubSkills, Path: dir, IsDir: true})
+
+	if d := findRule(diags, "name-mismatch"); d == nil || d.Line != 2 || d.Severity != SeverityWarning {
+		t.Errorf("expected name-mismatch warning on line 2, got %+v", d)
+	}
+	if d := findRule(diags, "model"); d == nil || d.Line != 5 || d.Severity != SeverityError {
+		t.Errorf("expected model error on line 5, got %+v", d)
+	}
+	if d := findRule(diags, "tools"); d == nil || !strings.Contains(d.Message, "Frobnicate") {
+		t.Errorf("expected unknown tool warning, got %+v", d)
+	}
+	link := findRule(diags, "broken-link")
+	if link == nil || link.Line != 7 || !strings.Contains(link.Message, "docs/missing.md") {
+		t.Errorf("expected broken-link on line 7, got %+v", link)
+	}
+	count := 0
+	for _, d := range diags {
+		if d.Rule == "broken-link" || d.Rule == "tools" {
+			count++
+		}
+	}
+	if count != 2 {
+		t.Errorf("expected exactly one broken link and one tool warning, got %v", diags)
+	}
+}
+
+func TestLintSkillRequiredFields(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "bare")
+	writeLintFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: bare\n---\nbody\n")
+
+	diags := LintItem(Item{Name: "bare", Type: config.HubSkills, Path: dir, IsDir: true})
+	if findRule(diags, "description-required") == nil {
+		t.Errorf("expected description-required, got %v", diags)
+	}
+
+	long := strings.Repeat("x", MaxDescriptionLength+1)
+	writeLintFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: bare\ndescription: "+long+"\n---\n")
+	diags = LintItem(Item{Name: "bare", Type: config.HubSkills, Path: dir, IsDir: true})
+	if findRule(diags, "description-length") == nil {
+		t.Errorf("expected description-length, got %v", diags)
+	}
+
+	os.Remove(filepath.Join(dir, "SKILL.md"))
+	diags = LintItem(Item{Name: "bare", Type: config.HubSkills, Path: dir, IsDir: true})
+	if findRule(diags, "skill-layout") == nil {
+		t.Errorf("expected skill-layout for missing SKILL.md, got %v", diags)
+	}
+}
+
+func TestLintAgent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reviewer.md")
+	writeLintFile(t, path, "---\nname: reviewer\ndescription: Reviews code\ntools: Read, Grep\nmodel: sonnet\n---\nYou review.\n")
+
+	if diags := LintItem(Item{Name: "reviewer.md", Type: config.HubAgents, Path: path}); len(diags) != 0 {
+		t.Errorf("expected clean agent, got %v", diags)
+	}
+
+	writeLintFile(t, path, "You review, but have no frontmatter.\n")
+	diags := LintItem(Item{Name: "reviewer.md", Type: config.HubAgents, Path: path})
+	if d := findRule(diags, "frontmatter"); d == nil || d.Severity != SeverityError {
+		t.Errorf("expected missing frontmatter error, got %v", diags)
+	}
+}
+
+func TestLintCommandOptionalFrontmatter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.md")
+	writeLintFile(t, path, "Deploy the app.\n")
+	if diags := LintItem(Item{Name: "deploy.md", Type: config.HubCommands, Path: path}); len(diags) != 0 {
+		t.Errorf("commands without frontmatter should be clean, got %v", diags)
+	}
+}
+
+func TestLintMemory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "go.md")
+	writeLintFile(t, path, "---\npriority: 10\n---\nRun gofmt.\n")
+	if diags := LintItem(Item{Name: "go.md", Type: config.HubMemory, Path: path}); len(diags) != 0 {
+		t.Errorf("expected clean snippet, got %v", diags)
+	}
+
+	writeLintFile(t, path, "---\npriority: first\n---\nRun gofmt.\n")
+	diags := LintItem(Item{Name: "go.md", Type: config.HubMemory, Path: path})
+	if d := findRule(diags, "memory-priority"); d == nil || d.Line != 2 {
+		t.Errorf("expected priority error on line 2, got %v", diags)
+	}
+}
+
+func TestLintHook(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "guard")
+	writeLintFile(t, filepath.Join(dir, "scripts", "ok.sh"), "#!/bin/sh\n")
+	writeLintFile(t, filepath.Join(dir, "hooks.json"), `{
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "${CLAUDE_PLUGIN_ROOT}/scripts/ok.sh"}]}
+    ],
+    "OnBoot": [
+      {"hooks": [{"type": "command", "command": "${CLAUDE_PLUGIN_ROOT}/scripts/missing.sh"}]}
+    ]
+  }
+}`)
+
+	diags := LintItem(Item{Name: "guard", Type: config.HubHooks, Path: dir, IsDir: true})
+	if d := findRule(diags, "hook-event"); d == nil || d.Line != 6 {
+		t.Errorf("expected unknown event on line 6, got %+v", d)
+	}
+	if d := findRule(diags, "hook-script"); d == nil || !strings.Contains(d.Message, "missing.sh") {
+		t.Errorf("expected missing script, got %+v", d)
+	}
+	if len(diags) != 2 {
+		t.Errorf("expected 2 diagnostics, got %v", diags)
+	}
+
+	writeLintFile(t, filepath.Join(dir, "hooks.json"), "{\n  \"hooks\": {\n    \"Stop\": [,]\n  }\n}")
+	diags = LintItem(Item{Name: "guard", Type: config.HubHooks, Path: dir, IsDir: true})
+	if d := findRule(diags, "json"); d == nil || d.Line != 3 {
+		t.Errorf("expected JSON error on line 3, got %+v", diags)
+	}
+}
+
+func TestLintBundleMissingMember(t *testing.T) {
+	bundleDir := filepath.Join(t.TempDir(), "kit")
+	b := &Bundle{Name: "kit", Members: ComponentList{Skills: []string{"ghost"}}}
+
+	diags := LintBundle(bundleDir, b)
+	if d := findRule(diags, "bundle-member"); d == nil || !HasErrors(diags) {
+		t.Errorf("expected bundle-member error, got %v", diags)
+	}
+}
+
+func TestLintStatusline(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "git")
+	writeLintFile(t, filepath.Join(dir, StatusLineFile), `{"command": "${CLAUDE_PLUGIN_ROOT}/status.sh"}`)
+	diags := LintItem(Item{Name: "git", Type: config.HubStatuslines, Path: dir, IsDir: true})
+	if d := findRule(diags, "statusline-script"); d == nil || !strings.Contains(d.Message, "status.sh") {
+		t.Errorf("expected missing script, got %v", diags)
+	}
+
+	writeLintFile(t, filepath.Join(dir, "status.sh"), "#!/bin/sh\n")
+	diags = LintItem(Item{Name: "git", Type: config.HubStatuslines, Path: dir, IsDir: true})
+	if d := findRule(diags, "statusline-exec"); d == nil || len(diags) != 1 {
+		t.Errorf("expected not-executable warning, got %v", diags)
+	}
+
+	os.Chmod(filepath.Join(dir, "status.sh"), 0755)
+	if diags := LintItem(Item{Name: "git", Type: config.HubStatuslines, Path: dir, IsDir: true}); len(diags) != 0 {
+		t.Errorf("expected clean statusline, got %v", diags)
+	}
+
+	writeLintFile(t, filepath.Join(dir, StatusLineFile), `{"padding": 2}`)
+	diags = LintItem(Item{Name: "git", Type: config.HubStatuslines, Path: dir, IsDir: true})
+	if d := findRule(diags, "statusline-layout"); d == nil {
+		t.Errorf("expected layout error for missing command, got %v", diags)
+	}
+}
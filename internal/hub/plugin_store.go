@@ -0,0 +1,208 @@
+package hub
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// StorePlugin is a plugin installed by Claude Code's /plugin command into
+// the shared plugin store (store/plugins/cache/<marketplace>/<name>/<version>)
+type StorePlugin struct {
+	Name        string
+	Marketplace string
+	Version     string
+	Description string
+	Commit      string // gitCommitSha recorded by Claude Code, if any
+	Owner       string // marketplace GitHub owner, if known
+	Repo        string // marketplace GitHub repo, if known
+	Path        string // installed plugin directory
+	Components  []PluginComponent
+}
+
+// ID returns the plugin's Claude Code identifier, name@marketplace
+func (p *StorePlugin) ID() string {
+	return p.Name + "@" + p.Marketplace
+}
+
+// PluginComponent is an item inside a plugin directory that maps onto a hub item
+type PluginComponent struct {
+	Type config.HubItemType
+	Name string // hub item name: a directory, or a file for agents/commands ("x.md")
+	Path string // path relative to the plugin directory
+}
+
+// installedPlugin is one entry of Claude Code's installed_plugins.json
+type installedPlugin struct {
+	Version      string `json:"version"`
+	InstallPath  string `json:"installPath"`
+	GitCommitSha string `json:"gitCommitSha"`
+	LastUpdated  string `json:"lastUpdated"`
+}
+
+// ScanPluginStore lists the plugins recorded in any of installedFiles
+// (installed_plugins.json of each profile) that are present in the plugin
+// store at storeDir. A plugin installed in several profiles is listed once,
+// at its most recently updated version.
+func ScanPluginStore(storeDir string, installedFiles []string) ([]StorePlugin, error) {
+	owners := loadKnownMarketplaces(filepath.Join(storeDir, string(config.PluginStoreKnownMarketplaces)))
+
+	found := make(map[string]installedPlugin)
+	for _, file := range installedFiles {
+		entries, err := loadInstalledPlugins(file)
+		if err != nil {
+			return nil, err
+		}
+		for id, entry := range entries {
+			if prev, ok := found[id]; !ok || entry.LastUpdated > prev.LastUpdated {
+				found[id] = entry
+			}
+		}
+	}
+
+	var plugins []StorePlugin
+	for id, entry := range found {
+		name, marketplace, ok := strings.Cut(id, "@")
+		if !ok {
+			continue
+		}
+		dir := filepath.Join(storeDir, string(config.PluginStoreCache), marketplace, name, entry.Version)
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			dir = entry.InstallPath
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+				continue // uninstalled or cache cleared
+			}
+		}
+
+		plugin := StorePlugin{
+			Name:        name,
+			Marketplace: marketplace,
+			Version:     entry.Version,
+			Commit:      entry.GitCommitSha,
+			Path:        dir,
+			Components:  DiscoverPluginComponents(dir, name),
+		}
+		if repo, ok := owners[marketplace]; ok {
+			plugin.Owner, plugin.Repo, _ = strings.Cut(repo, "/")
+		}
+		var manifest struct {
+			Description string `json:"description"`
+			Version     string `json:"version"`
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, ".claude-plugin", "plugin.json")); err == nil {
+			if json.Unmarshal(data, &manifest) == nil {
+				plugin.Description = manifest.Description
+				if plugin.Version == "" {
+					plugin.Version = manifest.Version
+				}
+			}
+		}
+		plugins = append(plugins, plugin)
+	}
+
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].ID() < plugins[j].ID() })
+	return plugins, nil
+}
+
+// DiscoverPluginComponents finds the skills, agents, commands and hooks of a
+// plugin in its default component directories. Skills are directories with a
+// SKILL.md; agents and commands are .md files or directories; hooks/ with a
+// hooks.json becomes one hook item named "<plugin>-hooks".
+func DiscoverPluginComponents(pluginDir, pluginName string) []PluginComponent {
+	var components []PluginComponent
+
+	for _, itemType := range []config.HubItemType{config.HubSkills, config.HubAgents, config.HubCommands} {
+		entries, err := os.ReadDir(filepath.Join(pluginDir, string(itemType)))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if strings.HasPrefix(name, ".") {
+				continue
+			}
+			rel := filepath.Join(string(itemType), name)
+			switch {
+			case itemType == config.HubSkills:
+				if _, err := os.Stat(filepath.Join(pluginDir, rel, "SKILL.md")); err != nil {
+					continue
+				}
+			case !e.IsDir() && !strings.HasSuffix(name, ".md"):
+				continue
+			}
+			components = append(components, PluginComponent{Type: itemType, Name: name, Path: rel})
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(pluginDir, "hooks", "hooks.json")); err == nil {
+		components = append(components, PluginComponent{Type: config.HubHooks, Name: pluginName + "-hooks", Path: "hooks"})
+	}
+
+	return components
+}
+
+// loadInstalledPlugins reads installed_plugins.json in either format Claude
+// Code has written: version 1 maps each plugin to one entry, version 2 to a
+// list of entries (one per scope). A missing file yields no plugins.
+func loadInstalledPlugins(path string) (map[string]installedPlugin, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var file struct {
+		Plugins map[string]json.RawMessage `json:"plugins"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]installedPlugin)
+	for id, raw := range file.Plugins {
+		var list []installedPlugin
+		if err := json.Unmarshal(raw, &list); err != nil {
+			var single installedPlugin
+			if err := json.Unmarshal(raw, &single); err != nil {
+				continue
+			}
+			list = []installedPlugin{single}
+		}
+		for _, entry := range list {
+			if prev, ok := result[id]; !ok || entry.LastUpdated > prev.LastUpdated {
+				result[id] = entry
+			}
+		}
+	}
+	return result, nil
+}
+
+// loadKnownMarketplaces maps marketplace names to "owner/repo" for
+// marketplaces added from GitHub
+func loadKnownMarketplaces(path string) map[string]string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var known map[string]struct {
+		Source struct {
+			Source string `json:"source"`
+			Repo   string `json:"repo"`
+		} `json:"source"`
+	}
+	if err := json.Unmarshal(data, &known); err != nil {
+		return nil
+	}
+	repos := make(map[string]string)
+	for name, m := range known {
+		if m.Source.Source == "github" && strings.Count(m.Source.Repo, "/") == 1 {
+			repos[name] = m.Source.Repo
+		}
+	}
+	return repos
+}
@@ -0,0 +1,64 @@
+package hub
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+func TestScanPluginStore(t *testing.T) {
+	store := t.TempDir()
+	profiles := t.TempDir()
+
+	plugin := filepath.Join(store, "cache", "acme-tools", "reviewer", "1.2.0")
+	writeLintFile(t, filepath.Join(plugin, ".claude-plugin", "plugin.json"), `{"name":"reviewer","description":"Code review helpers"}`)
+	writeLintFile(t, filepath.Join(plugin, "skills", "review", "SKILL.md"), "# review")
+	writeLintFile(t, filepath.Join(plugin, "skills", "notes", "README.md"), "not a skill")
+	writeLintFile(t, filepath.Join(plugin, "agents", "critic.md"), "# critic")
+	writeLintFile(t, filepath.Join(plugin, "commands", "review-pr.md"), "# review-pr")
+	writeLintFile(t, filepath.Join(plugin, "hooks", "hooks.json"), `{"hooks":{}}`)
+	writeLintFile(t, filepath.Join(store, "known_marketplaces.json"),
+		`{"acme-tools":{"source":{"source":"github","repo":"acme/claude-tools"}}}`)
+
+	// Version 2 file with an older entry, version 1 file with the newer one,
+	// and a plugin whose files are gone
+	v2 := filepath.Join(profiles, "dev", "plugins", "installed_plugins.json")
+	writeLintFile(t, v2, `{"version":2,"plugins":{
+		"reviewer@acme-tools":[{"scope":"user","version":"1.0.0","lastUpdated":"2026-01-01T00:00:00Z"}],
+		"gone@acme-tools":[{"version":"0.1.0","installPath":"/nonexistent"}]}}`)
+	v1 := filepath.Join(profiles, "work", "plugins", "installed_plugins.json")
+	writeLintFile(t, v1, `{"version":1,"plugins":{
+		"reviewer@acme-tools":{"version":"1.2.0","gitCommitSha":"abc123","lastUpdated":"2026-03-01T00:00:00Z"}}}`)
+
+	plugins, err := ScanPluginStore(store, []string{v2, v1, filepath.Join(profiles, "missing.json")})
+	if err != nil {
+		t.Fatalf("ScanPluginStore() error = %v", err)
+	}
+	if len(plugins) != 1 {
+		t.Fatalf("got %d plugins, want 1: %+v", len(plugins), plugins)
+	}
+
+	p := plugins[0]
+	if p.ID() != "reviewer@acme-tools" || p.Version != "1.2.0" || p.Commit != "abc123" || p.Path != plugin {
+		t.Errorf("plugin = %+v", p)
+	}
+	if p.Owner != "acme" || p.Repo != "claude-tools" || p.Description != "Code review helpers" {
+		t.Errorf("plugin metadata = %q/%q %q", p.Owner, p.Repo, p.Description)
+	}
+
+	want := []PluginComponent{
+		{Type: config.HubSkills, Name: "review", Path: filepath.Join("skills", "review")},
+		{Type: config.HubAgents, Name: "critic.md", Path: filepath.Join("agents", "critic.md")},
+		{Type: config.HubCommands, Name: "review-pr.md", Path: filepath.Join("commands", "review-pr.md")},
+		{Type: config.HubHooks, Name: "reviewer-hooks", Path: "hooks"},
+	}
+	if len(p.Components) != len(want) {
+		t.Fatalf("components = %+v, want %+v", p.Components, want)
+	}
+	for i := range want {
+		if p.Components[i] != want[i] {
+			t.Errorf("component %d = %+v, want %+v", i, p.Components[i], want[i])
+		}
+	}
+}
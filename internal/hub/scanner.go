@@ -53,11 +53,12 @@ func (s *Scanner) ScanSource(claudeDir string) (*Hub, error) {
 
 	// Map source directories to hub types
 	dirMap := map[string]config.HubItemType{
-		"skills":   config.HubSkills,
-		"agents":   config.HubAgents,
-		"hooks":    config.HubHooks,
-		"rules":    config.HubRules,
-		"commands": config.HubCommands,
+		"skills":        config.HubSkills,
+		"agents":        config.HubAgents,
+		"hooks":         config.HubHooks,
+		"rules":         config.HubRules,
+		"commands":      config.HubCommands,
+		"output-styles": config.HubOutputStyles,
 	}
 
 	for dirName, itemType := range dirMap {
@@ -0,0 +1,513 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// SearchIndexVersion is bumped whenever the on-disk index format or the
+// tokenizer changes, forcing a full rebuild
+const SearchIndexVersion = 1
+
+// Field weights used when ranking: a hit in the item name counts far more than
+// one buried in the body text
+const (
+	nameWeight = 10.0
+	descWeight = 4.0
+	bodyWeight = 1.0
+)
+
+// SearchIndex is a persistent full-text index over hub items. Each document
+// stores its own term frequencies (the forward index) so a changed item can be
+// reindexed without touching the others; the inverted term -> documents map is
+// derived in memory on load.
+type SearchIndex struct {
+	Version int                     `json:"version"`
+	Docs    map[string]*IndexedItem `json:"docs"`
+
+	inverted map[string][]string
+}
+
+// IndexedItem is one hub item in the search index, keyed by "type/name"
+type IndexedItem struct {
+	Type        config.HubItemType   `json:"type"`
+	Name        string               `json:"name"`
+	File        string               `json:"file,omitempty"` // Markdown file the body came from
+	Description string               `json:"description,omitempty"`
+	Fingerprint string               `json:"fingerprint"`
+	Terms       map[string]TermCount `json:"terms"`
+}
+
+// TermCount records how often a term occurs in each indexed field
+type TermCount struct {
+	Name int `json:"n,omitempty"`
+	Desc int `json:"d,omitempty"`
+	Body int `json:"b,omitempty"`
+}
+
+// SearchResult is a ranked match for a query
+type SearchResult struct {
+	Key         string             `json:"key"`
+	Type        config.HubItemType `json:"type"`
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Score       float64            `json:"score"`
+	Snippet     string             `json:"snippet,omitempty"`
+	Line        int                `json:"line,omitempty"`
+
+	matched map[string]bool
+}
+
+// SearchOptions narrows a search
+type SearchOptions struct {
+	Types []config.HubItemType // empty = all types
+	Limit int                  // 0 = no limit
+}
+
+// NewSearchIndex returns an empty index
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		Version: SearchIndexVersion,
+		Docs:    make(map[string]*IndexedItem),
+	}
+}
+
+// LoadSearchIndex reads the index from disk. A missing, unreadable or
+// outdated index yields an empty one to be rebuilt by Refresh.
+func LoadSearchIndex(path string) *SearchIndex {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewSearchIndex()
+	}
+	var idx SearchIndex
+	if err := json.Unmarshal(data, &idx); err != nil || idx.Version != SearchIndexVersion || idx.Docs == nil {
+		return NewSearchIndex()
+	}
+	return &idx
+}
+
+// Save writes the index to disk, creating the parent directory if needed
+func (idx *SearchIndex) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Refresh brings the index in line with the hub: new or modified items are
+// (re)indexed, removed items are dropped, unchanged items are left alone.
+// Returns true when anything changed and the index should be saved.
+func (idx *SearchIndex) Refresh(h *Hub) bool {
+	changed := false
+	seen := make(map[string]bool)
+
+	for _, item := range h.AllItems() {
+		key := string(item.Type) + "/" + item.Name
+		seen[key] = true
+		fp := itemFingerprint(item)
+		if doc, ok := idx.Docs[key]; ok && doc.Fingerprint == fp {
+			continue
+		}
+		idx.Docs[key] = indexItem(item, fp)
+		changed = true
+	}
+
+	for _, b := range h.Bundles {
+		key := string(config.HubBundles) + "/" + b.Name
+		seen[key] = true
+		manifest := filepath.Join(h.Path, string(config.HubBundles), b.Name, BundleManifestFile)
+		fp := fileFingerprint(manifest)
+		if doc, ok := idx.Docs[key]; ok && doc.Fingerprint == fp {
+			continue
+		}
+		idx.Docs[key] = indexBundle(b, fp)
+		changed = true
+	}
+
+	for key := range idx.Docs {
+		if !seen[key] {
+			delete(idx.Docs, key)
+			changed = true
+		}
+	}
+
+	if changed {
+		idx.inverted = nil
+	}
+	return changed
+}
+
+// Description returns the indexed description for a "type/name" key
+func (idx *SearchIndex) Description(key string) string {
+	if doc, ok := idx.Docs[key]; ok {
+		return doc.Description
+	}
+	return ""
+}
+
+// Search returns items matching every term in the query, best first. Terms
+// match exactly, by prefix, by substring, or within a small edit distance, with
+// weaker matches scoring lower.
+func (idx *SearchIndex) Search(query string, opts SearchOptions) []SearchResult {
+	queryTerms := tokenize(query)
+	if len(queryTerms) == 0 {
+		return nil
+	}
+	idx.buildInverted()
+
+	allowed := make(map[config.HubItemType]bool)
+	for _, t := range opts.Types {
+		allowed[t] = true
+	}
+
+	n := float64(len(idx.Docs))
+	// For each query term, the best score it contributes to each document and
+	// the vocabulary term that produced it
+	bestScores := make([]map[string]float64, len(queryTerms))
+	bestTerms := make([]map[string]string, len(queryTerms))
+	for qi, q := range queryTerms {
+		bestScores[qi] = make(map[string]float64)
+		bestTerms[qi] = make(map[string]string)
+		for term, keys := range idx.inverted {
+			quality := matchQuality(q, term)
+			if quality == 0 {
+				continue
+			}
+			idf := math.Log(1 + n/float64(len(keys)))
+			for _, key := range keys {
+				doc := idx.Docs[key]
+				if len(allowed) > 0 && !allowed[doc.Type] {
+					continue
+				}
+				if s := quality * idf * fieldScore(doc.Terms[term]); s > bestScores[qi][key] {
+					bestScores[qi][key] = s
+					bestTerms[qi][key] = term
+				}
+			}
+		}
+	}
+
+	// AND semantics: a document must match every query term
+	var results []*SearchResult
+	for key := range bestScores[0] {
+		r := &SearchResult{Key: key, matched: make(map[string]bool)}
+		for qi := range queryTerms {
+			s, ok := bestScores[qi][key]
+			if !ok {
+				r = nil
+				break
+			}
+			r.Score += s
+			r.matched[bestTerms[qi][key]] = true
+		}
+		if r == nil {
+			continue
+		}
+		doc := idx.Docs[key]
+		r.Type, r.Name, r.Description = doc.Type, doc.Name, doc.Description
+		results = append(results, r)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Key < results[j].Key
+	})
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+
+	// Snippets read the item file, so only build them for returned results
+	ranked := make([]SearchResult, 0, len(results))
+	for _, r := range results {
+		idx.attachSnippet(r, query)
+		ranked = append(ranked, *r)
+	}
+	return ranked
+}
+
+func (idx *SearchIndex) buildInverted() {
+	if idx.inverted != nil {
+		return
+	}
+	idx.inverted = make(map[string][]string)
+	keys := make([]string, 0, len(idx.Docs))
+	for key := range idx.Docs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for term := range idx.Docs[key].Terms {
+			idx.inverted[term] = append(idx.inverted[term], key)
+		}
+	}
+}
+
+// attachSnippet finds the first body line mentioning a matched term. Falls
+// back to the description when the hit was only in the name.
+func (idx *SearchIndex) attachSnippet(r *SearchResult, query string) {
+	doc := idx.Docs[r.Key]
+	if doc.File != "" {
+		if data, err := os.ReadFile(doc.File); err == nil {
+			body, offset := string(data), 0
+			if fm, err := ParseFrontmatter(data); err == nil && fm != nil {
+				body, offset = fm.Body, fm.BodyLine-1
+			}
+			phrase := strings.ToLower(strings.TrimSpace(query))
+			lines := strings.Split(body, "\n")
+			terms := make([]string, 0, len(r.matched))
+			for term := range r.matched {
+				terms = append(terms, term)
+			}
+			sort.Strings(terms)
+			// Prefer a line containing the whole query, then the earliest
+			// matched term in a line
+			for pass := 0; pass < 2; pass++ {
+				for i, line := range lines {
+					lower := strings.ToLower(line)
+					hit := -1
+					if pass == 0 {
+						hit = strings.Index(lower, phrase)
+					} else {
+						for _, term := range terms {
+							if at := strings.Index(lower, term); at >= 0 && (hit < 0 || at < hit) {
+								hit = at
+							}
+						}
+					}
+					if hit >= 0 {
+						r.Snippet = excerpt(line, hit, 100)
+						r.Line = offset + i + 1
+						return
+					}
+				}
+			}
+		}
+	}
+	r.Snippet = excerpt(doc.Description, 0, 100)
+}
+
+// matchQuality scores how well a vocabulary term matches a query term
+func matchQuality(q, term string) float64 {
+	switch {
+	case term == q:
+		return 1.0
+	case len(q) >= 2 && strings.HasPrefix(term, q):
+		return 0.75
+	case len(q) >= 3 && strings.Contains(term, q):
+		return 0.5
+	case len(q) >= 4 && abs(len(term)-len(q)) <= 2:
+		maxDist := 1
+		if len(q) >= 8 {
+			maxDist = 2
+		}
+		if editDistance(q, term, maxDist) <= maxDist {
+			return 0.4
+		}
+	}
+	return 0
+}
+
+func fieldScore(tc TermCount) float64 {
+	s := 0.0
+	if tc.Name > 0 {
+		s += nameWeight
+	}
+	if tc.Desc > 0 {
+		s += descWeight * (1 + math.Log(float64(tc.Desc)))
+	}
+	if tc.Body > 0 {
+		s += bodyWeight * (1 + math.Log(float64(tc.Body)))
+	}
+	return s
+}
+
+func indexItem(item Item, fingerprint string) *IndexedItem {
+	doc := &IndexedItem{
+		Type:        item.Type,
+		Name:        item.Name,
+		Fingerprint: fingerprint,
+		Terms:       make(map[string]TermCount),
+	}
+	for _, t := range tokenize(itemBaseName(item.Name)) {
+		tc := doc.Terms[t]
+		tc.Name++
+		doc.Terms[t] = tc
+	}
+
+	doc.File = MarkdownPath(item)
+	if doc.File == "" {
+		return doc
+	}
+	data, err := os.ReadFile(doc.File)
+	if err != nil {
+		return doc
+	}
+
+	body := string(data)
+	if fm, err := ParseFrontmatter(data); err == nil && fm != nil {
+		doc.Description = fm.String("description")
+		body = fm.Body
+	}
+	for _, t := range tokenize(doc.Description) {
+		tc := doc.Terms[t]
+		tc.Desc++
+		doc.Terms[t] = tc
+	}
+	for _, t := range tokenize(body) {
+		tc := doc.Terms[t]
+		tc.Body++
+		doc.Terms[t] = tc
+	}
+	return doc
+}
+
+func indexBundle(b *Bundle, fingerprint string) *IndexedItem {
+	doc := &IndexedItem{
+		Type:        config.HubBundles,
+		Name:        b.Name,
+		Description: b.Description,
+		Fingerprint: fingerprint,
+		Terms:       make(map[string]TermCount),
+	}
+	for _, t := range tokenize(b.Name) {
+		tc := doc.Terms[t]
+		tc.Name++
+		doc.Terms[t] = tc
+	}
+	for _, t := range tokenize(b.Description) {
+		tc := doc.Terms[t]
+		tc.Desc++
+		doc.Terms[t] = tc
+	}
+	for _, member := range b.Members.AllComponents() {
+		for _, t := range tokenize(member.Name) {
+			tc := doc.Terms[t]
+			tc.Body++
+			doc.Terms[t] = tc
+		}
+	}
+	return doc
+}
+
+// itemFingerprint identifies the indexed content of an item so unchanged
+// items can be skipped on refresh
+func itemFingerprint(item Item) string {
+	if md := MarkdownPath(item); md != "" {
+		return fileFingerprint(md)
+	}
+	return fileFingerprint(item.Path)
+}
+
+func fileFingerprint(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size())
+}
+
+// stopWords are skipped by the tokenizer
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "with": true, "you": true, "your": true,
+}
+
+// tokenize lowercases text and splits it into alphanumeric terms
+func tokenize(text string) []string {
+	var terms []string
+	for _, f := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(f) < 2 || len(f) > 40 || stopWords[f] {
+			continue
+		}
+		terms = append(terms, f)
+	}
+	return terms
+}
+
+// excerpt trims a line to roughly width characters centered near pos
+func excerpt(line string, pos, width int) string {
+	line = strings.TrimSpace(line)
+	if len(line) <= width {
+		return line
+	}
+	start := pos - width/3
+	if start < 0 {
+		start = 0
+	}
+	end := start + width
+	if end > len(line) {
+		end = len(line)
+		start = max(0, end-width)
+	}
+	// Avoid cutting through a multi-byte character
+	for start > 0 && !isRuneStart(line[start]) {
+		start--
+	}
+	for end < len(line) && !isRuneStart(line[end]) {
+		end++
+	}
+	out := line[start:end]
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(line) {
+		out += "…"
+	}
+	return out
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// editDistance computes the Levenshtein distance between a and b, giving up
+// early (returning limit+1) once it must exceed limit
+func editDistance(a, b string, limit int) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,124 @@
+package hub
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+func setupSearchHub(t *testing.T) string {
+	t.Helper()
+	hubDir := t.TempDir()
+	writeLintFile(t, filepath.Join(hubDir, "skills", "terraform", "SKILL.md"),
+		"---\nname: terraform\ndescription: Plan and apply infrastructure\n---\n# Terraform\n\nRun terraform plan before apply.\n")
+	writeLintFile(t, filepath.Join(hubDir, "skills", "kubernetes", "SKILL.md"),
+		"---\nname: kubernetes\ndescription: Manage clusters with kubectl\n---\nUse helm charts.\nAlso check terraform state for cluster infra.\n")
+	writeLintFile(t, filepath.Join(hubDir, "agents", "reviewer.md"),
+		"---\nname: reviewer\ndescription: Reviews terraform modules\n---\nYou review code.\n")
+	return hubDir
+}
+
+func scanSearchHub(t *testing.T, hubDir string) *Hub {
+	t.Helper()
+	h, err := NewScanner().Scan(hubDir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	return h
+}
+
+func resultKeys(results []SearchResult) []string {
+	var keys []string
+	for _, r := range results {
+		keys = append(keys, r.Key)
+	}
+	return keys
+}
+
+func TestSearchRanking(t *testing.T) {
+	hubDir := setupSearchHub(t)
+	idx := NewSearchIndex()
+	idx.Refresh(scanSearchHub(t, hubDir))
+
+	results := idx.Search("terraform", SearchOptions{})
+	keys := resultKeys(results)
+	want := []string{"skills/terraform", "agents/reviewer.md", "skills/kubernetes"}
+	if len(keys) != len(want) {
+		t.Fatalf("results = %v, want %v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("results = %v, want %v (name > description > body)", keys, want)
+		}
+	}
+
+	// Body hit carries a snippet pointing at the matching line
+	last := results[2]
+	if last.Line != 6 || last.Snippet == "" {
+		t.Errorf("snippet = %d: %q, want line 6", last.Line, last.Snippet)
+	}
+}
+
+func TestSearchFuzzyAndTerms(t *testing.T) {
+	hubDir := setupSearchHub(t)
+	idx := NewSearchIndex()
+	idx.Refresh(scanSearchHub(t, hubDir))
+
+	if keys := resultKeys(idx.Search("kubernets", SearchOptions{})); len(keys) != 1 || keys[0] != "skills/kubernetes" {
+		t.Errorf("fuzzy search = %v, want skills/kubernetes", keys)
+	}
+	if keys := resultKeys(idx.Search("terraform helm", SearchOptions{})); len(keys) != 1 || keys[0] != "skills/kubernetes" {
+		t.Errorf("all terms must match, got %v", keys)
+	}
+	if keys := resultKeys(idx.Search("terraform", SearchOptions{Types: []config.HubItemType{config.HubAgents}})); len(keys) != 1 || keys[0] != "agents/reviewer.md" {
+		t.Errorf("type filter = %v, want agents/reviewer", keys)
+	}
+	if keys := resultKeys(idx.Search("terraform", SearchOptions{Limit: 1})); len(keys) != 1 {
+		t.Errorf("limit = %v, want 1 result", keys)
+	}
+	if results := idx.Search("nonexistentword", SearchOptions{}); len(results) != 0 {
+		t.Errorf("expected no results, got %v", resultKeys(results))
+	}
+}
+
+func TestSearchIndexIncremental(t *testing.T) {
+	hubDir := setupSearchHub(t)
+	indexPath := filepath.Join(t.TempDir(), "cache", "search-index.json")
+
+	idx := LoadSearchIndex(indexPath)
+	if !idx.Refresh(scanSearchHub(t, hubDir)) {
+		t.Fatal("first refresh should report changes")
+	}
+	if err := idx.Save(indexPath); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	idx = LoadSearchIndex(indexPath)
+	if len(idx.Docs) != 3 {
+		t.Fatalf("loaded %d docs, want 3", len(idx.Docs))
+	}
+	if idx.Refresh(scanSearchHub(t, hubDir)) {
+		t.Error("refresh of an unchanged hub should be a no-op")
+	}
+
+	// Edit one item and remove another
+	writeLintFile(t, filepath.Join(hubDir, "agents", "reviewer.md"),
+		"---\nname: reviewer\ndescription: Reviews golang packages carefully\n---\nYou review code.\n")
+	if err := os.RemoveAll(filepath.Join(hubDir, "skills", "kubernetes")); err != nil {
+		t.Fatal(err)
+	}
+	if !idx.Refresh(scanSearchHub(t, hubDir)) {
+		t.Fatal("refresh should pick up edits and removals")
+	}
+	if _, ok := idx.Docs["skills/kubernetes"]; ok {
+		t.Error("removed item still indexed")
+	}
+	if got := idx.Description("agents/reviewer.md"); got != "Reviews golang packages carefully" {
+		t.Errorf("description = %q", got)
+	}
+	if keys := resultKeys(idx.Search("golang", SearchOptions{})); len(keys) != 1 || keys[0] != "agents/reviewer.md" {
+		t.Errorf("search after edit = %v", keys)
+	}
+}
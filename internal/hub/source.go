@@ -33,10 +33,11 @@ func (g *GitHubSource) RepoURL() string {
 
 // PluginSource contains plugin back-reference for components installed via plugin
 type PluginSource struct {
-	Name    string `yaml:"name"`    // plugin name
-	Owner   string `yaml:"owner"`   // original repo owner
-	Repo    string `yaml:"repo"`    // original repo name
-	Version string `yaml:"version"` // plugin version at install time
+	Name        string `yaml:"name"`                  // plugin name
+	Owner       string `yaml:"owner"`                 // original repo owner
+	Repo        string `yaml:"repo"`                  // original repo name
+	Version     string `yaml:"version"`               // plugin version at install time
+	Marketplace string `yaml:"marketplace,omitempty"` // Claude Code marketplace, for adopted plugins
 }
 
 // SourceManifest tracks the origin of a hub item
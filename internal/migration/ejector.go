@@ -0,0 +1,147 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/profile"
+)
+
+// EjectOptions controls what 'ccp profile eject' puts in the output directory
+type EjectOptions struct {
+	// Root is the path the ejected directory will live at when used (e.g.
+	// "$HOME/.claude" inside a container); defaults to the output directory.
+	// Profile paths in settings.json and plugin metadata are rewritten to it.
+	Root string
+
+	IncludeShared      bool // copy shared data dirs (history, projects, ...)
+	IncludeCredentials bool // copy .credentials.json
+}
+
+// EjectResult describes an ejected profile
+type EjectResult struct {
+	Dir                string   // output directory
+	Root               string   // path profile references were rewritten to
+	Rewritten          []string // files whose paths were rewritten, relative to Dir
+	SkippedShared      []string // shared data dirs left out
+	SkippedCredentials bool
+}
+
+// Ejector copies a profile into a standalone config directory that works
+// without ccp
+type Ejector struct {
+	paths *config.Paths
+}
+
+// NewEjector creates a new ejector
+func NewEjector(paths *config.Paths) *Ejector {
+	return &Ejector{paths: paths}
+}
+
+// ejectRewriteFiles are the files that may hold absolute profile or plugin
+// store paths, relative to the profile directory
+var ejectRewriteFiles = []string{
+	"settings.json",
+	path.Join("plugins", string(config.PluginStoreKnownMarketplaces)),
+	path.Join("plugins", "installed_plugins.json"),
+}
+
+// Eject copies profile profileName into destDir, which must not exist or be
+// empty. Symlinks are resolved to real files, ccp's own files are left out,
+// and paths into the profile and plugin store are rewritten to opts.Root.
+func (e *Ejector) Eject(profileName, destDir string, opts EjectOptions) (*EjectResult, error) {
+	profileDir := e.paths.ProfileDir(profileName)
+	profileInfo, err := os.Stat(profileDir)
+	if err != nil {
+		return nil, fmt.Errorf("profile not found: %s", profileName)
+	}
+
+	destDir, err = filepath.Abs(destDir)
+	if err != nil {
+		return nil, err
+	}
+	if entries, err := os.ReadDir(destDir); err == nil {
+		if len(entries) > 0 {
+			return nil, fmt.Errorf("destination %s is not empty", destDir)
+		}
+		if err := os.Remove(destDir); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(destDir), 0755); err != nil {
+		return nil, err
+	}
+
+	result := &EjectResult{Dir: destDir, Root: opts.Root}
+	if result.Root == "" {
+		result.Root = destDir
+	}
+
+	sharedData := make(map[string]bool)
+	for _, dataType := range config.AllDataItemTypes() {
+		sharedData[string(dataType)] = true
+	}
+	skip := func(rel string, info os.FileInfo) bool {
+		switch rel {
+		case "profile.toml", "profile.yaml", profile.SettingsFragmentFile, profile.CopiesFile:
+			return true
+		case string(config.HubMemory):
+			// Snippets are already rendered into CLAUDE.md
+			return true
+		case config.DataCredentials.EntryName():
+			result.SkippedCredentials = !opts.IncludeCredentials
+			return !opts.IncludeCredentials
+		}
+		// Shared data dirs are symlinks into profiles/shared; isolated ones
+		// belong to the profile and are always copied
+		if sharedData[rel] && info.Mode()&os.ModeSymlink != 0 && !opts.IncludeShared {
+			result.SkippedShared = append(result.SkippedShared, rel)
+			return true
+		}
+		return false
+	}
+
+	// Copy into a temporary sibling so a failed eject leaves nothing behind
+	tempDir, err := os.MkdirTemp(filepath.Dir(destDir), ".ccp-eject-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir in %s: %w", filepath.Dir(destDir), err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := copyResolvingSymlinks(profileDir, tempDir, "", skip); err != nil {
+		return nil, fmt.Errorf("failed to copy profile contents from %s: %w", profileDir, err)
+	}
+	if err := os.Rename(tempDir, destDir); err != nil {
+		return nil, fmt.Errorf("failed to rename %s to %s: %w", tempDir, destDir, err)
+	}
+	if err := os.Chmod(destDir, profileInfo.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("failed to set permissions on %s: %w", destDir, err)
+	}
+
+	// Hook commands use $HOME-based paths; plugin metadata uses absolute ones
+	replacements := make(map[string]string)
+	for old, rel := range map[string]string{profileDir: "", e.paths.StorePluginsDir(): "plugins"} {
+		newPath := result.Root
+		if rel != "" {
+			newPath += "/" + rel
+		}
+		replacements[old] = newPath
+		replacements[config.ToPortablePath(old)] = newPath
+	}
+	for _, rel := range ejectRewriteFiles {
+		changed, err := rewritePathPrefixes(filepath.Join(destDir, rel), replacements)
+		if err != nil {
+			return result, fmt.Errorf("failed to rewrite paths in %s: %w", rel, err)
+		}
+		if changed {
+			result.Rewritten = append(result.Rewritten, rel)
+		}
+	}
+
+	return result, nil
+}
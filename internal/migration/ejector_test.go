@@ -0,0 +1,127 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+func TestEjector_Eject(t *testing.T) {
+	paths, tmpDir := setupTestPaths(t)
+	t.Setenv("HOME", tmpDir)
+
+	// Hub skill and hook, linked into profile "ci" with a shared data dir
+	skillDir := filepath.Join(paths.HubDir, "skills", "debug")
+	hookDir := filepath.Join(paths.HubDir, "hooks", "lint")
+	profileDir := paths.ProfileDir("ci")
+	sharedHistory := filepath.Join(paths.SharedDir, "history")
+	for _, dir := range []string{skillDir, hookDir, sharedHistory, filepath.Join(profileDir, "skills"), filepath.Join(profileDir, "hooks")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		filepath.Join(skillDir, "SKILL.md"):                 "# debug",
+		filepath.Join(hookDir, "run.sh"):                    "echo lint",
+		filepath.Join(sharedHistory, "session.jsonl"):       "{}",
+		filepath.Join(profileDir, "profile.toml"):           "name = 'ci'",
+		filepath.Join(profileDir, "settings-fragment.json"): "{}",
+		filepath.Join(profileDir, config.CredentialsFile):   "secret",
+		filepath.Join(profileDir, "settings.json"): `{"hooks": {"PostToolUse": [{"hooks": [{"command": "$HOME/.ccp/profiles/ci/hooks/lint/run.sh"}]}]},
+ "other": "` + profileDir + `-old/x"}`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		filepath.Join(profileDir, "skills", "debug"): skillDir,
+		filepath.Join(profileDir, "hooks", "lint"):   hookDir,
+		filepath.Join(profileDir, "history"):         sharedHistory,
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	destDir := filepath.Join(tmpDir, "out")
+	result, err := NewEjector(paths).Eject("ci", destDir, EjectOptions{})
+	if err != nil {
+		t.Fatalf("Eject() error = %v", err)
+	}
+
+	info, err := os.Lstat(filepath.Join(destDir, "skills", "debug"))
+	if err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Errorf("skills/debug should be a real directory: %v", err)
+	}
+	for _, left := range []string{"profile.toml", "settings-fragment.json", config.CredentialsFile, "history"} {
+		if _, err := os.Lstat(filepath.Join(destDir, left)); !os.IsNotExist(err) {
+			t.Errorf("%s should be left out", left)
+		}
+	}
+	if !result.SkippedCredentials || len(result.SkippedShared) != 1 || result.SkippedShared[0] != "history" {
+		t.Errorf("result = %+v, want credentials and history skipped", result)
+	}
+
+	data, err := os.ReadFile(filepath.Join(destDir, "settings.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), destDir+"/hooks/lint/run.sh") {
+		t.Errorf("hook path not rewritten: %s", data)
+	}
+	if !strings.Contains(string(data), profileDir+"-old/x") {
+		t.Errorf("unrelated path rewritten: %s", data)
+	}
+
+	// Output directories are never overwritten
+	if _, err := NewEjector(paths).Eject("ci", destDir, EjectOptions{}); err == nil {
+		t.Error("Eject() into a non-empty directory should fail")
+	}
+}
+
+func TestEjector_Eject_IncludeAndRoot(t *testing.T) {
+	paths, tmpDir := setupTestPaths(t)
+	profileDir := paths.ProfileDir("ci")
+	sharedHistory := filepath.Join(paths.SharedDir, "history")
+	if err := os.MkdirAll(sharedHistory, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(profileDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(sharedHistory, filepath.Join(profileDir, "history")); err != nil {
+		t.Fatal(err)
+	}
+	settings := `{"statusLine": {"command": "` + profileDir + `/statusline.sh"}}`
+	if err := os.WriteFile(filepath.Join(profileDir, "settings.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(profileDir, config.CredentialsFile), []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := filepath.Join(tmpDir, "out")
+	opts := EjectOptions{Root: "/home/runner/.claude", IncludeShared: true, IncludeCredentials: true}
+	if _, err := NewEjector(paths).Eject("ci", destDir, opts); err != nil {
+		t.Fatalf("Eject() error = %v", err)
+	}
+
+	for _, included := range []string{"history", config.CredentialsFile} {
+		if _, err := os.Stat(filepath.Join(destDir, included)); err != nil {
+			t.Errorf("%s should be included: %v", included, err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(destDir, "settings.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "/home/runner/.claude/statusline.sh") {
+		t.Errorf("path not rewritten to root: %s", data)
+	}
+}
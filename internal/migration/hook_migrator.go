@@ -16,6 +16,10 @@ type HookMigrator struct {
 	paths    *config.Paths
 	symMgr   *symlink.Manager
 	rollback *Rollback
+
+	// CopyInside copies hooks inside ~/.claude instead of moving them, so the
+	// source directory stays intact (used by 'ccp init --merge')
+	CopyInside bool
 }
 
 // NewHookMigrator creates a new hook migrator
@@ -45,8 +49,13 @@ func (m *HookMigrator) MigrateHooks(plan *HookMigrationPlan, profileDir string)
 		return nil, fmt.Errorf("failed to create hooks hub dir: %w", err)
 	}
 
-	// Track used names to avoid conflicts
+	// Track used names to avoid conflicts, including hooks already in the hub
 	usedNames := make(map[string]int)
+	if entries, err := os.ReadDir(hooksHubDir); err == nil {
+		for _, e := range entries {
+			usedNames[e.Name()]++
+		}
+	}
 
 	// Migrate inside hooks (always migrate)
 	for _, hook := range plan.Inside {
@@ -113,7 +122,7 @@ func (m *HookMigrator) migrateHook(hook ClassifiedHook, usedNames map[string]int
 	dstPath := filepath.Join(scriptsDir, scriptName)
 
 	// Copy the script file (or move if inside)
-	if hook.Location == HookLocationInside {
+	if hook.Location == HookLocationInside && !m.CopyInside {
 		if err := moveItem(srcPath, dstPath); err != nil {
 			return nil, fmt.Errorf("failed to move hook file: %w", err)
 		}
@@ -244,13 +253,13 @@ func (m *HookMigrator) saveHooksJSON(hooksJSON *config.HooksJSON, hookDir string
 	return os.WriteFile(hooksPath, data, 0644)
 }
 
-// uniqueName generates a unique name by appending a suffix if needed
+// uniqueName returns base, or base-2, base-3, ... if taken, and marks it used
 func (m *HookMigrator) uniqueName(base string, used map[string]int) string {
 	name := base
-	if count, exists := used[base]; exists {
-		name = fmt.Sprintf("%s-%d", base, count+1)
+	for n := 2; used[name] > 0; n++ {
+		name = fmt.Sprintf("%s-%d", base, n)
 	}
-	used[base]++
+	used[name]++
 	return name
 }
 
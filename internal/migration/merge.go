@@ -0,0 +1,452 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/profile"
+)
+
+// MergeAction is what a merge does with one item of the local ~/.claude
+type MergeAction int
+
+const (
+	MergeAdopt  MergeAction = iota // Copy into the hub under its own name
+	MergeSkip                      // Identical item already in the hub
+	MergeRename                    // Name taken by a different item; copy under HubName
+)
+
+// String returns the string representation of MergeAction
+func (a MergeAction) String() string {
+	switch a {
+	case MergeAdopt:
+		return "adopt"
+	case MergeSkip:
+		return "skip"
+	case MergeRename:
+		return "rename"
+	default:
+		return "unknown"
+	}
+}
+
+// MergeItem is one hub-eligible item found in the local ~/.claude
+type MergeItem struct {
+	Type    config.HubItemType
+	Name    string // name in ~/.claude
+	HubName string // name in the hub after the merge
+	Action  MergeAction
+}
+
+// MergePlan describes how a local ~/.claude is folded into an existing ccp
+// setup (e.g. one synced from another machine)
+type MergePlan struct {
+	Profile       string // profile created or refreshed from the local settings
+	ProfileExists bool
+	Items         []MergeItem
+	FilesToCopy   []string // CLAUDE.md etc. missing from the profile
+	FileConflicts []string // files that differ from the profile's; left in the backup
+	DataDirs      []string // data directories merged into shared/
+	Leftovers     []string // other entries copied into the profile
+	BackupDir     string   // where the local ~/.claude is moved to
+
+	HookClassification *HookClassification
+	HookMigrationPlan  *HookMigrationPlan
+	MigratedHooks      []MigratedHook
+
+	SettingsTemplate map[string]interface{}
+	TemplateName     string
+	TemplateAction   MergeAction // MergeSkip reuses an identical template
+}
+
+// PlanMerge plans merging the local ~/.claude into the hub and profile
+// profileName. Items are compared with hub items of the same name by content
+// hash: identical ones are skipped, different ones renamed (name-2, name-3...).
+func (m *Migrator) PlanMerge(profileName string) (*MergePlan, error) {
+	profileDir := m.paths.ProfileDir(profileName)
+	backup := m.paths.ClaudeDir + ".pre-merge-" + time.Now().Format("20060102-150405")
+	plan := &MergePlan{Profile: profileName, BackupDir: backup}
+	for n := 2; ; n++ {
+		if _, err := os.Lstat(plan.BackupDir); err != nil {
+			break
+		}
+		plan.BackupDir = fmt.Sprintf("%s-%d", backup, n)
+	}
+	if _, err := os.Stat(profile.ManifestPath(profileDir)); err == nil {
+		plan.ProfileExists = true
+	}
+
+	scanner := hub.NewScanner()
+	h, err := scanner.ScanSource(m.paths.ClaudeDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan source: %w", err)
+	}
+
+	for _, itemType := range config.AllHubItemTypes() {
+		// Hooks are extracted from settings.json by HookMigrator
+		if itemType == config.HubHooks {
+			continue
+		}
+		for _, item := range h.Items[itemType] {
+			src := filepath.Join(m.paths.ClaudeDir, string(itemType), item.Name)
+			hubName, action, err := m.mergeTarget(itemType, item.Name, src)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare %s/%s: %w", itemType, item.Name, err)
+			}
+			plan.Items = append(plan.Items, MergeItem{Type: itemType, Name: item.Name, HubName: hubName, Action: action})
+		}
+	}
+
+	for _, f := range []string{"CLAUDE.md", "settings.local.json", config.DataCredentials.EntryName()} {
+		src := filepath.Join(m.paths.ClaudeDir, f)
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		dst := filepath.Join(profileDir, f)
+		if _, err := os.Stat(dst); err != nil {
+			plan.FilesToCopy = append(plan.FilesToCopy, f)
+		} else if !sameContent(src, dst) {
+			plan.FileConflicts = append(plan.FileConflicts, f)
+		}
+	}
+
+	for _, dataType := range config.AllDataItemTypes() {
+		if info, err := os.Stat(filepath.Join(m.paths.ClaudeDir, string(dataType))); err == nil && info.IsDir() {
+			plan.DataDirs = append(plan.DataDirs, string(dataType))
+		}
+	}
+
+	entries, err := os.ReadDir(m.paths.ClaudeDir)
+	if err != nil {
+		return nil, err
+	}
+	known := knownClaudeEntries()
+	for _, e := range entries {
+		if known[e.Name()] {
+			continue
+		}
+		if _, err := os.Lstat(filepath.Join(profileDir, e.Name())); err == nil && plan.ProfileExists {
+			continue
+		}
+		plan.Leftovers = append(plan.Leftovers, e.Name())
+	}
+
+	settingsPath := filepath.Join(m.paths.ClaudeDir, "settings.json")
+	if _, err := os.Stat(settingsPath); err == nil {
+		settings, err := ParseSettings(settingsPath)
+		if err == nil && settings.Hooks != nil {
+			plan.HookClassification = ClassifyHooks(ExtractHookPaths(settings, m.paths.ClaudeDir), m.paths.ClaudeDir)
+			plan.HookMigrationPlan = &HookMigrationPlan{
+				Inside: plan.HookClassification.Inside,
+				Inline: plan.HookClassification.Inline,
+			}
+			for _, hook := range plan.HookClassification.Outside {
+				plan.HookMigrationPlan.Decisions = append(plan.HookMigrationPlan.Decisions, HookMigrationDecision{
+					Hook:   hook,
+					Choice: HookChoiceCopy,
+				})
+			}
+		}
+
+		tmplSettings, err := hub.ExtractFromSettings(settingsPath)
+		if err == nil && len(tmplSettings) > 0 {
+			plan.SettingsTemplate = tmplSettings
+			plan.TemplateName, plan.TemplateAction = m.mergeTemplateTarget(profileName, tmplSettings)
+		}
+	}
+
+	return plan, nil
+}
+
+// mergeTarget picks the hub name for a local item: the first of name, name-2,
+// name-3... that is free, unless one of them already holds identical content
+func (m *Migrator) mergeTarget(itemType config.HubItemType, name, src string) (string, MergeAction, error) {
+	localHash, err := hub.ContentHash(src)
+	if err != nil {
+		return "", 0, err
+	}
+
+	base, ext := name, ""
+	if info, err := os.Stat(src); err == nil && !info.IsDir() {
+		ext = filepath.Ext(name)
+		base = strings.TrimSuffix(name, ext)
+	}
+
+	candidate := name
+	for n := 2; ; n++ {
+		hubPath := m.paths.HubItemPath(itemType, candidate)
+		if _, err := os.Lstat(hubPath); err != nil {
+			if candidate == name {
+				return candidate, MergeAdopt, nil
+			}
+			return candidate, MergeRename, nil
+		}
+		if hubHash, err := hub.ContentHash(hubPath); err == nil && hubHash == localHash {
+			return candidate, MergeSkip, nil
+		}
+		candidate = fmt.Sprintf("%s-%d%s", base, n, ext)
+	}
+}
+
+// mergeTemplateTarget reuses a hub template with the same settings, or names
+// a new one after the profile
+func (m *Migrator) mergeTemplateTarget(profileName string, settings map[string]interface{}) (string, MergeAction) {
+	tmplMgr := hub.NewTemplateManager(m.paths.HubDir)
+	if templates, err := tmplMgr.List(); err == nil {
+		for _, t := range templates {
+			if reflect.DeepEqual(t.Settings, settings) {
+				return t.Name, MergeSkip
+			}
+		}
+	}
+
+	name := profileName
+	for n := 2; tmplMgr.Exists(name); n++ {
+		name = fmt.Sprintf("%s-%d", profileName, n)
+	}
+	if name != profileName {
+		return name, MergeRename
+	}
+	return name, MergeAdopt
+}
+
+// ExecuteMerge performs a merge plan. Items are copied, not moved: the local
+// ~/.claude is kept intact as plan.BackupDir and ~/.claude is replaced with a
+// symlink to the merged profile.
+func (m *Migrator) ExecuteMerge(plan *MergePlan) error {
+	// Step 1: Make sure the hub layout exists (it is shared, never rolled back)
+	for _, itemType := range config.AllHubItemTypes() {
+		if err := os.MkdirAll(m.paths.HubItemDir(itemType), 0755); err != nil {
+			return fmt.Errorf("failed to create hub/%s: %w", itemType, err)
+		}
+	}
+
+	// Step 2: Save the settings template
+	if len(plan.SettingsTemplate) > 0 && plan.TemplateAction != MergeSkip {
+		tmplMgr := hub.NewTemplateManager(m.paths.HubDir)
+		if err := tmplMgr.Save(&hub.Template{Name: plan.TemplateName, Settings: plan.SettingsTemplate}); err != nil {
+			return fmt.Errorf("failed to save settings template: %w", err)
+		}
+		m.rollback.AddDir(filepath.Join(m.paths.HubDir, string(config.HubSettingsTemplates), plan.TemplateName))
+	}
+
+	// Step 3: Copy adopted and renamed items into the hub
+	for _, item := range plan.Items {
+		if item.Action == MergeSkip {
+			continue
+		}
+		src := filepath.Join(m.paths.ClaudeDir, string(item.Type), item.Name)
+		dst := m.paths.HubItemPath(item.Type, item.HubName)
+		if err := copyRecursive(src, dst); err != nil {
+			return m.rollbackAndReturn(fmt.Errorf("failed to copy %s/%s: %w", item.Type, item.Name, err))
+		}
+		m.rollback.AddDir(dst)
+	}
+
+	// Step 4: Create the profile, or link the items into the existing one
+	profileDir := m.paths.ProfileDir(plan.Profile)
+	if !plan.ProfileExists {
+		manifest := profile.NewManifest(plan.Profile, "Merged from local ~/.claude")
+		for _, item := range plan.Items {
+			manifest.AddHubItem(item.Type, item.HubName)
+		}
+		if _, err := profile.NewManager(m.paths).Create(plan.Profile, manifest); err != nil {
+			return m.rollbackAndReturn(fmt.Errorf("failed to create profile %s: %w", plan.Profile, err))
+		}
+		m.rollback.AddDir(profileDir)
+	} else {
+		for _, item := range plan.Items {
+			linkPath := filepath.Join(profileDir, string(item.Type), item.HubName)
+			if _, err := os.Lstat(linkPath); err == nil {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(linkPath), 0755); err != nil {
+				return m.rollbackAndReturn(err)
+			}
+			if err := m.symMgr.Create(linkPath, m.paths.HubItemPath(item.Type, item.HubName)); err != nil {
+				return m.rollbackAndReturn(fmt.Errorf("failed to create symlink %s: %w", linkPath, err))
+			}
+		}
+	}
+
+	manifestPath := profile.ManifestPath(profileDir)
+	manifest, err := profile.LoadManifest(manifestPath)
+	if err != nil {
+		return m.rollbackAndReturn(fmt.Errorf("failed to load manifest: %w", err))
+	}
+	for _, item := range plan.Items {
+		manifest.AddHubItem(item.Type, item.HubName)
+	}
+	if plan.TemplateName != "" {
+		manifest.SettingsTemplate = plan.TemplateName
+	}
+
+	// Step 5: Migrate hooks, reusing identical hooks already in the hub
+	if plan.HookMigrationPlan != nil {
+		existing := hubItemHashes(m.paths.HubItemDir(config.HubHooks))
+
+		hookMigrator := NewHookMigrator(m.paths, m.rollback)
+		hookMigrator.CopyInside = true
+		migrated, err := hookMigrator.MigrateHooks(plan.HookMigrationPlan, profileDir)
+		if err != nil {
+			return m.rollbackAndReturn(fmt.Errorf("failed to migrate hooks: %w", err))
+		}
+		for i := range migrated {
+			if err := m.dedupeMigratedHook(&migrated[i], existing, profileDir); err != nil {
+				return m.rollbackAndReturn(err)
+			}
+			manifest.AddHubItem(config.HubHooks, migrated[i].Name)
+		}
+		plan.MigratedHooks = migrated
+	}
+
+	if err := manifest.Save(manifestPath); err != nil {
+		return m.rollbackAndReturn(fmt.Errorf("failed to save manifest: %w", err))
+	}
+	if err := profile.RegenerateSettings(m.paths, profileDir, manifest); err != nil {
+		return m.rollbackAndReturn(fmt.Errorf("failed to generate settings.json: %w", err))
+	}
+
+	// Step 6: Copy config files and unknown entries the profile lacks
+	for _, f := range append(plan.FilesToCopy, plan.Leftovers...) {
+		dst := filepath.Join(profileDir, f)
+		if _, err := os.Lstat(dst); err == nil {
+			continue
+		}
+		if err := copyRecursive(filepath.Join(m.paths.ClaudeDir, f), dst); err != nil {
+			return m.rollbackAndReturn(fmt.Errorf("failed to copy %s: %w", f, err))
+		}
+	}
+	if err := m.applyCredentialsMode(profileDir); err != nil {
+		return m.rollbackAndReturn(err)
+	}
+
+	// Step 7: Merge data directories into shared/, keeping existing entries
+	for _, dataType := range plan.DataDirs {
+		src := filepath.Join(m.paths.ClaudeDir, dataType)
+		if err := mergeDir(src, m.paths.SharedDataDir(config.DataItemType(dataType))); err != nil {
+			return m.rollbackAndReturn(fmt.Errorf("failed to merge %s: %w", dataType, err))
+		}
+	}
+
+	// Step 8: Keep the local ~/.claude as a backup and point ~/.claude at the profile
+	if err := os.Rename(m.paths.ClaudeDir, plan.BackupDir); err != nil {
+		return m.rollbackAndReturn(fmt.Errorf("failed to back up %s: %w", m.paths.ClaudeDir, err))
+	}
+	m.rollback.AddMove(plan.BackupDir, m.paths.ClaudeDir)
+	if err := m.symMgr.Create(m.paths.ClaudeDir, profileDir); err != nil {
+		return m.rollbackAndReturn(fmt.Errorf("failed to create symlink: %w", err))
+	}
+
+	m.rollback.Clear()
+	return nil
+}
+
+// dedupeMigratedHook replaces a freshly migrated hook with an identical hook
+// that was already in the hub
+func (m *Migrator) dedupeMigratedHook(hook *MigratedHook, existing map[string]string, profileDir string) error {
+	hash, err := hub.ContentHash(hook.HubPath)
+	if err != nil {
+		return err
+	}
+	name, ok := existing[hash]
+	if !ok {
+		return nil
+	}
+
+	linkPath := filepath.Join(profileDir, string(config.HubHooks), hook.Name)
+	if err := os.Remove(linkPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.RemoveAll(hook.HubPath); err != nil {
+		return err
+	}
+
+	hook.Name = name
+	hook.HubPath = m.paths.HubItemPath(config.HubHooks, name)
+	linkPath = filepath.Join(profileDir, string(config.HubHooks), name)
+	if _, err := os.Lstat(linkPath); err == nil {
+		return nil
+	}
+	return m.symMgr.Create(linkPath, hook.HubPath)
+}
+
+// hubItemHashes maps the content hash of each item in dir to its name
+func hubItemHashes(dir string) map[string]string {
+	hashes := make(map[string]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return hashes
+	}
+	for _, e := range entries {
+		if hash, err := hub.ContentHash(filepath.Join(dir, e.Name())); err == nil {
+			if _, ok := hashes[hash]; !ok {
+				hashes[hash] = e.Name()
+			}
+		}
+	}
+	return hashes
+}
+
+// mergeDir copies entries of src missing from dst, recursing into
+// directories present in both
+func mergeDir(src, dst string) error {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		srcPath := filepath.Join(src, e.Name())
+		dstPath := filepath.Join(dst, e.Name())
+		info, err := os.Stat(dstPath)
+		switch {
+		case err != nil:
+			if err := copyRecursive(srcPath, dstPath); err != nil {
+				return err
+			}
+		case info.IsDir() && e.IsDir():
+			if err := mergeDir(srcPath, dstPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sameContent reports whether two files have identical content
+func sameContent(a, b string) bool {
+	ha, err := hub.ContentHash(a)
+	if err != nil {
+		return false
+	}
+	hb, err := hub.ContentHash(b)
+	return err == nil && ha == hb
+}
+
+// knownClaudeEntries are the ~/.claude entries handled explicitly by a migration
+func knownClaudeEntries() map[string]bool {
+	known := map[string]bool{
+		"CLAUDE.md":                        true,
+		"settings.json":                    true,
+		"settings.local.json":              true,
+		"profile.toml":                     true,
+		"profile.yaml":                     true,
+		config.DataCredentials.EntryName(): true,
+	}
+	for _, t := range config.AllHubItemTypes() {
+		known[string(t)] = true
+	}
+	for _, t := range config.AllDataItemTypes() {
+		known[string(t)] = true
+	}
+	return known
+}
@@ -0,0 +1,149 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/profile"
+)
+
+// writeLocalClaude populates a ~/.claude as found on a second machine, plus
+// any existing ccp files (absolute path to content)
+func writeLocalClaude(t *testing.T, claudeDir string, existing map[string]string) {
+	t.Helper()
+	files := map[string]string{
+		filepath.Join(claudeDir, "skills", "alpha", "SKILL.md"): "# alpha",
+		filepath.Join(claudeDir, "skills", "beta", "SKILL.md"):  "# beta (laptop)",
+		filepath.Join(claudeDir, "skills", "gamma", "SKILL.md"): "# gamma",
+		filepath.Join(claudeDir, "agents", "critic.md"):         "# critic (laptop)",
+		filepath.Join(claudeDir, "CLAUDE.md"):                   "# laptop memory",
+		filepath.Join(claudeDir, "todos", "local.json"):         "[]",
+		filepath.Join(claudeDir, "settings.json"): `{
+  "model": "opus",
+  "hooks": {"SessionStart": [{"hooks": [{"type": "command", "command": "echo hello"}]}]}
+}`,
+	}
+	for path, content := range existing {
+		files[path] = content
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMigrator_PlanMerge(t *testing.T) {
+	paths, _ := setupTestPaths(t)
+	writeLocalClaude(t, paths.ClaudeDir, map[string]string{
+		paths.HubItemPath(config.HubSkills, "alpha") + "/SKILL.md": "# alpha",
+		paths.HubItemPath(config.HubSkills, "beta") + "/SKILL.md":  "# beta",
+		paths.HubItemPath(config.HubAgents, "critic.md"):           "# critic",
+	})
+
+	plan, err := NewMigrator(paths).PlanMerge("laptop")
+	if err != nil {
+		t.Fatalf("PlanMerge: %v", err)
+	}
+
+	want := map[string]MergeItem{
+		"alpha":     {Type: config.HubSkills, Name: "alpha", HubName: "alpha", Action: MergeSkip},
+		"beta":      {Type: config.HubSkills, Name: "beta", HubName: "beta-2", Action: MergeRename},
+		"gamma":     {Type: config.HubSkills, Name: "gamma", HubName: "gamma", Action: MergeAdopt},
+		"critic.md": {Type: config.HubAgents, Name: "critic.md", HubName: "critic-2.md", Action: MergeRename},
+	}
+	if len(plan.Items) != len(want) {
+		t.Fatalf("Items = %+v, want %d items", plan.Items, len(want))
+	}
+	for _, item := range plan.Items {
+		if item != want[item.Name] {
+			t.Errorf("item %s = %+v, want %+v", item.Name, item, want[item.Name])
+		}
+	}
+
+	if plan.ProfileExists || plan.TemplateName != "laptop" || plan.TemplateAction != MergeAdopt {
+		t.Errorf("profile/template = %v %q %v", plan.ProfileExists, plan.TemplateName, plan.TemplateAction)
+	}
+	if plan.HookMigrationPlan == nil || len(plan.HookMigrationPlan.Inline) != 1 {
+		t.Errorf("HookMigrationPlan = %+v, want one inline hook", plan.HookMigrationPlan)
+	}
+}
+
+func TestMigrator_ExecuteMerge_Rerun(t *testing.T) {
+	paths, _ := setupTestPaths(t)
+	paths.StoreDir = filepath.Join(paths.CcpDir, "store")
+	writeLocalClaude(t, paths.ClaudeDir, map[string]string{
+		paths.HubItemPath(config.HubSkills, "beta") + "/SKILL.md":                       "# beta",
+		filepath.Join(paths.SharedDataDir(config.DataItemType("todos")), "synced.json"): "[]",
+	})
+
+	m := NewMigrator(paths)
+	plan, err := m.PlanMerge("laptop")
+	if err != nil {
+		t.Fatalf("PlanMerge: %v", err)
+	}
+	if err := m.ExecuteMerge(plan); err != nil {
+		t.Fatalf("ExecuteMerge: %v", err)
+	}
+
+	profileDir := paths.ProfileDir("laptop")
+	if target, err := filepath.EvalSymlinks(paths.ClaudeDir); err != nil || target != profileDir {
+		t.Errorf("~/.claude -> %q (%v), want %s", target, err, profileDir)
+	}
+	if _, err := os.Stat(filepath.Join(plan.BackupDir, "skills", "gamma", "SKILL.md")); err != nil {
+		t.Errorf("backup is missing local items: %v", err)
+	}
+	if data, _ := os.ReadFile(paths.HubItemPath(config.HubSkills, "beta-2") + "/SKILL.md"); string(data) != "# beta (laptop)" {
+		t.Errorf("hub beta-2 = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(profileDir, "skills", "beta-2", "SKILL.md")); err != nil {
+		t.Errorf("renamed item not linked into profile: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(paths.SharedDataDir(config.DataItemType("todos")), "local.json")); err != nil {
+		t.Errorf("data dir not merged into shared: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(profileDir, "CLAUDE.md")); string(data) != "# laptop memory" {
+		t.Errorf("profile CLAUDE.md = %q", data)
+	}
+
+	manifest, err := profile.LoadManifest(profile.ManifestPath(profileDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.SettingsTemplate != "laptop" || len(manifest.Hub.Hooks) != 1 {
+		t.Errorf("manifest template %q hooks %v", manifest.SettingsTemplate, manifest.Hub.Hooks)
+	}
+	hookName := manifest.Hub.Hooks[0]
+
+	// The same ~/.claude again: everything is an identical duplicate
+	if err := os.Remove(paths.ClaudeDir); err != nil {
+		t.Fatal(err)
+	}
+	writeLocalClaude(t, paths.ClaudeDir, nil)
+
+	plan, err = m.PlanMerge("laptop")
+	if err != nil {
+		t.Fatalf("second PlanMerge: %v", err)
+	}
+	for _, item := range plan.Items {
+		if item.Action != MergeSkip {
+			t.Errorf("rerun item %s/%s = %v, want skip", item.Type, item.Name, item.Action)
+		}
+	}
+	if !plan.ProfileExists || plan.TemplateAction != MergeSkip || len(plan.FilesToCopy) != 0 {
+		t.Errorf("rerun plan = exists %v template %v files %v", plan.ProfileExists, plan.TemplateAction, plan.FilesToCopy)
+	}
+	if err := m.ExecuteMerge(plan); err != nil {
+		t.Fatalf("second ExecuteMerge: %v", err)
+	}
+
+	hooks, _ := os.ReadDir(paths.HubItemDir(config.HubHooks))
+	if len(hooks) != 1 || len(plan.MigratedHooks) != 1 || plan.MigratedHooks[0].Name != hookName {
+		t.Errorf("identical hook was not reused: hub has %d hooks, migrated %+v", len(hooks), plan.MigratedHooks)
+	}
+}
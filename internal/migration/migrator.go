@@ -61,7 +61,7 @@ func (m *Migrator) Plan() (*MigrationPlan, error) {
 	}
 
 	// Check for files to copy
-	filesToCheck := []string{"CLAUDE.md", "settings.json", "settings.local.json"}
+	filesToCheck := []string{"CLAUDE.md", "settings.json", "settings.local.json", config.DataCredentials.EntryName()}
 	for _, f := range filesToCheck {
 		if _, err := os.Stat(filepath.Join(m.paths.ClaudeDir, f)); err == nil {
 			plan.FilesToCopy = append(plan.FilesToCopy, f)
@@ -335,6 +335,9 @@ func (m *Migrator) createDefaultProfile(plan *MigrationPlan) error {
 			return fmt.Errorf("failed to copy %s: %w", f, err)
 		}
 	}
+	if err := m.applyCredentialsMode(defaultDir); err != nil {
+		return err
+	}
 
 	// Handle data directories — all shared
 	for _, dataType := range config.AllDataItemTypes() {
@@ -434,6 +437,7 @@ func (m *Migrator) moveRemainingItems(profileDir string) error {
 	knownNames["CLAUDE.md"] = true
 	knownNames["settings.json"] = true
 	knownNames["settings.local.json"] = true
+	knownNames[config.DataCredentials.EntryName()] = true
 	knownNames["profile.toml"] = true
 	knownNames["profile.yaml"] = true
 
@@ -502,6 +506,35 @@ func copyFile(src, dst string) error {
 	return err
 }
 
+// applyCredentialsMode puts a login copied into profileDir in the default
+// mode of config.DataCredentials: kept in the profile and readable by its
+// owner only when isolated, moved to the shared login (if there is none yet)
+// and linked when shared.
+func (m *Migrator) applyCredentialsMode(profileDir string) error {
+	name := config.DataCredentials.EntryName()
+	path := filepath.Join(profileDir, name)
+	if info, err := os.Lstat(path); err != nil || !info.Mode().IsRegular() {
+		return nil
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("failed to secure %s: %w", name, err)
+	}
+	if config.DefaultDataConfig()[config.DataCredentials] != config.ShareModeShared {
+		return nil
+	}
+	shared := m.paths.SharedDataDir(config.DataCredentials)
+	if _, err := os.Lstat(shared); err == nil {
+		return nil // a different shared login is never overwritten
+	}
+	if err := os.MkdirAll(m.paths.SharedDir, 0755); err != nil {
+		return err
+	}
+	if err := m.moveItem(path, shared); err != nil {
+		return err
+	}
+	return m.symMgr.Create(path, shared)
+}
+
 // copyRecursive copies a file or directory recursively
 func copyRecursive(src, dst string) error {
 	info, err := os.Stat(src)
@@ -242,10 +242,13 @@ func TestMigrator_Execute_HappyPath(t *testing.T) {
 	if err := os.WriteFile(filepath.Join(paths.ClaudeDir, "CLAUDE.md"), []byte("# Test"), 0644); err != nil {
 		t.Fatal(err)
 	}
+	if err := os.WriteFile(filepath.Join(paths.ClaudeDir, config.CredentialsFile), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
 
 	m := NewMigrator(paths)
 	plan := &MigrationPlan{
-		FilesToCopy: []string{"CLAUDE.md"},
+		FilesToCopy: []string{"CLAUDE.md", config.CredentialsFile},
 		HubItems:    make(map[config.HubItemType][]string),
 	}
 
@@ -275,6 +278,11 @@ func TestMigrator_Execute_HappyPath(t *testing.T) {
 		t.Error("CLAUDE.md should be copied to default profile")
 	}
 
+	// Verify credentials stay isolated in the default profile, owner-only
+	if info, err := os.Lstat(filepath.Join(defaultProfile, config.CredentialsFile)); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("credentials = %v, %v; want a 0600 file in the default profile", info, err)
+	}
+
 	// Verify manifest was created (toml or yaml)
 	tomlExists := false
 	yamlExists := false
@@ -3,7 +3,9 @@ package migration
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/samhoang/ccp/internal/config"
@@ -83,6 +85,17 @@ func (r *Resetter) copyProfileContents(profileDir, destDir string) error {
 
 // copyDirResolvingSymlinks copies a directory, resolving symlinks to actual content
 func (r *Resetter) copyDirResolvingSymlinks(srcDir, dstDir string, isRoot bool) error {
+	return copyResolvingSymlinks(srcDir, dstDir, "", func(rel string, _ os.FileInfo) bool {
+		// Skip profile manifest at root - it's ccp-specific
+		return isRoot && (rel == "profile.toml" || rel == "profile.yaml")
+	})
+}
+
+// copyResolvingSymlinks copies srcDir to dstDir, replacing symlinks with the
+// content they point to. Broken symlinks are dropped. skip is called with
+// each entry's path relative to the copy root (prefixed by rel) and its
+// Lstat info; entries it returns true for are not copied.
+func copyResolvingSymlinks(srcDir, dstDir, rel string, skip func(rel string, info os.FileInfo) bool) error {
 	srcInfo, err := os.Stat(srcDir)
 	if err != nil {
 		return err
@@ -100,11 +113,7 @@ func (r *Resetter) copyDirResolvingSymlinks(srcDir, dstDir string, isRoot bool)
 	for _, entry := range entries {
 		srcPath := filepath.Join(srcDir, entry.Name())
 		dstPath := filepath.Join(dstDir, entry.Name())
-
-		// Skip profile manifest at root - it's ccp-specific
-		if isRoot && (entry.Name() == "profile.toml" || entry.Name() == "profile.yaml") {
-			continue
-		}
+		entryRel := path.Join(rel, entry.Name())
 
 		// Check if it's a symlink
 		info, err := os.Lstat(srcPath)
@@ -112,6 +121,10 @@ func (r *Resetter) copyDirResolvingSymlinks(srcDir, dstDir string, isRoot bool)
 			return err
 		}
 
+		if skip != nil && skip(entryRel, info) {
+			continue
+		}
+
 		if info.Mode()&os.ModeSymlink != 0 {
 			// It's a symlink - resolve and copy the actual content
 			target, err := filepath.EvalSymlinks(srcPath)
@@ -137,7 +150,7 @@ func (r *Resetter) copyDirResolvingSymlinks(srcDir, dstDir string, isRoot bool)
 			}
 		} else if info.IsDir() {
 			// Regular directory - recurse, resolving any symlinks inside
-			if err := r.copyDirResolvingSymlinks(srcPath, dstPath, false); err != nil {
+			if err := copyResolvingSymlinks(srcPath, dstPath, entryRel, skip); err != nil {
 				return err
 			}
 		} else {
@@ -183,3 +196,40 @@ func (r *Resetter) rewriteSettingsHooks(oldProfileDir string) error {
 
 	return os.WriteFile(settingsPath, []byte(content), 0644)
 }
+
+// rewritePathPrefixes replaces each old path in a file with its new one and
+// reports whether the file changed. Old paths only match when followed by a
+// path separator or a closing quote, so /a/dev does not rewrite /a/dev2.
+// A missing file is not an error.
+func rewritePathPrefixes(file string, replacements map[string]string) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// Longest prefixes first, so nested paths win over their parents
+	olds := make([]string, 0, len(replacements))
+	for old := range replacements {
+		olds = append(olds, old)
+	}
+	sort.Slice(olds, func(i, j int) bool { return len(olds[i]) > len(olds[j]) })
+
+	content := string(data)
+	for _, old := range olds {
+		for _, end := range []string{"/", `"`} {
+			content = strings.ReplaceAll(content, old+end, replacements[old]+end)
+		}
+	}
+	if content == string(data) {
+		return false, nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(file, []byte(content), info.Mode().Perm())
+}
@@ -0,0 +1,134 @@
+// Package parallel runs independent tasks (one per source, repository, ...)
+// on a bounded worker pool with per-task timeouts and live progress.
+package parallel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultWorkers is the pool size used when Options.Workers is unset
+const DefaultWorkers = 8
+
+// Task is one unit of work. Run reports intermediate status through report
+// and returns a final status line, or an error.
+type Task struct {
+	Name string
+	Run  func(ctx context.Context, report func(status string)) (string, error)
+}
+
+// Options configure Run
+type Options struct {
+	Workers  int           // concurrent tasks; <= 0 means DefaultWorkers
+	Timeout  time.Duration // per-task deadline; 0 means none
+	Progress Progress      // optional live display
+}
+
+// Result is the outcome of one task
+type Result struct {
+	Name    string
+	Status  string
+	Err     error
+	Elapsed time.Duration
+}
+
+// Run executes tasks concurrently and returns their results in task order.
+// A failing task never stops the others; cancelling ctx stops tasks that
+// have not started.
+func Run(ctx context.Context, tasks []Task, opts Options) []Result {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+	progress := opts.Progress
+	if progress == nil {
+		progress = noProgress{}
+	}
+
+	names := make([]string, len(tasks))
+	for i, task := range tasks {
+		names[i] = task.Name
+	}
+	progress.Start(names)
+	defer progress.Stop()
+
+	results := make([]Result, len(tasks))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = runTask(ctx, i, tasks[i], opts.Timeout, progress)
+			}
+		}()
+	}
+	for i := range tasks {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+func runTask(ctx context.Context, i int, task Task, timeout time.Duration, progress Progress) Result {
+	result := Result{Name: task.Name}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		progress.Update(i, Failed, err.Error())
+		return result
+	}
+
+	taskCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		taskCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	progress.Update(i, Running, "")
+	status, err := task.Run(taskCtx, func(status string) { progress.Update(i, Running, status) })
+	result.Elapsed = time.Since(start)
+	if err != nil && errors.Is(taskCtx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s: %w", timeout, err)
+	}
+	result.Status, result.Err = status, err
+
+	if err != nil {
+		progress.Update(i, Failed, err.Error())
+	} else {
+		progress.Update(i, Done, status)
+	}
+	return result
+}
+
+// Errors joins the errors of failed results, each prefixed with the task
+// name; nil when every task succeeded
+func Errors(results []Result) error {
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Name, r.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FailedCount counts failed results
+func FailedCount(results []Result) int {
+	n := 0
+	for _, r := range results {
+		if r.Err != nil {
+			n++
+		}
+	}
+	return n
+}
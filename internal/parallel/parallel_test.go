@@ -0,0 +1,113 @@
+package parallel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunKeepsTaskOrder(t *testing.T) {
+	var tasks []Task
+	for i := 0; i < 5; i++ {
+		delay := time.Duration(5-i) * time.Millisecond
+		tasks = append(tasks, Task{
+			Name: fmt.Sprintf("task-%d", i),
+			Run: func(ctx context.Context, report func(string)) (string, error) {
+				time.Sleep(delay)
+				return fmt.Sprintf("done %d", i), nil
+			},
+		})
+	}
+
+	results := Run(context.Background(), tasks, Options{Workers: 5})
+	for i, r := range results {
+		if r.Name != fmt.Sprintf("task-%d", i) || r.Status != fmt.Sprintf("done %d", i) {
+			t.Errorf("result %d = %+v", i, r)
+		}
+	}
+	if err := Errors(results); err != nil {
+		t.Errorf("Errors() = %v, want nil", err)
+	}
+}
+
+func TestRunBoundsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	var tasks []Task
+	for i := 0; i < 10; i++ {
+		tasks = append(tasks, Task{
+			Name: fmt.Sprint(i),
+			Run: func(ctx context.Context, report func(string)) (string, error) {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				return "", nil
+			},
+		})
+	}
+
+	Run(context.Background(), tasks, Options{Workers: 3})
+	if got := peak.Load(); got > 3 || got < 1 {
+		t.Errorf("peak concurrency = %d, want 1..3", got)
+	}
+}
+
+func TestRunTimeoutAndErrors(t *testing.T) {
+	tasks := []Task{
+		{Name: "ok", Run: func(ctx context.Context, report func(string)) (string, error) {
+			return "fine", nil
+		}},
+		{Name: "slow", Run: func(ctx context.Context, report func(string)) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		}},
+		{Name: "broken", Run: func(ctx context.Context, report func(string)) (string, error) {
+			return "", errors.New("boom")
+		}},
+	}
+
+	results := Run(context.Background(), tasks, Options{Timeout: 20 * time.Millisecond})
+	if results[0].Err != nil {
+		t.Errorf("ok task failed: %v", results[0].Err)
+	}
+	if err := results[1].Err; err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("slow task error = %v, want timeout", err)
+	}
+	if FailedCount(results) != 2 {
+		t.Errorf("FailedCount() = %d, want 2", FailedCount(results))
+	}
+
+	err := Errors(results)
+	if err == nil || !strings.Contains(err.Error(), "broken: boom") || !strings.Contains(err.Error(), "slow: timed out") {
+		t.Errorf("Errors() = %v", err)
+	}
+}
+
+func TestDisplayWithoutTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	tasks := []Task{
+		{Name: "alpha", Run: func(ctx context.Context, report func(string)) (string, error) {
+			report("fetching")
+			return "updated", nil
+		}},
+		{Name: "b", Run: func(ctx context.Context, report func(string)) (string, error) {
+			return "", errors.New("network down\nmore detail")
+		}},
+	}
+
+	Run(context.Background(), tasks, Options{Workers: 1, Progress: NewDisplay(&buf)})
+	want := "  ✓ alpha  updated\n  ⚠ b      network down\n"
+	if got := buf.String(); got != want {
+		t.Errorf("display output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+package parallel
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// State is the lifecycle of a task in a progress display
+type State int
+
+const (
+	Pending State = iota
+	Running
+	Done
+	Failed
+)
+
+func (s State) icon() string {
+	switch s {
+	case Running:
+		return "…"
+	case Done:
+		return "✓"
+	case Failed:
+		return "⚠"
+	}
+	return "·"
+}
+
+// Progress receives task state changes from Run. Calls are serialized by
+// the implementation, not by Run.
+type Progress interface {
+	Start(names []string)
+	Update(index int, state State, status string)
+	Stop()
+}
+
+type noProgress struct{}
+
+func (noProgress) Start([]string)            {}
+func (noProgress) Update(int, State, string) {}
+func (noProgress) Stop()                     {}
+
+// NewDisplay returns a Progress writing to w: one live line per task,
+// redrawn in place, when w is a terminal; otherwise one line per finished
+// task, suitable for logs and CI.
+func NewDisplay(w io.Writer) Progress {
+	return &display{w: w, live: isTerminal(w)}
+}
+
+type display struct {
+	mu       sync.Mutex
+	w        io.Writer
+	live     bool
+	names    []string
+	states   []State
+	statuses []string
+	width    int
+	drawn    bool
+}
+
+func (d *display) Start(names []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.names = names
+	d.states = make([]State, len(names))
+	d.statuses = make([]string, len(names))
+	for _, name := range names {
+		d.width = max(d.width, len(name))
+	}
+	if d.live {
+		d.redraw()
+	}
+}
+
+func (d *display) Update(index int, state State, status string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.states[index] = state
+	d.statuses[index] = firstLine(status)
+	if d.live {
+		d.redraw()
+	} else if state == Done || state == Failed {
+		fmt.Fprintln(d.w, d.line(index))
+	}
+}
+
+func (d *display) Stop() {}
+
+// redraw moves the cursor back over the previous frame and rewrites it
+func (d *display) redraw() {
+	if d.drawn {
+		fmt.Fprintf(d.w, "\x1b[%dA", len(d.names))
+	}
+	for i := range d.names {
+		fmt.Fprintf(d.w, "\x1b[2K%s\n", d.line(i))
+	}
+	d.drawn = true
+}
+
+func (d *display) line(i int) string {
+	status := d.statuses[i]
+	if status == "" && d.states[i] == Pending {
+		status = "waiting"
+	}
+	return strings.TrimRight(fmt.Sprintf("  %s %-*s  %s", d.states[i].icon(), d.width, d.names[i], status), " ")
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i != -1 {
+		return s[:i]
+	}
+	return s
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -35,6 +35,26 @@ type Item struct {
 	ID       string
 	Label    string
 	Selected bool
+	// Description is shown as a faint column and matched by / search
+	Description string
+	// Group is the heading the item is listed under (e.g. its source)
+	Group string
+	// Badges are short annotations shown after the label
+	Badges []string
+	// PreviewPath is the file rendered in the preview pane (p)
+	PreviewPath string
+}
+
+// matchesQuery reports whether every word of the search query appears in the
+// item's label, ID or description (case-insensitive)
+func matchesQuery(item Item, query string) bool {
+	haystack := strings.ToLower(item.Label + "\n" + item.ID + "\n" + item.Description)
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(haystack, word) {
+			return false
+		}
+	}
+	return true
 }
 
 // Model is the Bubble Tea model for multi-select picker
@@ -49,6 +69,7 @@ type Model struct {
 	searchInput textinput.Model
 	searching   bool
 	filter      filterMode
+	display     display
 }
 
 // New creates a new picker model
@@ -66,11 +87,14 @@ func New(title string, items []Item) Model {
 	ti.CharLimit = 50
 	ti.Width = 40
 
+	items = groupItems(items)
+
 	return Model{
 		title:       title,
 		items:       items,
 		selected:    selected,
 		searchInput: ti,
+		display:     newDisplay(),
 	}
 }
 
@@ -105,12 +129,8 @@ func (m Model) getFilteredItems() []Item {
 		if m.filter == filterUnchecked && m.selected[item.ID] {
 			continue
 		}
-		if m.searchInput.Value() != "" {
-			query := strings.ToLower(m.searchInput.Value())
-			if !strings.Contains(strings.ToLower(item.Label), query) &&
-				!strings.Contains(strings.ToLower(item.ID), query) {
-				continue
-			}
+		if m.searchInput.Value() != "" && !matchesQuery(item, m.searchInput.Value()) {
+			continue
 		}
 		filtered = append(filtered, item)
 	}
@@ -130,25 +150,7 @@ func (m *Model) adjustScroll() {
 		m.cursor = 0
 	}
 
-	// Adjust offset to keep cursor visible
-	if m.cursor < m.offset {
-		m.offset = m.cursor
-	}
-	if m.cursor >= m.offset+maxVisibleItems {
-		m.offset = m.cursor - maxVisibleItems + 1
-	}
-
-	// Clamp offset
-	maxOffset := itemCount - maxVisibleItems
-	if maxOffset < 0 {
-		maxOffset = 0
-	}
-	if m.offset > maxOffset {
-		m.offset = maxOffset
-	}
-	if m.offset < 0 {
-		m.offset = 0
-	}
+	m.offset = scrollOffset(filteredItems, m.cursor, m.offset)
 }
 
 // Update implements tea.Model
@@ -156,6 +158,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.display.width = msg.Width
+
 	case tea.KeyMsg:
 		// Handle search mode
 		if m.searching {
@@ -234,6 +239,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cursor = 0
 			m.offset = 0
 
+		case key.Matches(msg, keys.Descriptions):
+			m.display.descriptions = !m.display.descriptions
+
+		case key.Matches(msg, keys.Preview):
+			m.display.preview = !m.display.preview
+
 		case key.Matches(msg, keys.Confirm):
 			m.done = true
 			return m, tea.Quit
@@ -306,14 +317,17 @@ func (m Model) View() string {
 
 		// Calculate visible range
 		start := m.offset
-		end := start + maxVisibleItems
-		if end > len(filteredItems) {
-			end = len(filteredItems)
-		}
+		end := visibleEnd(filteredItems, start)
 
 		// Render visible items
+		width := labelWidth(m.items)
 		for i := start; i < end; i++ {
 			item := filteredItems[i]
+			if header := groupHeader(filteredItems, i, start); header != "" {
+				b.WriteString(header)
+				b.WriteString("\n")
+			}
+
 			cursor := "  "
 			if i == m.cursor {
 				cursor = cursorStyle.Render("> ")
@@ -324,7 +338,8 @@ func (m Model) View() string {
 				checked = selectedStyle.Render("[x]")
 			}
 
-			b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, checked, item.Label))
+			b.WriteString(m.display.renderRow(cursor+checked+" ", item.Label, item, width))
+			b.WriteString("\n")
 		}
 
 		// Show scroll indicator at bottom
@@ -333,24 +348,33 @@ func (m Model) View() string {
 			b.WriteString(scrollIndicatorStyle.Render(fmt.Sprintf("  ↓ %d more below", remaining)))
 			b.WriteString("\n")
 		}
+
+		if m.display.preview && m.cursor < len(filteredItems) {
+			if preview := m.display.renderPreview(filteredItems[m.cursor]); preview != "" {
+				b.WriteString(preview)
+				b.WriteString("\n")
+			}
+		}
 	}
 
 	b.WriteString("\n")
-	b.WriteString(lipgloss.NewStyle().Faint(true).Render("↑/↓: navigate • space: toggle • a: all/none • f: filter • /: search • enter: confirm • q: quit"))
+	b.WriteString(lipgloss.NewStyle().Faint(true).Render("↑/↓: navigate • space: toggle • a: all/none • f: filter • /: search • " + displayHelp() + " • enter: confirm • q: quit"))
 
 	return b.String()
 }
 
 // KeyMap defines the key bindings
 type keyMap struct {
-	Up      key.Binding
-	Down    key.Binding
-	Toggle  key.Binding
-	All     key.Binding
-	Filter  key.Binding
-	Search  key.Binding
-	Confirm key.Binding
-	Quit    key.Binding
+	Up           key.Binding
+	Down         key.Binding
+	Toggle       key.Binding
+	All          key.Binding
+	Filter       key.Binding
+	Search       key.Binding
+	Descriptions key.Binding
+	Preview      key.Binding
+	Confirm      key.Binding
+	Quit         key.Binding
 }
 
 var keys = keyMap{
@@ -372,6 +396,12 @@ var keys = keyMap{
 	Search: key.NewBinding(
 		key.WithKeys("/"),
 	),
+	Descriptions: key.NewBinding(
+		key.WithKeys("d"),
+	),
+	Preview: key.NewBinding(
+		key.WithKeys("p"),
+	),
 	Confirm: key.NewBinding(
 		key.WithKeys("enter"),
 	),
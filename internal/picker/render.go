@@ -0,0 +1,210 @@
+package picker
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	defaultWidth    = 100 // assumed terminal width until a WindowSizeMsg arrives
+	maxLabelWidth   = 40  // label column is padded up to this width
+	maxPreviewLines = 14  // lines of the item file shown in the preview pane
+)
+
+var (
+	badgeStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	descriptionStyle = lipgloss.NewStyle().Faint(true)
+	groupStyle       = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("245"))
+	previewStyle     = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("240")).
+				Padding(0, 1)
+)
+
+// display holds the presentation toggles shared by all picker models
+type display struct {
+	width        int
+	descriptions bool // show the description column
+	preview      bool // show the preview pane for the item under the cursor
+	previews     map[string]string
+}
+
+func newDisplay() display {
+	return display{
+		width:        defaultWidth,
+		descriptions: true,
+		previews:     make(map[string]string),
+	}
+}
+
+// groupItems returns a copy of items stably ordered by Group so each group
+// renders as one block. Items without any group keep their order.
+func groupItems(items []Item) []Item {
+	grouped := make([]Item, len(items))
+	copy(grouped, items)
+	sort.SliceStable(grouped, func(i, j int) bool {
+		return grouped[i].Group < grouped[j].Group
+	})
+	return grouped
+}
+
+// labelWidth returns the width of the label column for a set of items
+func labelWidth(items []Item) int {
+	width := 0
+	for _, item := range items {
+		if w := lipgloss.Width(item.Label); w > width {
+			width = w
+		}
+	}
+	return min(width, maxLabelWidth)
+}
+
+// startsGroup reports whether items[i] needs a group header in a window
+// starting at start
+func startsGroup(items []Item, i, start int) bool {
+	group := items[i].Group
+	return group != "" && (i == start || items[i-1].Group != group)
+}
+
+// groupHeader returns the header to print before items[i], or "" when it
+// continues the previous item's group
+func groupHeader(items []Item, i, start int) string {
+	if !startsGroup(items, i, start) {
+		return ""
+	}
+	return groupStyle.Render("  " + items[i].Group)
+}
+
+// visibleEnd returns the end of the window of items beginning at start that
+// fits in maxVisibleItems lines, group headers included. The window always
+// holds at least one item.
+func visibleEnd(items []Item, start int) int {
+	lines := 0
+	end := start
+	for end < len(items) {
+		rows := 1
+		if startsGroup(items, end, start) {
+			rows++
+		}
+		if lines+rows > maxVisibleItems && end > start {
+			break
+		}
+		lines += rows
+		end++
+	}
+	return end
+}
+
+// scrollOffset returns the window start that keeps cursor visible, moving
+// offset as little as possible and keeping the last window full
+func scrollOffset(items []Item, cursor, offset int) int {
+	if cursor < offset {
+		offset = cursor
+	}
+	for offset < cursor && cursor >= visibleEnd(items, offset) {
+		offset++
+	}
+	for offset > 0 && visibleEnd(items, offset-1) == len(items) {
+		offset--
+	}
+	return max(offset, 0)
+}
+
+// renderRow renders one item line: prefix (cursor/checkbox), padded label,
+// badges and, when enabled, the description truncated to the terminal width
+func (d display) renderRow(prefix string, label string, item Item, width int) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	b.WriteString(label)
+
+	used := lipgloss.Width(prefix) + lipgloss.Width(item.Label)
+	if pad := width - lipgloss.Width(item.Label); pad > 0 && (len(item.Badges) > 0 || d.showDescription(item)) {
+		b.WriteString(strings.Repeat(" ", pad))
+		used += pad
+	}
+
+	for _, badge := range item.Badges {
+		b.WriteString(" ")
+		b.WriteString(badgeStyle.Render("[" + badge + "]"))
+		used += lipgloss.Width(badge) + 3
+	}
+
+	if d.showDescription(item) {
+		if room := d.width - used - 3; room > 10 {
+			b.WriteString("  ")
+			b.WriteString(descriptionStyle.Render(truncate(firstLine(item.Description), room)))
+		}
+	}
+
+	return b.String()
+}
+
+func (d display) showDescription(item Item) bool {
+	return d.descriptions && item.Description != ""
+}
+
+// renderPreview renders the preview pane for an item, reading its file once
+func (d display) renderPreview(item Item) string {
+	if item.PreviewPath == "" {
+		return ""
+	}
+	content, ok := d.previews[item.PreviewPath]
+	if !ok {
+		content = loadPreview(item.PreviewPath, d.width-4)
+		d.previews[item.PreviewPath] = content
+	}
+	return previewStyle.Width(min(d.width-2, 100)).Render(content)
+}
+
+// loadPreview returns the first lines of a file, truncated to width
+func loadPreview(path string, width int) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return descriptionStyle.Render("(no preview: " + err.Error() + ")")
+	}
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"), "\n")
+	truncated := len(lines) > maxPreviewLines
+	if truncated {
+		lines = lines[:maxPreviewLines]
+	}
+	for i, line := range lines {
+		lines[i] = truncate(strings.ReplaceAll(line, "\t", "  "), width)
+	}
+	if truncated {
+		lines = append(lines, descriptionStyle.Render("…"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// displayHelp returns the help text for the description and preview toggles
+func displayHelp() string {
+	return "d: descriptions • p: preview"
+}
+
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
+
+// truncate shortens s to at most width display cells, adding an ellipsis
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) > width {
+		runes = runes[:width]
+	}
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
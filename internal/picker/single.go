@@ -20,6 +20,7 @@ type SingleModel struct {
 	quitting    bool
 	searchInput textinput.Model
 	searching   bool
+	display     display
 }
 
 // NewSingle creates a new single-select picker model
@@ -30,6 +31,8 @@ func NewSingle(title string, items []Item) SingleModel {
 	ti.CharLimit = 50
 	ti.Width = 40
 
+	items = groupItems(items)
+
 	// Find initially selected item
 	cursor := 0
 	for i, item := range items {
@@ -44,6 +47,7 @@ func NewSingle(title string, items []Item) SingleModel {
 		items:       items,
 		cursor:      cursor,
 		searchInput: ti,
+		display:     newDisplay(),
 	}
 }
 
@@ -72,11 +76,9 @@ func (m SingleModel) getFilteredItems() []Item {
 		return m.items
 	}
 
-	query := strings.ToLower(m.searchInput.Value())
 	var filtered []Item
 	for _, item := range m.items {
-		if strings.Contains(strings.ToLower(item.Label), query) ||
-			strings.Contains(strings.ToLower(item.ID), query) {
+		if matchesQuery(item, m.searchInput.Value()) {
 			filtered = append(filtered, item)
 		}
 	}
@@ -96,25 +98,7 @@ func (m *SingleModel) adjustScroll() {
 		m.cursor = 0
 	}
 
-	// Adjust offset to keep cursor visible
-	if m.cursor < m.offset {
-		m.offset = m.cursor
-	}
-	if m.cursor >= m.offset+maxVisibleItems {
-		m.offset = m.cursor - maxVisibleItems + 1
-	}
-
-	// Clamp offset
-	maxOffset := itemCount - maxVisibleItems
-	if maxOffset < 0 {
-		maxOffset = 0
-	}
-	if m.offset > maxOffset {
-		m.offset = maxOffset
-	}
-	if m.offset < 0 {
-		m.offset = 0
-	}
+	m.offset = scrollOffset(filteredItems, m.cursor, m.offset)
 }
 
 // Update implements tea.Model
@@ -122,6 +106,9 @@ func (m SingleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.display.width = msg.Width
+
 	case tea.KeyMsg:
 		// Handle search mode
 		if m.searching {
@@ -175,6 +162,12 @@ func (m SingleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.offset = 0
 			}
 
+		case key.Matches(msg, singleKeys.Descriptions):
+			m.display.descriptions = !m.display.descriptions
+
+		case key.Matches(msg, singleKeys.Preview):
+			m.display.preview = !m.display.preview
+
 		case key.Matches(msg, singleKeys.Confirm):
 			m.done = true
 			return m, tea.Quit
@@ -232,20 +225,21 @@ func (m SingleModel) View() string {
 
 		// Calculate visible range
 		start := m.offset
-		end := start + maxVisibleItems
-		if end > len(filteredItems) {
-			end = len(filteredItems)
-		}
+		end := visibleEnd(filteredItems, start)
 
 		// Render visible items
+		width := labelWidth(m.items)
 		for i := start; i < end; i++ {
 			item := filteredItems[i]
+			if header := groupHeader(filteredItems, i, start); header != "" {
+				b.WriteString(header)
+				b.WriteString("\n")
+			}
+
 			if i == m.cursor {
-				b.WriteString(cursorStyle.Render("> "))
-				b.WriteString(selectedStyle.Render(item.Label))
+				b.WriteString(m.display.renderRow(cursorStyle.Render("> "), selectedStyle.Render(item.Label), item, width))
 			} else {
-				b.WriteString("  ")
-				b.WriteString(item.Label)
+				b.WriteString(m.display.renderRow("  ", item.Label, item, width))
 			}
 			b.WriteString("\n")
 		}
@@ -256,21 +250,30 @@ func (m SingleModel) View() string {
 			b.WriteString(scrollIndicatorStyle.Render(fmt.Sprintf("  ↓ %d more below", remaining)))
 			b.WriteString("\n")
 		}
+
+		if m.display.preview && m.cursor < len(filteredItems) {
+			if preview := m.display.renderPreview(filteredItems[m.cursor]); preview != "" {
+				b.WriteString(preview)
+				b.WriteString("\n")
+			}
+		}
 	}
 
 	b.WriteString("\n")
-	b.WriteString(lipgloss.NewStyle().Faint(true).Render("↑/↓: navigate • /: search • enter: select • q: quit"))
+	b.WriteString(lipgloss.NewStyle().Faint(true).Render("↑/↓: navigate • /: search • " + displayHelp() + " • enter: select • q: quit"))
 
 	return b.String()
 }
 
 // singleKeyMap defines the key bindings for single select
 type singleKeyMap struct {
-	Up      key.Binding
-	Down    key.Binding
-	Search  key.Binding
-	Confirm key.Binding
-	Quit    key.Binding
+	Up           key.Binding
+	Down         key.Binding
+	Search       key.Binding
+	Descriptions key.Binding
+	Preview      key.Binding
+	Confirm      key.Binding
+	Quit         key.Binding
 }
 
 var singleKeys = singleKeyMap{
@@ -283,6 +286,12 @@ var singleKeys = singleKeyMap{
 	Search: key.NewBinding(
 		key.WithKeys("/"),
 	),
+	Descriptions: key.NewBinding(
+		key.WithKeys("d"),
+	),
+	Preview: key.NewBinding(
+		key.WithKeys("p"),
+	),
 	Confirm: key.NewBinding(
 		key.WithKeys("enter"),
 	),
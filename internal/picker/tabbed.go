@@ -30,12 +30,14 @@ type TabbedModel struct {
 	searchInput textinput.Model
 	searching   bool
 	filter      filterMode
+	display     display
 }
 
 // NewTabbed creates a new tabbed picker model
 func NewTabbed(tabs []Tab) TabbedModel {
 	// Initialize selected maps for each tab
 	for i := range tabs {
+		tabs[i].Items = groupItems(tabs[i].Items)
 		tabs[i].selected = make(map[string]bool)
 		for _, item := range tabs[i].Items {
 			if item.Selected {
@@ -53,6 +55,7 @@ func NewTabbed(tabs []Tab) TabbedModel {
 	return TabbedModel{
 		tabs:        tabs,
 		searchInput: ti,
+		display:     newDisplay(),
 	}
 }
 
@@ -91,12 +94,8 @@ func (m TabbedModel) getFilteredItems(tab *Tab) []Item {
 		if m.filter == filterUnchecked && tab.selected[item.ID] {
 			continue
 		}
-		if m.searchInput.Value() != "" {
-			query := strings.ToLower(m.searchInput.Value())
-			if !strings.Contains(strings.ToLower(item.Label), query) &&
-				!strings.Contains(strings.ToLower(item.ID), query) {
-				continue
-			}
+		if m.searchInput.Value() != "" && !matchesQuery(item, m.searchInput.Value()) {
+			continue
 		}
 		filtered = append(filtered, item)
 	}
@@ -117,25 +116,7 @@ func (m *TabbedModel) adjustScroll() {
 		tab.cursor = 0
 	}
 
-	// Adjust offset to keep cursor visible
-	if tab.cursor < tab.offset {
-		tab.offset = tab.cursor
-	}
-	if tab.cursor >= tab.offset+maxVisibleItems {
-		tab.offset = tab.cursor - maxVisibleItems + 1
-	}
-
-	// Clamp offset
-	maxOffset := itemCount - maxVisibleItems
-	if maxOffset < 0 {
-		maxOffset = 0
-	}
-	if tab.offset > maxOffset {
-		tab.offset = maxOffset
-	}
-	if tab.offset < 0 {
-		tab.offset = 0
-	}
+	tab.offset = scrollOffset(filteredItems, tab.cursor, tab.offset)
 }
 
 // Update implements tea.Model
@@ -143,6 +124,9 @@ func (m TabbedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.display.width = msg.Width
+
 	case tea.KeyMsg:
 		// Handle search mode
 		if m.searching {
@@ -245,6 +229,12 @@ func (m TabbedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			tab.cursor = 0
 			tab.offset = 0
 
+		case key.Matches(msg, tabbedKeys.Descriptions):
+			m.display.descriptions = !m.display.descriptions
+
+		case key.Matches(msg, tabbedKeys.Preview):
+			m.display.preview = !m.display.preview
+
 		case key.Matches(msg, tabbedKeys.Confirm):
 			m.done = true
 			return m, tea.Quit
@@ -336,14 +326,17 @@ func (m TabbedModel) View() string {
 
 		// Calculate visible range
 		start := tab.offset
-		end := start + maxVisibleItems
-		if end > len(filteredItems) {
-			end = len(filteredItems)
-		}
+		end := visibleEnd(filteredItems, start)
 
 		// Render visible items
+		width := labelWidth(tab.Items)
 		for i := start; i < end; i++ {
 			item := filteredItems[i]
+			if header := groupHeader(filteredItems, i, start); header != "" {
+				b.WriteString(header)
+				b.WriteString("\n")
+			}
+
 			cursor := "  "
 			if i == tab.cursor {
 				cursor = cursorStyle.Render("> ")
@@ -354,7 +347,8 @@ func (m TabbedModel) View() string {
 				checked = selectedStyle.Render("[x]")
 			}
 
-			b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, checked, item.Label))
+			b.WriteString(m.display.renderRow(cursor+checked+" ", item.Label, item, width))
+			b.WriteString("\n")
 		}
 
 		// Show scroll indicator at bottom
@@ -363,10 +357,17 @@ func (m TabbedModel) View() string {
 			b.WriteString(scrollIndicatorStyle.Render(fmt.Sprintf("  ↓ %d more below", remaining)))
 			b.WriteString("\n")
 		}
+
+		if m.display.preview && tab.cursor < len(filteredItems) {
+			if preview := m.display.renderPreview(filteredItems[tab.cursor]); preview != "" {
+				b.WriteString(preview)
+				b.WriteString("\n")
+			}
+		}
 	}
 
 	b.WriteString("\n")
-	helpText := "←/→: switch tab • ↑/↓: navigate • space: toggle • a: all/none • f: filter • /: search • enter: confirm • q: quit"
+	helpText := "←/→: switch tab • ↑/↓: navigate • space: toggle • a: all/none • f: filter • /: search • " + displayHelp() + " • enter: confirm • q: quit"
 	b.WriteString(lipgloss.NewStyle().Faint(true).Render(helpText))
 
 	return b.String()
@@ -374,16 +375,18 @@ func (m TabbedModel) View() string {
 
 // TabbedKeyMap defines the key bindings for tabbed picker
 type tabbedKeyMap struct {
-	Up      key.Binding
-	Down    key.Binding
-	Left    key.Binding
-	Right   key.Binding
-	Toggle  key.Binding
-	All     key.Binding
-	Filter  key.Binding
-	Search  key.Binding
-	Confirm key.Binding
-	Quit    key.Binding
+	Up           key.Binding
+	Down         key.Binding
+	Left         key.Binding
+	Right        key.Binding
+	Toggle       key.Binding
+	All          key.Binding
+	Filter       key.Binding
+	Search       key.Binding
+	Descriptions key.Binding
+	Preview      key.Binding
+	Confirm      key.Binding
+	Quit         key.Binding
 }
 
 var tabbedKeys = tabbedKeyMap{
@@ -411,6 +414,12 @@ var tabbedKeys = tabbedKeyMap{
 	Search: key.NewBinding(
 		key.WithKeys("/"),
 	),
+	Descriptions: key.NewBinding(
+		key.WithKeys("d"),
+	),
+	Preview: key.NewBinding(
+		key.WithKeys("p"),
+	),
 	Confirm: key.NewBinding(
 		key.WithKeys("enter"),
 	),
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -185,3 +186,121 @@ func TestBundleDrift_MembersNotExtra(t *testing.T) {
 		t.Errorf("expected a missing bundle-member drift issue, got %+v", report.Issues)
 	}
 }
+
+func TestBundleSettings_MergeOrder(t *testing.T) {
+	paths, mgr := setupBundleTest(t)
+
+	tmplDir := filepath.Join(paths.HubDir, "settings-templates", "base")
+	mustWrite(t, filepath.Join(tmplDir, "settings.json"), `{"model": "sonnet", "permissions": {"allow": ["Read"]}}`)
+	mustWrite(t, filepath.Join(paths.BundleDir("impeccable"), hub.BundleSettingsFile),
+		`{"model": "opus", "env": {"IMPECCABLE": "1"}, "permissions": {"allow": ["Read", "Bash(npm run lint)"]}}`)
+
+	p, _ := mgr.Get("p")
+	p.Manifest.SettingsTemplate = "base"
+	if err := p.Manifest.Save(ManifestPath(p.Path)); err != nil {
+		t.Fatal(err)
+	}
+	mustWrite(t, filepath.Join(p.Path, SettingsFragmentFile), `{"model": "haiku"}`)
+
+	if err := mgr.LinkHubBundle("p", "impeccable"); err != nil {
+		t.Fatalf("LinkHubBundle: %v", err)
+	}
+
+	settings := readSettings(t, p.Path)
+	// profile fragment > bundle > template
+	if settings["model"] != "haiku" {
+		t.Errorf("model = %v, want profile fragment value haiku", settings["model"])
+	}
+	env, _ := settings["env"].(map[string]interface{})
+	if env["IMPECCABLE"] != "1" {
+		t.Errorf("bundle env not merged: %v", settings["env"])
+	}
+	allow := settings["permissions"].(map[string]interface{})["allow"].([]interface{})
+	if len(allow) != 2 || allow[0] != "Read" || allow[1] != "Bash(npm run lint)" {
+		t.Errorf("allow = %v, want template and bundle entries unioned", allow)
+	}
+}
+
+func TestUnlinkHubBundle_RemovesSettingsContributions(t *testing.T) {
+	paths, mgr := setupBundleTest(t)
+	mustWrite(t, filepath.Join(paths.BundleDir("impeccable"), hub.BundleSettingsFile),
+		`{"env": {"IMPECCABLE": "1"}, "permissions": {"allow": ["Bash(npm run lint)"]}}`)
+
+	if err := mgr.LinkHubBundle("p", "impeccable"); err != nil {
+		t.Fatalf("LinkHubBundle: %v", err)
+	}
+
+	// The user adds a permission by hand and captures it: the fragment ends
+	// up holding the whole allow list, bundle entry included, which is then
+	// the user's own
+	p, _ := mgr.Get("p")
+	settings := readSettings(t, p.Path)
+	settings["permissions"] = map[string]interface{}{"allow": []interface{}{"Bash(npm run lint)", "WebFetch"}}
+	writeJSONFile(filepath.Join(p.Path, "settings.json"), settings)
+	fragment, err := UpdateFragment(paths, p.Path, p.Manifest)
+	if err != nil {
+		t.Fatalf("UpdateFragment: %v", err)
+	}
+	if _, ok := fragment["env"]; ok {
+		t.Errorf("bundle env should not be captured into the fragment: %v", fragment)
+	}
+
+	if err := mgr.UnlinkHubBundle("p", "impeccable"); err != nil {
+		t.Fatalf("UnlinkHubBundle: %v", err)
+	}
+
+	settings = readSettings(t, p.Path)
+	if _, ok := settings["env"]; ok {
+		t.Errorf("env should be gone after unlink: %v", settings["env"])
+	}
+	allow := settings["permissions"].(map[string]interface{})["allow"].([]interface{})
+	if len(allow) != 2 || allow[1] != "WebFetch" {
+		t.Errorf("allow = %v, want the captured list kept", allow)
+	}
+	if _, ok := settings["hooks"]; ok {
+		t.Errorf("bundle hooks should be gone after unlink: %v", settings["hooks"])
+	}
+}
+
+func TestUnlinkHubBundle_KeepsOverlappingFragment(t *testing.T) {
+	paths, mgr := setupBundleTest(t)
+	mustWrite(t, filepath.Join(paths.BundleDir("impeccable"), hub.BundleSettingsFile),
+		`{"model": "opus", "permissions": {"allow": ["Bash(git:*)"]}}`)
+	p, _ := mgr.Get("p")
+	fragment := `{"model":"opus","permissions":{"allow":["Bash(git:*)","Read"]}}`
+	mustWrite(t, filepath.Join(p.Path, SettingsFragmentFile), fragment)
+
+	if err := mgr.LinkHubBundle("p", "impeccable"); err != nil {
+		t.Fatalf("LinkHubBundle: %v", err)
+	}
+	if err := mgr.UnlinkHubBundle("p", "impeccable"); err != nil {
+		t.Fatalf("UnlinkHubBundle: %v", err)
+	}
+
+	// Values the user set themselves survive even where they equal the bundle's
+	got, err := loadFragment(p.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want map[string]interface{}
+	json.Unmarshal([]byte(fragment), &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fragment = %v, want %v", got, want)
+	}
+	if settings := readSettings(t, p.Path); settings["model"] != "opus" {
+		t.Errorf("model = %v, want the fragment's opus", settings["model"])
+	}
+}
+
+func readSettings(t *testing.T, profileDir string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(profileDir, "settings.json"))
+	if err != nil {
+		t.Fatalf("read settings.json: %v", err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("parse settings.json: %v", err)
+	}
+	return settings
+}
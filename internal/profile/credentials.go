@@ -0,0 +1,243 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// credentialsPerm keeps credential files readable by their owner only
+const credentialsPerm = 0600
+
+// ClaudeStateFile is where Claude Code keeps the account details of a config
+// directory
+const ClaudeStateFile = ".claude.json"
+
+// Account describes the Claude login of a profile without its secrets
+type Account struct {
+	Mode         config.ShareMode // shared or isolated credentials
+	LoggedIn     bool             // a credentials file is present
+	Email        string           // account email, when Claude Code recorded it
+	Organization string
+	Subscription string // plan type from the credentials, e.g. "max"
+}
+
+// Identity returns a printable account name: the email, or the plan type
+// when no email is known
+func (a *Account) Identity() string {
+	switch {
+	case !a.LoggedIn:
+		return ""
+	case a.Email != "" && a.Organization != "":
+		return fmt.Sprintf("%s (%s)", a.Email, a.Organization)
+	case a.Email != "":
+		return a.Email
+	case a.Subscription != "":
+		return a.Subscription + " account"
+	}
+	return "logged in"
+}
+
+// CredentialsMode reports whether the profile in profileDir uses the shared
+// login. Credentials are isolated unless they link to the shared file.
+func (m *Manager) CredentialsMode(profileDir string) config.ShareMode {
+	linkPath := filepath.Join(profileDir, config.DataCredentials.EntryName())
+	if ok, err := m.symMgr.Validate(linkPath, m.paths.SharedDataDir(config.DataCredentials)); err == nil && ok {
+		return config.ShareModeShared
+	}
+	return config.ShareModeIsolated
+}
+
+// GetAccount reads the login of profile name. Only the account email,
+// organization and plan type are read; tokens are never kept.
+func (m *Manager) GetAccount(name string) (*Account, error) {
+	profileDir := m.paths.ProfileDir(name)
+	if !m.Exists(name) {
+		return nil, fmt.Errorf("profile not found: %s", name)
+	}
+
+	account := &Account{Mode: m.CredentialsMode(profileDir)}
+	data, err := os.ReadFile(filepath.Join(profileDir, config.DataCredentials.EntryName()))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return account, nil
+		}
+		return nil, err
+	}
+	account.LoggedIn = true
+
+	var creds struct {
+		ClaudeAiOauth struct {
+			SubscriptionType string `json:"subscriptionType"`
+		} `json:"claudeAiOauth"`
+	}
+	if json.Unmarshal(data, &creds) == nil {
+		account.Subscription = creds.ClaudeAiOauth.SubscriptionType
+	}
+
+	if data, err := os.ReadFile(filepath.Join(profileDir, ClaudeStateFile)); err == nil {
+		var state struct {
+			OAuthAccount struct {
+				EmailAddress     string `json:"emailAddress"`
+				OrganizationName string `json:"organizationName"`
+			} `json:"oauthAccount"`
+		}
+		if json.Unmarshal(data, &state) == nil {
+			account.Email = state.OAuthAccount.EmailAddress
+			account.Organization = state.OAuthAccount.OrganizationName
+		}
+	}
+	return account, nil
+}
+
+// ShareCredentials switches profile name to the shared login. A login of the
+// profile's own becomes the shared one when there is none yet; a different
+// shared login is never overwritten.
+func (m *Manager) ShareCredentials(name string) error {
+	profileDir := m.paths.ProfileDir(name)
+	if !m.Exists(name) {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+	if m.CredentialsMode(profileDir) == config.ShareModeShared {
+		return nil
+	}
+
+	own := filepath.Join(profileDir, config.DataCredentials.EntryName())
+	shared := m.paths.SharedDataDir(config.DataCredentials)
+	if isLink, _ := m.symMgr.IsSymlink(own); isLink {
+		return fmt.Errorf("%s links elsewhere; not managed by ccp", own)
+	}
+	ownData, err := readCredentials(own)
+	if err != nil {
+		return err
+	}
+	sharedData, err := readCredentials(shared)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case ownData == nil && sharedData == nil:
+		return fmt.Errorf("no credentials to share: log in with profile %s first", name)
+	case ownData != nil && sharedData == nil:
+		if err := os.MkdirAll(m.paths.SharedDir, 0755); err != nil {
+			return err
+		}
+		if err := moveCredentials(own, shared); err != nil {
+			return err
+		}
+	case ownData != nil:
+		if !bytes.Equal(ownData, sharedData) {
+			return fmt.Errorf("profile %s is logged in separately from the shared login: log out of it or remove %s first", name, own)
+		}
+		if err := os.Remove(own); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Chmod(shared, credentialsPerm); err != nil {
+		return err
+	}
+	return m.symMgr.Create(own, shared)
+}
+
+// IsolateCredentials gives profile name a login of its own, starting from a
+// copy of the shared one. The shared file is moved instead when no other
+// profile uses it.
+func (m *Manager) IsolateCredentials(name string) error {
+	profileDir := m.paths.ProfileDir(name)
+	if !m.Exists(name) {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+	if m.CredentialsMode(profileDir) != config.ShareModeShared {
+		return nil
+	}
+
+	own := filepath.Join(profileDir, config.DataCredentials.EntryName())
+	shared := m.paths.SharedDataDir(config.DataCredentials)
+	data, err := readCredentials(shared)
+	if err != nil {
+		return err
+	}
+	if err := m.symMgr.Remove(own); err != nil {
+		return err
+	}
+	if data == nil {
+		return nil // the link was broken; the profile is simply logged out
+	}
+
+	users, err := m.sharedCredentialsUsers()
+	if err != nil {
+		return err
+	}
+	if len(users) == 0 {
+		return moveCredentials(shared, own)
+	}
+	return writeCredentials(own, data)
+}
+
+// sharedCredentialsUsers lists the profiles linked to the shared login
+func (m *Manager) sharedCredentialsUsers() ([]string, error) {
+	profiles, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+	var users []string
+	for _, p := range profiles {
+		if m.CredentialsMode(p.Path) == config.ShareModeShared {
+			users = append(users, p.Name)
+		}
+	}
+	return users, nil
+}
+
+// readCredentials returns the content of a credentials file, or nil if there
+// is none
+func readCredentials(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// writeCredentials writes data to path through a temporary file, so a
+// failure never leaves half a login behind
+func writeCredentials(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".credentials-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(credentialsPerm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// moveCredentials moves a credentials file, keeping it private to its owner
+func moveCredentials(src, dst string) error {
+	if err := os.Rename(src, dst); err != nil {
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		if err := writeCredentials(dst, data); err != nil {
+			return err
+		}
+		return os.Remove(src)
+	}
+	return os.Chmod(dst, credentialsPerm)
+}
@@ -0,0 +1,100 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+func TestCredentialsShareAndIsolate(t *testing.T) {
+	testDir := t.TempDir()
+	paths := &config.Paths{
+		CcpDir:      testDir,
+		HubDir:      filepath.Join(testDir, "hub"),
+		ProfilesDir: filepath.Join(testDir, "profiles"),
+		SharedDir:   filepath.Join(testDir, "profiles", "shared"),
+		StoreDir:    filepath.Join(testDir, "store"),
+	}
+	mgr := NewManager(paths)
+	work, err := mgr.Create("work", NewManifest("work", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	personal, err := mgr.Create("personal", NewManifest("personal", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// New profiles are isolated and logged out
+	if mode := mgr.CredentialsMode(work.Path); mode != config.ShareModeIsolated {
+		t.Errorf("new profile mode = %s, want isolated", mode)
+	}
+	if err := mgr.ShareCredentials("work"); err == nil {
+		t.Error("ShareCredentials() without any login should fail")
+	}
+
+	workCreds := `{"claudeAiOauth":{"accessToken":"sk-secret","subscriptionType":"team"}}`
+	mustWrite(t, filepath.Join(work.Path, config.CredentialsFile), workCreds)
+	mustWrite(t, filepath.Join(work.Path, ClaudeStateFile), `{"oauthAccount":{"emailAddress":"sam@work.example","organizationName":"Acme"}}`)
+	account, err := mgr.GetAccount("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := account.Identity(); got != "sam@work.example (Acme)" || account.Subscription != "team" {
+		t.Errorf("account = %+v, identity %q", account, got)
+	}
+
+	// The profile's own login becomes the shared one, private to the owner
+	if err := mgr.ShareCredentials("work"); err != nil {
+		t.Fatalf("ShareCredentials(work) error = %v", err)
+	}
+	if mode := mgr.CredentialsMode(work.Path); mode != config.ShareModeShared {
+		t.Errorf("mode = %s, want shared", mode)
+	}
+	info, err := os.Stat(paths.SharedCredentialsPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("shared credentials perm = %o, want 600", perm)
+	}
+	if err := mgr.ShareCredentials("personal"); err != nil {
+		t.Fatalf("ShareCredentials(personal) error = %v", err)
+	}
+
+	// Isolating copies the shared login while others still use it
+	if err := mgr.IsolateCredentials("personal"); err != nil {
+		t.Fatalf("IsolateCredentials(personal) error = %v", err)
+	}
+	own := filepath.Join(personal.Path, config.CredentialsFile)
+	if isLink, _ := mgr.symMgr.IsSymlink(own); isLink {
+		t.Error("isolated credentials should be a file")
+	}
+	if info, err := os.Stat(own); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("isolated credentials = %v, %v; want a 0600 file", info, err)
+	}
+	if _, err := os.Stat(paths.SharedCredentialsPath()); err != nil {
+		t.Error("shared credentials still used by work should be kept")
+	}
+
+	// A different login is never overwritten by sharing
+	mustWrite(t, own, `{"claudeAiOauth":{"accessToken":"sk-other"}}`)
+	if err := mgr.ShareCredentials("personal"); err == nil || strings.Contains(err.Error(), "sk-") {
+		t.Errorf("ShareCredentials() with a different login: err = %v", err)
+	}
+
+	// The last user takes the shared file with it
+	if err := mgr.IsolateCredentials("work"); err != nil {
+		t.Fatalf("IsolateCredentials(work) error = %v", err)
+	}
+	if _, err := os.Stat(paths.SharedCredentialsPath()); !os.IsNotExist(err) {
+		t.Error("unused shared credentials should be moved into the profile")
+	}
+	data, _ := os.ReadFile(filepath.Join(work.Path, config.CredentialsFile))
+	if string(data) != workCreds {
+		t.Errorf("work credentials = %q", data)
+	}
+}
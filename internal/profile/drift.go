@@ -18,6 +18,8 @@ const (
 	DriftBroken     DriftType = "broken"      // Symlink exists but is broken
 	DriftMismatched DriftType = "mismatched"  // Symlink points to wrong target
 	DriftHubMissing DriftType = "hub_missing" // In manifest but hub item doesn't exist
+	DriftStale      DriftType = "stale"       // Copy differs from its hub item (copy mode)
+	DriftMemory     DriftType = "memory"      // Generated region of CLAUDE.md is out of date or edited
 )
 
 // DriftItem represents a single drift issue
@@ -101,6 +103,20 @@ func (d *Detector) Detect(profile *Profile) (*DriftReport, error) {
 	}
 	report.Issues = append(report.Issues, bundleIssues...)
 
+	// Memory last, so fixing it renders from the restored snippet links
+	state, err := CheckMemory(d.paths, profile.Path, profile.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	if state != MemoryCurrent {
+		report.Issues = append(report.Issues, DriftItem{
+			Type:     DriftMemory,
+			ItemType: config.HubMemory,
+			ItemName: MemoryFile,
+			Actual:   state.String(),
+		})
+	}
+
 	return report, nil
 }
 
@@ -148,47 +164,45 @@ func (d *Detector) detectItemTypeDrift(profile *Profile, itemType config.HubItem
 			continue
 		}
 
-		info, err := d.symMgr.Info(itemPath)
+		state, detail, err := CheckItem(profile.Path, profile.Manifest, itemPath, hubPath)
 		if err != nil {
 			return nil, err
 		}
 
-		if !info.Exists {
+		switch state {
+		case ItemMissing:
 			issues = append(issues, DriftItem{
 				Type:     DriftMissing,
 				ItemType: itemType,
 				ItemName: name,
 			})
-			continue
-		}
-
-		if info.IsSymlink {
-			// Check if symlink is broken
-			if info.IsBroken {
-				issues = append(issues, DriftItem{
-					Type:     DriftBroken,
-					ItemType: itemType,
-					ItemName: name,
-					Actual:   info.Target,
-				})
-				continue
-			}
-
-			// Check if symlink points to correct target
-			expectedTarget := d.paths.HubItemPath(itemType, name)
-			valid, err := d.symMgr.Validate(itemPath, expectedTarget)
-			if err != nil {
-				return nil, err
-			}
-			if !valid {
-				issues = append(issues, DriftItem{
-					Type:     DriftMismatched,
-					ItemType: itemType,
-					ItemName: name,
-					Expected: expectedTarget,
-					Actual:   info.Target,
-				})
+		case ItemBroken:
+			issues = append(issues, DriftItem{
+				Type:     DriftBroken,
+				ItemType: itemType,
+				ItemName: name,
+				Actual:   detail,
+			})
+		case ItemMismatched:
+			expected := hubPath
+			if profile.Manifest.CopyMode() {
+				expected = "copy of " + hubPath
 			}
+			issues = append(issues, DriftItem{
+				Type:     DriftMismatched,
+				ItemType: itemType,
+				ItemName: name,
+				Expected: expected,
+				Actual:   detail,
+			})
+		case ItemStale:
+			// Copies are compared by content hash instead of link target
+			issues = append(issues, DriftItem{
+				Type:     DriftStale,
+				ItemType: itemType,
+				ItemName: name,
+				Actual:   detail,
+			})
 		}
 	}
 
@@ -355,14 +369,26 @@ func (d *Detector) fixIssue(profile *Profile, issue DriftItem, dryRun bool) (str
 	hubPath := d.paths.HubItemPath(issue.ItemType, issue.ItemName)
 
 	switch issue.Type {
+	case DriftMemory:
+		action := "render memory snippets into " + filepath.Join(profile.Path, MemoryFile) + " (" + issue.Actual + ")"
+		if !dryRun {
+			if _, err := RenderMemory(d.paths, profile.Path, profile.Manifest); err != nil {
+				return "", err
+			}
+		}
+		return action, nil
+
 	case DriftMissing:
 		action := "create symlink: " + itemPath + " -> " + hubPath
+		if profile.Manifest.CopyMode() {
+			action = "copy: " + hubPath + " -> " + itemPath
+		}
 		if !dryRun {
 			// Ensure hub item exists
 			if _, err := os.Stat(hubPath); err != nil {
 				return "", err
 			}
-			if err := d.symMgr.Create(itemPath, hubPath); err != nil {
+			if err := MaterializeItem(profile.Path, profile.Manifest, itemPath, hubPath); err != nil {
 				return "", err
 			}
 		}
@@ -371,25 +397,36 @@ func (d *Detector) fixIssue(profile *Profile, issue DriftItem, dryRun bool) (str
 	case DriftExtra:
 		action := "remove: " + itemPath
 		if !dryRun {
+			if _, err := RemoveItem(profile.Path, itemPath); err != nil {
+				return "", err
+			}
 			if err := os.RemoveAll(itemPath); err != nil {
 				return "", err
 			}
 		}
 		return action, nil
 
-	case DriftBroken, DriftMismatched:
+	case DriftBroken, DriftMismatched, DriftStale:
 		action := "recreate symlink: " + itemPath + " -> " + hubPath
+		switch {
+		case issue.Type == DriftStale:
+			action = "refresh copy (" + issue.Actual + "): " + hubPath + " -> " + itemPath
+		case profile.Manifest.CopyMode():
+			action = "replace with copy: " + hubPath + " -> " + itemPath
+		}
 		if !dryRun {
-			// Remove existing
-			if err := os.Remove(itemPath); err != nil && !os.IsNotExist(err) {
-				return "", err
-			}
 			// Check hub item exists
 			if _, err := os.Stat(hubPath); err != nil {
 				return "", err
 			}
-			// Create new symlink
-			if err := d.symMgr.Create(itemPath, hubPath); err != nil {
+			if issue.Type == DriftStale {
+				// Also replaces a copy ccp did not record; the manifest owns this path
+				if err := os.RemoveAll(itemPath); err != nil {
+					return "", err
+				}
+			}
+			// MaterializeItem replaces the symlink or recorded copy in place
+			if err := MaterializeItem(profile.Path, profile.Manifest, itemPath, hubPath); err != nil {
 				return "", err
 			}
 		}
@@ -0,0 +1,54 @@
+package profile
+
+import (
+	"regexp"
+	"sort"
+)
+
+// envRef matches ${env:NAME} references in profile env values
+var envRef = regexp.MustCompile(`\$\{env:([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// reservedEnv are variables ccp sets itself; a profile's env cannot override them
+var reservedEnv = map[string]bool{
+	"CLAUDE_CONFIG_DIR":                            true,
+	"CLAUDE_CODE_ADDITIONAL_DIRECTORIES_CLAUDE_MD": true,
+}
+
+// EnvVars returns the profile's env table without the variables ccp sets
+// itself. Values are as written; see ExpandEnvRefs.
+func (m *Manifest) EnvVars() map[string]string {
+	vars := make(map[string]string, len(m.Env))
+	for name, value := range m.Env {
+		if !reservedEnv[name] {
+			vars[name] = value
+		}
+	}
+	return vars
+}
+
+// EnvNames returns the names of EnvVars in sorted order
+func (m *Manifest) EnvNames() []string {
+	var names []string
+	for name := range m.EnvVars() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExpandEnvRefs rewrites an env value piecewise: text between references
+// goes through literal, each ${env:NAME} is replaced by ref(NAME). Use
+// os.Getenv as ref to resolve values, or a reference in the syntax of the
+// file being written to defer them.
+func ExpandEnvRefs(value string, literal, ref func(string) string) string {
+	if literal == nil {
+		literal = func(s string) string { return s }
+	}
+	var out string
+	last := 0
+	for _, m := range envRef.FindAllStringSubmatchIndex(value, -1) {
+		out += literal(value[last:m[0]]) + ref(value[m[2]:m[3]])
+		last = m[1]
+	}
+	return out + literal(value[last:])
+}
@@ -0,0 +1,64 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandEnvRefs(t *testing.T) {
+	lookup := func(name string) string {
+		return map[string]string{"HOME": "/home/me", "REGION": "eu"}[name]
+	}
+	tests := []struct {
+		value, want string
+	}{
+		{"plain", "plain"},
+		{"${env:HOME}/.aws", "/home/me/.aws"},
+		{"bedrock-${env:REGION}-${env:UNSET}", "bedrock-eu-"},
+		{"${HOME} and ${env:bad-name}", "${HOME} and ${env:bad-name}"},
+	}
+	for _, tt := range tests {
+		if got := ExpandEnvRefs(tt.value, nil, lookup); got != tt.want {
+			t.Errorf("ExpandEnvRefs(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	// Literal text and references can be written for a target syntax
+	got := ExpandEnvRefs(`"${env:HOME}"`, strings.ToUpper, func(name string) string { return "{{env." + name + "}}" })
+	if got != `"{{env.HOME}}"` {
+		t.Errorf("ExpandEnvRefs() with literal = %q", got)
+	}
+}
+
+func TestManifestEnv(t *testing.T) {
+	dir := t.TempDir()
+	m := NewManifest("work", "")
+	m.Env = map[string]string{
+		"CLAUDE_CODE_USE_BEDROCK": "1",
+		"AWS_PROFILE":             "work-${env:USER}",
+		"CLAUDE_CONFIG_DIR":       "/elsewhere",
+		"CLAUDE_CODE_ADDITIONAL_DIRECTORIES_CLAUDE_MD": "0",
+	}
+	if err := m.SaveTOML(dir); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "profile.toml"))
+	if !strings.Contains(string(data), "[env]") {
+		t.Errorf("profile.toml has no [env] table:\n%s", data)
+	}
+
+	loaded, err := LoadManifest(filepath.Join(dir, "profile.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Env, m.Env) {
+		t.Errorf("Env = %v, want %v", loaded.Env, m.Env)
+	}
+	// ccp sets CLAUDE_CONFIG_DIR and CLAUDE_CODE_ADDITIONAL_DIRECTORIES_CLAUDE_MD itself
+	if names := loaded.EnvNames(); !reflect.DeepEqual(names, []string{"AWS_PROFILE", "CLAUDE_CODE_USE_BEDROCK"}) {
+		t.Errorf("EnvNames() = %v", names)
+	}
+}
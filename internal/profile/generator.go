@@ -14,20 +14,33 @@ import (
 const SettingsFragmentFile = "settings-fragment.json"
 
 // GenerateSettings creates a complete settings map from the manifest.
-// Pipeline: base template → deep merge fragment → overlay hooks.
+// Pipeline: base template → bundle settings (in link order) → statusline →
+// deep merge fragment → deep merge machine.toml overrides → overlay hooks.
 func GenerateSettings(manifest *Manifest, paths *config.Paths, profileDir string) (map[string]interface{}, error) {
-	settings := make(map[string]interface{})
+	return generateSettings(manifest, paths, profileDir, nil)
+}
 
-	// Load settings template (base)
-	if manifest.SettingsTemplate != "" {
-		tmplMgr := hub.NewTemplateManager(paths.HubDir)
-		tmpl, err := tmplMgr.Load(manifest.SettingsTemplate)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load template %s: %w", manifest.SettingsTemplate, err)
-		}
-		for key, value := range tmpl.Settings {
-			settings[key] = value
-		}
+// SettingsProjection describes pending changes for ProjectSettings
+type SettingsProjection struct {
+	// Templates replaces the saved settings of the named templates
+	Templates map[string]map[string]interface{}
+}
+
+// ProjectSettings is GenerateSettings for changes that are not applied yet:
+// hooks the manifest lists but the profile has not linked are read from the
+// hub, and proj may substitute edited templates. It is used to preview
+// 'profile edit' and 'template edit'.
+func ProjectSettings(manifest *Manifest, paths *config.Paths, profileDir string, proj *SettingsProjection) (map[string]interface{}, error) {
+	if proj == nil {
+		proj = &SettingsProjection{}
+	}
+	return generateSettings(manifest, paths, profileDir, proj)
+}
+
+func generateSettings(manifest *Manifest, paths *config.Paths, profileDir string, proj *SettingsProjection) (map[string]interface{}, error) {
+	settings, err := baseSettings(manifest, paths, profileDir, proj)
+	if err != nil {
+		return nil, err
 	}
 
 	// Load and merge per-profile fragment
@@ -39,8 +52,17 @@ func GenerateSettings(manifest *Manifest, paths *config.Paths, profileDir string
 		settings = deepMerge(settings, fragment)
 	}
 
+	// Host-specific overrides have the last word
+	machine, err := machineSettings(paths, profileDir)
+	if err != nil {
+		return nil, err
+	}
+	if machine != nil {
+		settings = deepMerge(settings, machine)
+	}
+
 	// Collect hub hooks and merge with any existing hooks from fragment
-	hubHooks, err := GenerateSettingsHooks(paths, profileDir, manifest)
+	hubHooks, err := generateSettingsHooks(paths, profileDir, manifest, proj != nil)
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +73,91 @@ func GenerateSettings(manifest *Manifest, paths *config.Paths, profileDir string
 	return settings, nil
 }
 
+// baseSettings is what a profile's settings are before its own fragment:
+// the settings template overlaid with the settings of each linked bundle and
+// the linked statusline. Bundle settings merge additively (arrays are
+// unioned) so that, e.g., the permission allows required by several bundles
+// all apply; the profile fragment merged afterwards still has the last word.
+func baseSettings(manifest *Manifest, paths *config.Paths, profileDir string, proj *SettingsProjection) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+
+	// Load settings template (base)
+	if pending, ok := proj.template(manifest.SettingsTemplate); ok {
+		for key, value := range pending {
+			settings[key] = value
+		}
+	} else if manifest.SettingsTemplate != "" {
+		tmplMgr := hub.NewTemplateManager(paths.HubDir)
+		tmpl, err := tmplMgr.Load(manifest.SettingsTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load template %s: %w", manifest.SettingsTemplate, err)
+		}
+		for key, value := range tmpl.Settings {
+			settings[key] = value
+		}
+	}
+
+	for _, bundleName := range manifest.Hub.Bundles {
+		bundleSettings, err := hub.LoadBundleSettings(paths.BundlesDir(), bundleName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load settings of bundle %s: %w", bundleName, err)
+		}
+		if bundleSettings != nil {
+			settings = additiveMerge(settings, bundleSettings)
+		}
+	}
+
+	statusLine, err := statusLineSettings(manifest, paths, profileDir)
+	if err != nil {
+		return nil, err
+	}
+	if statusLine != nil {
+		settings["statusLine"] = statusLine
+	}
+
+	return settings, nil
+}
+
+// statusLineSettings returns the statusLine setting for the last linked
+// statusline, with ${CLAUDE_PLUGIN_ROOT} resolved to its path in the profile
+// the way hook commands are. Statuslines not linked yet are read from the
+// hub; missing ones are skipped and left to drift detection.
+func statusLineSettings(manifest *Manifest, paths *config.Paths, profileDir string) (map[string]interface{}, error) {
+	for i := len(manifest.Hub.Statuslines) - 1; i >= 0; i-- {
+		name := manifest.Hub.Statuslines[i]
+		itemPath := filepath.Join(profileDir, string(config.HubStatuslines), name)
+		sl, err := hub.GetStatusLine(itemPath)
+		if os.IsNotExist(err) {
+			sl, err = hub.GetStatusLine(paths.HubItemPath(config.HubStatuslines, name))
+		}
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("statuslines/%s: %w", name, err)
+		}
+
+		statusLine := map[string]interface{}{
+			"type":    sl.Type,
+			"command": resolvePluginRootPath(sl.Command, itemPath),
+		}
+		if sl.Padding != nil {
+			statusLine["padding"] = float64(*sl.Padding)
+		}
+		return statusLine, nil
+	}
+	return nil, nil
+}
+
+// template returns the pending settings of template name, if any
+func (p *SettingsProjection) template(name string) (map[string]interface{}, bool) {
+	if p == nil || name == "" {
+		return nil, false
+	}
+	settings, ok := p.Templates[name]
+	return settings, ok
+}
+
 // FragmentExists returns true if a settings fragment file exists in the profile directory.
 func FragmentExists(profileDir string) bool {
 	_, err := os.Stat(filepath.Join(profileDir, SettingsFragmentFile))
@@ -167,17 +274,77 @@ func computeFragment(paths *config.Paths, profileDir string, manifest *Manifest)
 
 	delete(current, "hooks")
 
-	base := make(map[string]interface{})
-	if manifest.SettingsTemplate != "" {
-		tmplMgr := hub.NewTemplateManager(paths.HubDir)
-		tmpl, err := tmplMgr.Load(manifest.SettingsTemplate)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load template %s: %w", manifest.SettingsTemplate, err)
+	// Bundle settings are part of the base so they are not captured into
+	// the fragment, where they would outlive the bundle being unlinked
+	base, err := baseSettings(manifest, paths, profileDir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Neither are machine.toml overrides, which must not leak into the synced
+	// fragment; what they hide of the old fragment is kept as it was
+	machine, err := machineSettings(paths, profileDir)
+	if err != nil {
+		return nil, err
+	}
+	if machine == nil {
+		return DiffSettings(base, current), nil
+	}
+	fragment := DiffSettings(deepMerge(base, machine), current)
+	old, err := loadFragment(profileDir)
+	if err != nil {
+		return nil, err
+	}
+	if hidden := shadowedSettings(old, machine); len(hidden) > 0 {
+		fragment = deepMerge(fragment, hidden)
+	}
+	return fragment, nil
+}
+
+// machineSettings returns the machine.toml overrides for the profile in
+// profileDir merged into one map, or nil if there are none
+func machineSettings(paths *config.Paths, profileDir string) (map[string]interface{}, error) {
+	if paths.CcpDir == "" {
+		return nil, nil
+	}
+	machine, err := config.LoadMachineConfig(paths.CcpDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", config.MachineFile, err)
+	}
+	var merged map[string]interface{}
+	for _, overlay := range machine.SettingsOverlays(filepath.Base(profileDir)) {
+		if merged == nil {
+			merged = make(map[string]interface{})
 		}
-		base = tmpl.Settings
+		merged = deepMerge(merged, overlay)
+	}
+	if merged == nil {
+		return nil, nil
 	}
+	// TOML integers would never equal the float64 of a parsed settings.json
+	normalized, _ := normalizeJSON(merged).(map[string]interface{})
+	return normalized, nil
+}
 
-	return DiffSettings(base, current), nil
+// shadowedSettings returns the parts of settings that overlay replaces
+func shadowedSettings(settings, overlay map[string]interface{}) map[string]interface{} {
+	hidden := make(map[string]interface{})
+	for k, ov := range overlay {
+		sv, ok := settings[k]
+		if !ok {
+			continue
+		}
+		om, omOK := ov.(map[string]interface{})
+		sm, smOK := sv.(map[string]interface{})
+		if omOK && smOK {
+			if sub := shadowedSettings(sm, om); len(sub) > 0 {
+				hidden[k] = sub
+			}
+			continue
+		}
+		hidden[k] = sv
+	}
+	return hidden
 }
 
 // deepMerge merges src into dst recursively.
@@ -203,3 +370,89 @@ func deepMerge(dst, src map[string]interface{}) map[string]interface{} {
 	}
 	return result
 }
+
+// additiveMerge merges src into dst recursively. Objects merge recursively,
+// arrays are unioned (dst order first, duplicates dropped) and scalars in src
+// replace dst.
+func additiveMerge(dst, src map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(dst))
+	for k, v := range dst {
+		result[k] = v
+	}
+	for k, srcVal := range src {
+		dstVal, exists := result[k]
+		if !exists {
+			result[k] = srcVal
+			continue
+		}
+		switch sv := srcVal.(type) {
+		case map[string]interface{}:
+			if dm, ok := dstVal.(map[string]interface{}); ok {
+				result[k] = additiveMerge(dm, sv)
+				continue
+			}
+		case []interface{}:
+			if da, ok := dstVal.([]interface{}); ok {
+				merged := append([]interface{}{}, da...)
+				for _, v := range sv {
+					if !containsValue(merged, v) {
+						merged = append(merged, v)
+					}
+				}
+				result[k] = merged
+				continue
+			}
+		}
+		result[k] = srcVal
+	}
+	return result
+}
+
+// StripSettings removes the contributions of contrib from settings: equal
+// scalars are deleted, array elements found in contrib are dropped and
+// objects are stripped recursively. Keys left empty are deleted. It is used
+// to take ccp's hook settings back out of a project's settings.json.
+func StripSettings(settings, contrib map[string]interface{}) {
+	for k, cv := range contrib {
+		v, exists := settings[k]
+		if !exists {
+			continue
+		}
+		switch c := cv.(type) {
+		case map[string]interface{}:
+			if m, ok := v.(map[string]interface{}); ok {
+				StripSettings(m, c)
+				if len(m) == 0 {
+					delete(settings, k)
+				}
+			}
+		case []interface{}:
+			if arr, ok := v.([]interface{}); ok {
+				var kept []interface{}
+				for _, elem := range arr {
+					if !containsValue(c, elem) {
+						kept = append(kept, elem)
+					}
+				}
+				if len(kept) == 0 {
+					delete(settings, k)
+				} else {
+					settings[k] = kept
+				}
+			}
+		default:
+			if reflect.DeepEqual(v, cv) {
+				delete(settings, k)
+			}
+		}
+	}
+}
+
+func containsValue(list []interface{}, v interface{}) bool {
+	for _, item := range list {
+		if reflect.DeepEqual(item, v) {
+			return true
+		}
+	}
+	return false
+}
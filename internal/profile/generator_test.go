@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/samhoang/ccp/internal/config"
@@ -836,3 +837,97 @@ func TestUpdateFragment_NoDiff_RemovesFragment(t *testing.T) {
 		t.Error("stale fragment file should have been removed")
 	}
 }
+
+func TestGenerateSettings_MachineOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	profileDir := filepath.Join(tmpDir, "profiles", "work")
+	os.MkdirAll(profileDir, 0755)
+	os.WriteFile(filepath.Join(profileDir, SettingsFragmentFile), []byte(`{"model":"opus","env":{"A":"1"}}`), 0644)
+
+	machine := `
+[settings.env]
+PROXY = "http://proxy:3128"
+
+[profiles.work.settings]
+model = "haiku"
+cleanupPeriodDays = 7
+`
+	os.WriteFile(filepath.Join(tmpDir, config.MachineFile), []byte(machine), 0644)
+
+	paths := &config.Paths{CcpDir: tmpDir, HubDir: filepath.Join(tmpDir, "hub")}
+	manifest := &Manifest{}
+
+	settings, err := GenerateSettings(manifest, paths, profileDir)
+	if err != nil {
+		t.Fatalf("GenerateSettings() error = %v", err)
+	}
+	env := settings["env"].(map[string]interface{})
+	if settings["model"] != "haiku" || env["A"] != "1" || env["PROXY"] != "http://proxy:3128" {
+		t.Errorf("machine overrides not applied: %v", settings)
+	}
+
+	// Capturing the generated settings must not copy the overrides into the
+	// synced fragment, nor lose what they hide of it
+	data, _ := json.Marshal(settings)
+	os.WriteFile(filepath.Join(profileDir, "settings.json"), data, 0644)
+	fragment, err := PreviewFragment(paths, profileDir, manifest)
+	if err != nil {
+		t.Fatalf("PreviewFragment() error = %v", err)
+	}
+	want := map[string]interface{}{"model": "opus", "env": map[string]interface{}{"A": "1"}}
+	if !reflect.DeepEqual(fragment, want) {
+		t.Errorf("PreviewFragment() = %v, want %v", fragment, want)
+	}
+}
+
+func TestGenerateSettings_StatusLine(t *testing.T) {
+	tmpDir := t.TempDir()
+	hubDir := filepath.Join(tmpDir, "hub")
+	profileDir := filepath.Join(tmpDir, "profiles", "work")
+	t.Setenv("HOME", tmpDir)
+
+	// A directory statusline linked into the profile, and a script only in the hub
+	gitDir := filepath.Join(profileDir, "statuslines", "git")
+	os.MkdirAll(gitDir, 0755)
+	os.WriteFile(filepath.Join(gitDir, "statusline.json"), []byte(`{"command": "${CLAUDE_PLUGIN_ROOT}/bin/status.sh --short", "padding": 1}`), 0644)
+	os.MkdirAll(filepath.Join(hubDir, "statuslines"), 0755)
+	os.WriteFile(filepath.Join(hubDir, "statuslines", "minimal.sh"), []byte("#!/bin/sh\necho ok\n"), 0755)
+
+	paths := &config.Paths{CcpDir: tmpDir, HubDir: hubDir}
+	manifest := &Manifest{Hub: HubLinks{Statuslines: []string{"minimal.sh", "git"}}}
+
+	settings, err := GenerateSettings(manifest, paths, profileDir)
+	if err != nil {
+		t.Fatalf("GenerateSettings() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"type":    "command",
+		"command": "$HOME/profiles/work/statuslines/git/bin/status.sh --short",
+		"padding": float64(1),
+	}
+	if !reflect.DeepEqual(settings["statusLine"], want) {
+		t.Errorf("statusLine = %v, want %v", settings["statusLine"], want)
+	}
+
+	// The last linked statusline wins; a file item is the command itself
+	manifest.Hub.Statuslines = []string{"git", "minimal.sh"}
+	settings, err = GenerateSettings(manifest, paths, profileDir)
+	if err != nil {
+		t.Fatalf("GenerateSettings() error = %v", err)
+	}
+	want = map[string]interface{}{"type": "command", "command": "$HOME/profiles/work/statuslines/minimal.sh"}
+	if !reflect.DeepEqual(settings["statusLine"], want) {
+		t.Errorf("statusLine = %v, want %v", settings["statusLine"], want)
+	}
+
+	// The generated statusLine is part of the base, not of the fragment
+	data, _ := json.Marshal(settings)
+	os.WriteFile(filepath.Join(profileDir, "settings.json"), data, 0644)
+	fragment, err := PreviewFragment(paths, profileDir, manifest)
+	if err != nil {
+		t.Fatalf("PreviewFragment() error = %v", err)
+	}
+	if _, ok := fragment["statusLine"]; ok {
+		t.Errorf("statusLine captured into fragment: %v", fragment)
+	}
+}
@@ -25,19 +25,30 @@ type Manifest struct {
 	Engine           string              `toml:"engine,omitempty" yaml:"engine,omitempty"`     // Deprecated: flattened by migration
 	Context          string              `toml:"context,omitempty" yaml:"context,omitempty"`   // Deprecated: flattened by migration
 	SettingsTemplate string              `toml:"settings-template,omitempty" yaml:"settings-template,omitempty"`
+	Materialize      string              `toml:"materialize,omitempty" yaml:"materialize,omitempty"` // "symlink" (default) or "copy"
 	Created          time.Time           `toml:"created" yaml:"created"`
 	Updated          time.Time           `toml:"updated" yaml:"updated"`
 	Hub   HubLinks            `toml:"hub" yaml:"hub"`
 	Hooks []config.HookConfig `toml:"hooks,omitempty" yaml:"hooks,omitempty"`
+	// Env holds variables set before Claude Code starts (see EnvVars);
+	// values may reference the caller's environment as ${env:NAME}
+	Env map[string]string `toml:"env,omitempty" yaml:"env,omitempty"`
 }
 
 // HubLinks defines which hub items are linked to this profile
 type HubLinks struct {
-	Skills   []string `toml:"skills,omitempty" yaml:"skills,omitempty"`
-	Agents   []string `toml:"agents,omitempty" yaml:"agents,omitempty"`
-	Hooks    []string `toml:"hooks,omitempty" yaml:"hooks,omitempty"`
-	Rules    []string `toml:"rules,omitempty" yaml:"rules,omitempty"`
-	Commands []string `toml:"commands,omitempty" yaml:"commands,omitempty"`
+	Skills       []string `toml:"skills,omitempty" yaml:"skills,omitempty"`
+	Agents       []string `toml:"agents,omitempty" yaml:"agents,omitempty"`
+	Hooks        []string `toml:"hooks,omitempty" yaml:"hooks,omitempty"`
+	Rules        []string `toml:"rules,omitempty" yaml:"rules,omitempty"`
+	Commands     []string `toml:"commands,omitempty" yaml:"commands,omitempty"`
+	OutputStyles []string `toml:"output-styles,omitempty" yaml:"output-styles,omitempty"`
+	// Statuslines lists statusLine commands; the last one is written to the
+	// generated settings.json (see statusLineSettings)
+	Statuslines []string `toml:"statuslines,omitempty" yaml:"statuslines,omitempty"`
+	// Memory lists CLAUDE.md snippets; they are rendered into the profile's
+	// CLAUDE.md in priority order (see RenderMemory)
+	Memory []string `toml:"memory,omitempty" yaml:"memory,omitempty"`
 	// Bundles lists linked composite items by name only. Their members are
 	// materialized as per-member symlinks at link time and are deliberately not
 	// recorded here, so a bundle can only be linked/unlinked as a whole.
@@ -136,6 +147,12 @@ func (m *Manifest) GetHubItems(itemType config.HubItemType) []string {
 		return m.Hub.Rules
 	case config.HubCommands:
 		return m.Hub.Commands
+	case config.HubOutputStyles:
+		return m.Hub.OutputStyles
+	case config.HubStatuslines:
+		return m.Hub.Statuslines
+	case config.HubMemory:
+		return m.Hub.Memory
 	case config.HubBundles:
 		return m.Hub.Bundles
 	default:
@@ -156,6 +173,12 @@ func (m *Manifest) SetHubItems(itemType config.HubItemType, items []string) {
 		m.Hub.Rules = items
 	case config.HubCommands:
 		m.Hub.Commands = items
+	case config.HubOutputStyles:
+		m.Hub.OutputStyles = items
+	case config.HubStatuslines:
+		m.Hub.Statuslines = items
+	case config.HubMemory:
+		m.Hub.Memory = items
 	case config.HubBundles:
 		m.Hub.Bundles = items
 	}
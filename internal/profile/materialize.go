@@ -0,0 +1,279 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/source"
+	"github.com/samhoang/ccp/internal/symlink"
+)
+
+// How a profile's hub items are placed in its directory (Manifest.Materialize)
+const (
+	MaterializeSymlink = "symlink" // default: symlinks into the hub
+	MaterializeCopy    = "copy"    // copies, for bind mounts and tools that don't follow links
+)
+
+// CopiesFile records the items ccp copied into a copy-mode profile, with the
+// hub content hash at copy time
+const CopiesFile = ".ccp-copies.toml"
+
+// ErrCopyModified is returned by EnsureItem for a copy edited in the profile
+// since ccp placed it, which is kept rather than overwritten
+var ErrCopyModified = errors.New("modified in profile; run 'ccp profile fix' to replace it")
+
+// ValidateMaterialize checks a materialize mode given on the command line
+func ValidateMaterialize(mode string) error {
+	switch mode {
+	case "", MaterializeSymlink, MaterializeCopy:
+		return nil
+	}
+	return fmt.Errorf("invalid materialize mode %q: use %s or %s", mode, MaterializeSymlink, MaterializeCopy)
+}
+
+// CopyMode reports whether hub items are copied into the profile
+func (m *Manifest) CopyMode() bool {
+	return m.Materialize == MaterializeCopy
+}
+
+// ItemState is how a profile item compares to its hub item
+type ItemState int
+
+const (
+	ItemCurrent    ItemState = iota // placed as the profile's mode asks and up to date
+	ItemMissing                     // nothing at the item path
+	ItemBroken                      // symlink whose target is gone
+	ItemMismatched                  // symlink to another target, or placed in the other mode
+	ItemStale                       // copy whose content differs from the hub item
+)
+
+type copyRecord struct {
+	Items map[string]string `toml:"items"` // profile-relative path -> hub content hash
+}
+
+func loadCopies(profileDir string) (*copyRecord, error) {
+	record := &copyRecord{Items: make(map[string]string)}
+	data, err := os.ReadFile(filepath.Join(profileDir, CopiesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return record, nil
+		}
+		return nil, err
+	}
+	if err := toml.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", CopiesFile, err)
+	}
+	if record.Items == nil {
+		record.Items = make(map[string]string)
+	}
+	return record, nil
+}
+
+func (r *copyRecord) save(profileDir string) error {
+	path := filepath.Join(profileDir, CopiesFile)
+	if len(r.Items) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := toml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func copyKey(profileDir, itemPath string) string {
+	rel, err := filepath.Rel(profileDir, itemPath)
+	if err != nil {
+		return itemPath
+	}
+	return filepath.ToSlash(rel)
+}
+
+// CheckItem compares the item at itemPath with the hub item at hubPath. For
+// mismatched and stale items, detail says what was found instead.
+func CheckItem(profileDir string, manifest *Manifest, itemPath, hubPath string) (ItemState, string, error) {
+	symMgr := symlink.New()
+	info, err := symMgr.Info(itemPath)
+	if err != nil {
+		return ItemCurrent, "", err
+	}
+	if !info.Exists {
+		return ItemMissing, "", nil
+	}
+	if info.IsSymlink && info.IsBroken {
+		return ItemBroken, info.Target, nil
+	}
+
+	if !manifest.CopyMode() {
+		if !info.IsSymlink {
+			// Real files are the user's unless ccp recorded copying them
+			record, err := loadCopies(profileDir)
+			if err != nil {
+				return ItemCurrent, "", err
+			}
+			if _, ok := record.Items[copyKey(profileDir, itemPath)]; ok {
+				return ItemMismatched, "copy", nil
+			}
+			return ItemCurrent, "", nil
+		}
+		valid, err := symMgr.Validate(itemPath, hubPath)
+		if err != nil {
+			return ItemCurrent, "", err
+		}
+		if !valid {
+			return ItemMismatched, info.Target, nil
+		}
+		return ItemCurrent, "", nil
+	}
+
+	if info.IsSymlink {
+		return ItemMismatched, info.Target, nil
+	}
+	hubHash, err := hub.ContentHash(hubPath)
+	if err != nil {
+		return ItemCurrent, "", err
+	}
+	copyHash, err := hub.ContentHash(itemPath)
+	if err != nil {
+		return ItemCurrent, "", err
+	}
+	if copyHash == hubHash {
+		return ItemCurrent, "", nil
+	}
+	record, err := loadCopies(profileDir)
+	if err != nil {
+		return ItemCurrent, "", err
+	}
+	if recorded, ok := record.Items[copyKey(profileDir, itemPath)]; ok && recorded != copyHash {
+		return ItemStale, "modified in profile", nil
+	}
+	return ItemStale, "hub updated", nil
+}
+
+// MaterializeItem places the hub item at hubPath into the profile at
+// itemPath, as a symlink or a recorded copy depending on the manifest.
+// Whatever ccp placed there before is replaced.
+func MaterializeItem(profileDir string, manifest *Manifest, itemPath, hubPath string) error {
+	if _, err := RemoveItem(profileDir, itemPath); err != nil {
+		return err
+	}
+	if _, err := os.Lstat(itemPath); err == nil {
+		return fmt.Errorf("%s already exists and was not placed by ccp", itemPath)
+	}
+	if !manifest.CopyMode() {
+		return symlink.New().Create(itemPath, hubPath)
+	}
+
+	hash, err := hub.ContentHash(hubPath)
+	if err != nil {
+		return err
+	}
+	if err := source.CopyTree(hubPath, itemPath); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", hubPath, err)
+	}
+	record, err := loadCopies(profileDir)
+	if err != nil {
+		return err
+	}
+	record.Items[copyKey(profileDir, itemPath)] = hash
+	return record.save(profileDir)
+}
+
+// RemoveItem removes an item ccp placed in the profile: a symlink, or a copy
+// it recorded. Other files are left alone. It reports whether anything was
+// removed.
+func RemoveItem(profileDir, itemPath string) (bool, error) {
+	info, err := os.Lstat(itemPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return true, os.Remove(itemPath)
+	}
+
+	record, err := loadCopies(profileDir)
+	if err != nil {
+		return false, err
+	}
+	key := copyKey(profileDir, itemPath)
+	if _, ok := record.Items[key]; !ok {
+		return false, nil
+	}
+	if err := os.RemoveAll(itemPath); err != nil {
+		return false, err
+	}
+	delete(record.Items, key)
+	return true, record.save(profileDir)
+}
+
+// EnsureItem materializes the hub item unless it is already current and
+// reports whether it did. A copy modified in the profile is left alone and
+// reported with ErrCopyModified, unless force is set.
+func EnsureItem(profileDir string, manifest *Manifest, itemPath, hubPath string, force bool) (bool, error) {
+	state, _, err := CheckItem(profileDir, manifest, itemPath, hubPath)
+	if err != nil {
+		return false, err
+	}
+	if state == ItemCurrent {
+		return false, nil
+	}
+	if !force {
+		modified, err := copyModified(profileDir, itemPath)
+		if err != nil {
+			return false, err
+		}
+		if modified {
+			return false, ErrCopyModified
+		}
+	}
+	return true, MaterializeItem(profileDir, manifest, itemPath, hubPath)
+}
+
+// copyModified reports whether itemPath is a copy ccp recorded whose content
+// no longer matches the hash it was copied with
+func copyModified(profileDir, itemPath string) (bool, error) {
+	info, err := os.Lstat(itemPath)
+	if err != nil || info.Mode()&os.ModeSymlink != 0 {
+		return false, nil
+	}
+	record, err := loadCopies(profileDir)
+	if err != nil {
+		return false, err
+	}
+	recorded, ok := record.Items[copyKey(profileDir, itemPath)]
+	if !ok {
+		return false, nil
+	}
+	hash, err := hub.ContentHash(itemPath)
+	if err != nil {
+		return false, err
+	}
+	return hash != recorded, nil
+}
+
+// DisownItem forgets that ccp copied the item at itemPath, so it stays in
+// the profile as the profile's own files (e.g. when its hub item is removed
+// with --copy)
+func DisownItem(profileDir, itemPath string) error {
+	record, err := loadCopies(profileDir)
+	if err != nil {
+		return err
+	}
+	key := copyKey(profileDir, itemPath)
+	if _, ok := record.Items[key]; !ok {
+		return nil
+	}
+	delete(record.Items, key)
+	return record.save(profileDir)
+}
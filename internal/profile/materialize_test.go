@@ -0,0 +1,170 @@
+package profile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// setupCopyTest builds a hub with one skill and a copy-mode profile using it
+func setupCopyTest(t *testing.T) (*config.Paths, *Manager, *Profile) {
+	t.Helper()
+	testDir := t.TempDir()
+	paths := &config.Paths{
+		CcpDir:          testDir,
+		ClaudeDir:       filepath.Join(testDir, "claude"),
+		GlobalClaudeDir: filepath.Join(testDir, "claude"),
+		HubDir:          filepath.Join(testDir, "hub"),
+		ProfilesDir:     filepath.Join(testDir, "profiles"),
+		SharedDir:       filepath.Join(testDir, "profiles", "shared"),
+		StoreDir:        filepath.Join(testDir, "store"),
+	}
+	mustWrite(t, filepath.Join(paths.HubItemPath(config.HubSkills, "debug"), "SKILL.md"), "# debug")
+
+	manifest := NewManifest("p", "")
+	manifest.Materialize = MaterializeCopy
+	manifest.Hub.Skills = []string{"debug"}
+	mgr := NewManager(paths)
+	p, err := mgr.Create("p", manifest)
+	if err != nil {
+		t.Fatalf("Create profile: %v", err)
+	}
+	return paths, mgr, p
+}
+
+func TestMaterializeCopy(t *testing.T) {
+	paths, _, p := setupCopyTest(t)
+	itemPath := filepath.Join(p.Path, "skills", "debug")
+	hubPath := paths.HubItemPath(config.HubSkills, "debug")
+
+	info, err := os.Lstat(itemPath)
+	if err != nil {
+		t.Fatalf("copy not created: %v", err)
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		t.Fatal("item is a symlink, want a copy")
+	}
+	state, _, err := CheckItem(p.Path, p.Manifest, itemPath, hubPath)
+	if err != nil || state != ItemCurrent {
+		t.Errorf("CheckItem() = %v, %v; want current", state, err)
+	}
+
+	// A hub change makes the copy stale
+	mustWrite(t, filepath.Join(hubPath, "SKILL.md"), "# debug v2")
+	state, detail, err := CheckItem(p.Path, p.Manifest, itemPath, hubPath)
+	if err != nil || state != ItemStale || detail != "hub updated" {
+		t.Errorf("CheckItem() = %v, %q, %v; want stale (hub updated)", state, detail, err)
+	}
+
+	// A local edit is reported as such
+	mustWrite(t, filepath.Join(itemPath, "SKILL.md"), "# edited")
+	if _, detail, _ := CheckItem(p.Path, p.Manifest, itemPath, hubPath); detail != "modified in profile" {
+		t.Errorf("CheckItem() detail = %q, want modified in profile", detail)
+	}
+}
+
+func TestDetectAndFixStaleCopy(t *testing.T) {
+	paths, _, p := setupCopyTest(t)
+	hubPath := paths.HubItemPath(config.HubSkills, "debug")
+	mustWrite(t, filepath.Join(hubPath, "SKILL.md"), "# debug v2")
+
+	detector := NewDetector(paths)
+	report, err := detector.Detect(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale := report.IssuesByType()[DriftStale]; len(stale) != 1 || stale[0].ItemName != "debug" {
+		t.Fatalf("stale issues = %+v, want debug", stale)
+	}
+
+	if _, err := detector.Fix(p, report, FixOptions{}); err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(p.Path, "skills", "debug", "SKILL.md"))
+	if err != nil || string(data) != "# debug v2" {
+		t.Errorf("refreshed copy = %q, %v; want hub content", data, err)
+	}
+	report, err = detector.Detect(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale := report.IssuesByType()[DriftStale]; len(stale) != 0 {
+		t.Errorf("stale issues after fix = %+v", stale)
+	}
+}
+
+func TestRemoveItemKeepsUnrecordedFiles(t *testing.T) {
+	_, _, p := setupCopyTest(t)
+	itemPath := filepath.Join(p.Path, "skills", "debug")
+	ownPath := filepath.Join(p.Path, "skills", "mine")
+	mustWrite(t, filepath.Join(ownPath, "SKILL.md"), "# mine")
+
+	if removed, err := RemoveItem(p.Path, ownPath); err != nil || removed {
+		t.Errorf("RemoveItem(own) = %v, %v; want left alone", removed, err)
+	}
+	if _, err := os.Stat(ownPath); err != nil {
+		t.Errorf("own files removed: %v", err)
+	}
+
+	if removed, err := RemoveItem(p.Path, itemPath); err != nil || !removed {
+		t.Errorf("RemoveItem(copy) = %v, %v; want removed", removed, err)
+	}
+	if _, err := os.Stat(itemPath); !os.IsNotExist(err) {
+		t.Errorf("copy still present: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(p.Path, CopiesFile)); !os.IsNotExist(err) {
+		t.Errorf("%s kept after last copy removed: %v", CopiesFile, err)
+	}
+}
+
+func TestEnsureItemSwitchesMode(t *testing.T) {
+	paths, _, p := setupCopyTest(t)
+	itemPath := filepath.Join(p.Path, "skills", "debug")
+	hubPath := paths.HubItemPath(config.HubSkills, "debug")
+
+	p.Manifest.Materialize = MaterializeSymlink
+	if state, _, _ := CheckItem(p.Path, p.Manifest, itemPath, hubPath); state != ItemMismatched {
+		t.Fatalf("CheckItem() = %v, want mismatched after switching to symlink", state)
+	}
+	if changed, err := EnsureItem(p.Path, p.Manifest, itemPath, hubPath, false); err != nil || !changed {
+		t.Fatalf("EnsureItem() = %v, %v", changed, err)
+	}
+	if info, err := os.Lstat(itemPath); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("item is not a symlink after switch: %v", err)
+	}
+	if changed, err := EnsureItem(p.Path, p.Manifest, itemPath, hubPath, false); err != nil || changed {
+		t.Errorf("second EnsureItem() = %v, %v; want no change", changed, err)
+	}
+}
+
+func TestEnsureItemKeepsModifiedCopy(t *testing.T) {
+	paths, _, p := setupCopyTest(t)
+	itemPath := filepath.Join(p.Path, "skills", "debug")
+	hubPath := paths.HubItemPath(config.HubSkills, "debug")
+
+	// A hub update alone is refreshed
+	mustWrite(t, filepath.Join(hubPath, "SKILL.md"), "# debug v2")
+	if changed, err := EnsureItem(p.Path, p.Manifest, itemPath, hubPath, false); err != nil || !changed {
+		t.Fatalf("EnsureItem(hub updated) = %v, %v; want refreshed", changed, err)
+	}
+
+	// A hand edit is kept and reported, even after another hub update
+	mustWrite(t, filepath.Join(itemPath, "SKILL.md"), "# edited")
+	mustWrite(t, filepath.Join(hubPath, "SKILL.md"), "# debug v3")
+	if changed, err := EnsureItem(p.Path, p.Manifest, itemPath, hubPath, false); !errors.Is(err, ErrCopyModified) || changed {
+		t.Fatalf("EnsureItem(modified) = %v, %v; want ErrCopyModified", changed, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(itemPath, "SKILL.md")); string(data) != "# edited" {
+		t.Errorf("edited copy overwritten: %q", data)
+	}
+
+	if changed, err := EnsureItem(p.Path, p.Manifest, itemPath, hubPath, true); err != nil || !changed {
+		t.Fatalf("EnsureItem(force) = %v, %v; want replaced", changed, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(itemPath, "SKILL.md")); string(data) != "# debug v3" {
+		t.Errorf("forced copy = %q, want the hub content", data)
+	}
+}
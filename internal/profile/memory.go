@@ -0,0 +1,264 @@
+package profile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+)
+
+// MemoryFile is the profile's memory file; ccp renders memory snippets into
+// a marked region of it and leaves the rest to the user
+const MemoryFile = "CLAUDE.md"
+
+// DefaultMemoryPriority applies to snippets without a priority field; lower
+// priorities are rendered first
+const DefaultMemoryPriority = 50
+
+const (
+	memoryBegin = "<!-- ccp:memory:begin"
+	memoryEnd   = "<!-- ccp:memory:end"
+
+	memoryBeginLine = memoryBegin + " (generated from hub memory snippets by 'ccp profile sync'; edit outside this block) -->"
+)
+
+// MemorySnippet is a hub memory item ready to render
+type MemorySnippet struct {
+	Name     string
+	Priority int
+	Body     string
+}
+
+// MemoryState is how the generated region of CLAUDE.md compares to the
+// profile's memory snippets
+type MemoryState int
+
+const (
+	MemoryCurrent MemoryState = iota // region matches the snippets (or there is nothing to render)
+	MemoryMissing                    // snippets listed but no region rendered
+	MemoryStale                      // snippets changed since the region was rendered
+	MemoryEdited                     // region was edited by hand
+)
+
+// String returns the drift detail for a memory state
+func (s MemoryState) String() string {
+	switch s {
+	case MemoryMissing:
+		return "not rendered"
+	case MemoryStale:
+		return "snippets changed"
+	case MemoryEdited:
+		return "edited by hand"
+	default:
+		return "current"
+	}
+}
+
+// memoryDoc is CLAUDE.md split around its generated region
+type memoryDoc struct {
+	before, after string // user-owned content
+	region        string // generated content between the markers
+	recordedHash  string // hash written in the end marker at render time
+	found         bool
+}
+
+// LoadMemorySnippets reads the profile's memory snippets in render order:
+// by priority, then manifest order. Snippets are read from the profile
+// (symlink or copy), falling back to the hub; missing ones are skipped and
+// left to drift detection.
+func LoadMemorySnippets(paths *config.Paths, profileDir string, manifest *Manifest) ([]MemorySnippet, error) {
+	var snippets []MemorySnippet
+	for _, name := range manifest.Hub.Memory {
+		data, err := os.ReadFile(filepath.Join(profileDir, string(config.HubMemory), name))
+		if err != nil {
+			data, err = os.ReadFile(paths.HubItemPath(config.HubMemory, name))
+		}
+		if err != nil {
+			continue
+		}
+		snippet, err := parseMemorySnippet(name, data)
+		if err != nil {
+			return nil, fmt.Errorf("memory/%s: %w", name, err)
+		}
+		snippets = append(snippets, snippet)
+	}
+	sort.SliceStable(snippets, func(i, j int) bool {
+		return snippets[i].Priority < snippets[j].Priority
+	})
+	return snippets, nil
+}
+
+func parseMemorySnippet(name string, data []byte) (MemorySnippet, error) {
+	snippet := MemorySnippet{Name: name, Priority: DefaultMemoryPriority, Body: string(data)}
+	fm, err := hub.ParseFrontmatter(data)
+	if err != nil {
+		return snippet, err
+	}
+	if fm != nil {
+		snippet.Body = fm.Body
+		if p := fm.String("priority"); p != "" {
+			priority, err := strconv.Atoi(p)
+			if err != nil {
+				return snippet, fmt.Errorf("priority must be an integer, got %q", p)
+			}
+			snippet.Priority = priority
+		}
+	}
+	snippet.Body = strings.TrimSpace(strings.ReplaceAll(snippet.Body, "\r\n", "\n"))
+	return snippet, nil
+}
+
+// renderMemoryRegion renders snippets as the content between the markers
+func renderMemoryRegion(snippets []MemorySnippet) string {
+	var b strings.Builder
+	for i, s := range snippets {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "<!-- memory: %s -->\n", s.Name)
+		if s.Body != "" {
+			b.WriteString(s.Body)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func memoryHash(region string) string {
+	sum := sha256.Sum256([]byte(region))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// parseMemoryDoc splits CLAUDE.md content around the generated region
+func parseMemoryDoc(content string) (*memoryDoc, error) {
+	doc := &memoryDoc{before: content}
+	start := lineIndex(content, memoryBegin, 0)
+	if start < 0 {
+		return doc, nil
+	}
+	regionStart := len(content)
+	if nl := strings.IndexByte(content[start:], '\n'); nl >= 0 {
+		regionStart = start + nl + 1
+	}
+	end := lineIndex(content, memoryEnd, regionStart)
+	if end < 0 {
+		return nil, fmt.Errorf("%s has an unterminated ccp memory block", MemoryFile)
+	}
+	endLine := content[end:]
+	after := ""
+	if nl := strings.IndexByte(endLine, '\n'); nl >= 0 {
+		endLine, after = endLine[:nl], endLine[nl+1:]
+	}
+
+	doc.before = content[:start]
+	doc.region = content[regionStart:end]
+	doc.after = after
+	doc.found = true
+	if fields := strings.Fields(strings.TrimPrefix(endLine, memoryEnd)); len(fields) > 0 {
+		doc.recordedHash = strings.TrimPrefix(fields[0], "hash=")
+	}
+	return doc, nil
+}
+
+// lineIndex returns the offset of the first line at or after from that
+// starts with prefix, or -1
+func lineIndex(content, prefix string, from int) int {
+	for i := from; i < len(content); {
+		if strings.HasPrefix(content[i:], prefix) {
+			return i
+		}
+		nl := strings.IndexByte(content[i:], '\n')
+		if nl < 0 {
+			break
+		}
+		i += nl + 1
+	}
+	return -1
+}
+
+// render returns the document with region as its generated content; an
+// empty region removes the block and leaves only the user's content
+func (d *memoryDoc) render(region string) string {
+	if region == "" {
+		if !d.found {
+			return d.before
+		}
+		return strings.TrimLeft(d.before+d.after, "\n")
+	}
+	block := memoryBeginLine + "\n" + region + memoryEnd + " hash=" + memoryHash(region) + " -->\n"
+	if !d.found {
+		// New block goes on top; existing content stays below as the user's
+		if d.before == "" {
+			return block
+		}
+		return block + "\n" + d.before
+	}
+	return d.before + block + d.after
+}
+
+func readMemoryDoc(profileDir string) (*memoryDoc, error) {
+	data, err := os.ReadFile(filepath.Join(profileDir, MemoryFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return parseMemoryDoc(string(data))
+}
+
+// RenderMemory renders the profile's memory snippets into the generated
+// region of its CLAUDE.md, keeping everything outside the region as is.
+// With no snippets the region is removed. Reports whether the file changed.
+func RenderMemory(paths *config.Paths, profileDir string, manifest *Manifest) (bool, error) {
+	doc, err := readMemoryDoc(profileDir)
+	if err != nil {
+		return false, err
+	}
+	if len(manifest.Hub.Memory) == 0 && !doc.found {
+		return false, nil
+	}
+	snippets, err := LoadMemorySnippets(paths, profileDir, manifest)
+	if err != nil {
+		return false, err
+	}
+
+	path := filepath.Join(profileDir, MemoryFile)
+	old, _ := os.ReadFile(path)
+	content := doc.render(renderMemoryRegion(snippets))
+	if content == string(old) {
+		return false, nil
+	}
+	if content == "" {
+		return true, os.Remove(path)
+	}
+	return true, os.WriteFile(path, []byte(content), 0644)
+}
+
+// CheckMemory compares the generated region of the profile's CLAUDE.md with
+// what RenderMemory would write. Hand edits take precedence over staleness.
+func CheckMemory(paths *config.Paths, profileDir string, manifest *Manifest) (MemoryState, error) {
+	doc, err := readMemoryDoc(profileDir)
+	if err != nil {
+		return MemoryCurrent, err
+	}
+	if doc.found && memoryHash(doc.region) != doc.recordedHash {
+		return MemoryEdited, nil
+	}
+	snippets, err := LoadMemorySnippets(paths, profileDir, manifest)
+	if err != nil {
+		return MemoryCurrent, err
+	}
+	region := renderMemoryRegion(snippets)
+	switch {
+	case !doc.found && region != "":
+		return MemoryMissing, nil
+	case doc.found && doc.region != region:
+		return MemoryStale, nil
+	}
+	return MemoryCurrent, nil
+}
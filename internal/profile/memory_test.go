@@ -0,0 +1,124 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// setupMemoryTest builds a hub with two memory snippets and a profile with
+// a hand-written CLAUDE.md
+func setupMemoryTest(t *testing.T) (*config.Paths, *Profile) {
+	t.Helper()
+	testDir := t.TempDir()
+	paths := &config.Paths{
+		CcpDir:      testDir,
+		HubDir:      filepath.Join(testDir, "hub"),
+		ProfilesDir: filepath.Join(testDir, "profiles"),
+		SharedDir:   filepath.Join(testDir, "profiles", "shared"),
+		StoreDir:    filepath.Join(testDir, "store"),
+	}
+	mustWrite(t, paths.HubItemPath(config.HubMemory, "testing.md"), "# Testing\nUse table tests.\n")
+	mustWrite(t, paths.HubItemPath(config.HubMemory, "go.md"), "---\npriority: 10\n---\n# Go\nRun gofmt.\n")
+
+	mgr := NewManager(paths)
+	p, err := mgr.Create("p", NewManifest("p", ""))
+	if err != nil {
+		t.Fatalf("Create profile: %v", err)
+	}
+	mustWrite(t, filepath.Join(p.Path, MemoryFile), "# My notes\n")
+	for _, name := range []string{"testing.md", "go.md"} {
+		if err := mgr.LinkHubItem("p", config.HubMemory, name); err != nil {
+			t.Fatalf("LinkHubItem(%s): %v", name, err)
+		}
+	}
+	p, err = mgr.Get("p")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return paths, p
+}
+
+func readMemory(t *testing.T, p *Profile) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(p.Path, MemoryFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRenderMemory(t *testing.T) {
+	paths, p := setupMemoryTest(t)
+
+	content := readMemory(t, p)
+	goAt, testingAt := strings.Index(content, "Run gofmt."), strings.Index(content, "Use table tests.")
+	if goAt < 0 || testingAt < 0 || goAt > testingAt {
+		t.Errorf("snippets not rendered in priority order:\n%s", content)
+	}
+	if !strings.HasSuffix(content, "\n# My notes\n") {
+		t.Errorf("user content not kept below the generated region:\n%s", content)
+	}
+	if changed, err := RenderMemory(paths, p.Path, p.Manifest); err != nil || changed {
+		t.Errorf("second RenderMemory() = %v, %v; want no change", changed, err)
+	}
+
+	// Removing every snippet leaves only the user's content
+	p.Manifest.Hub.Memory = nil
+	if _, err := RenderMemory(paths, p.Path, p.Manifest); err != nil {
+		t.Fatal(err)
+	}
+	if got := readMemory(t, p); got != "# My notes\n" {
+		t.Errorf("CLAUDE.md = %q, want only user content", got)
+	}
+}
+
+func TestCheckMemory(t *testing.T) {
+	paths, p := setupMemoryTest(t)
+	path := filepath.Join(p.Path, MemoryFile)
+
+	// Edits outside the region are the user's
+	mustWrite(t, path, readMemory(t, p)+"More notes\n")
+	if state, err := CheckMemory(paths, p.Path, p.Manifest); err != nil || state != MemoryCurrent {
+		t.Errorf("CheckMemory() = %v, %v; want current", state, err)
+	}
+
+	// A hub change makes the region stale
+	mustWrite(t, paths.HubItemPath(config.HubMemory, "go.md"), "# Go\nRun gofmt and vet.\n")
+	if state, _ := CheckMemory(paths, p.Path, p.Manifest); state != MemoryStale {
+		t.Errorf("CheckMemory() = %v, want stale", state)
+	}
+
+	// Hand edits inside the region are reported as such
+	mustWrite(t, path, strings.Replace(readMemory(t, p), "Use table tests.", "Use any tests.", 1))
+	if state, _ := CheckMemory(paths, p.Path, p.Manifest); state != MemoryEdited {
+		t.Errorf("CheckMemory() = %v, want edited", state)
+	}
+}
+
+func TestDetectAndFixMemory(t *testing.T) {
+	paths, p := setupMemoryTest(t)
+	path := filepath.Join(p.Path, MemoryFile)
+	mustWrite(t, path, strings.Replace(readMemory(t, p), "Run gofmt.", "Run nothing.", 1))
+
+	detector := NewDetector(paths)
+	report, err := detector.Detect(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := report.IssuesByType()[DriftMemory]
+	if len(issues) != 1 || issues[0].Actual != MemoryEdited.String() {
+		t.Fatalf("memory issues = %+v, want one edited", issues)
+	}
+
+	if _, err := detector.Fix(p, report, FixOptions{}); err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+	content := readMemory(t, p)
+	if !strings.Contains(content, "Run gofmt.") || !strings.Contains(content, "# My notes") {
+		t.Errorf("Fix() did not re-render the region:\n%s", content)
+	}
+}
@@ -0,0 +1,227 @@
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// Overlay is a temporary config directory that layers extra hub items over
+// a profile, for 'ccp run --with' and 'ccp session --with'. Everything but
+// the generated files links back into the profile, so history, plugins and
+// credentials are the profile's own; the profile itself is never changed.
+type Overlay struct {
+	Dir      string    // config directory to point CLAUDE_CONFIG_DIR at
+	Profile  string    // base profile name
+	Items    []string  // extra items as type/name, in the order given
+	Manifest *Manifest // the profile's manifest with the extra items added
+	root     string    // temporary directory holding Dir
+}
+
+// overlayGenerated are the profile files an overlay writes itself instead of
+// linking to, or leaves out
+var overlayGenerated = map[string]bool{
+	"settings.json":      true,
+	SettingsFragmentFile: true,
+	"profile.toml":       true,
+	"profile.yaml":       true,
+	CopiesFile:           true,
+}
+
+// ParseOverlayItem parses a type/name reference to a hub item, adding a .md
+// extension to the name when only that exists in the hub
+func ParseOverlayItem(paths *config.Paths, ref string) (config.HubItemType, string, error) {
+	parts := strings.SplitN(ref, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid item: %s (expected type/name)", ref)
+	}
+	itemType, name := config.HubItemType(parts[0]), parts[1]
+
+	valid := false
+	for _, t := range config.AllHubItemTypes() {
+		if t == itemType && t != config.HubSettingsTemplates {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return "", "", fmt.Errorf("invalid item type: %s (valid: skills, agents, hooks, rules, commands, output-styles, statuslines, memory)", parts[0])
+	}
+
+	if _, err := os.Stat(paths.HubItemPath(itemType, name)); err == nil {
+		return itemType, name, nil
+	}
+	if _, err := os.Stat(paths.HubItemPath(itemType, name+".md")); err == nil {
+		return itemType, name + ".md", nil
+	}
+	return "", "", fmt.Errorf("hub item not found: %s", ref)
+}
+
+// CreateOverlay builds an overlay of profile profileName with the hub items
+// refs (type/name) linked on top. Settings are generated for the combined
+// manifest from the profile's current settings, and memory snippets are
+// rendered into a copy of its CLAUDE.md. Call Remove when done with it.
+func (m *Manager) CreateOverlay(profileName string, refs []string) (*Overlay, error) {
+	p, err := m.Get(profileName)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, fmt.Errorf("profile not found: %s", profileName)
+	}
+
+	// Copy the hub lists so the profile's manifest is left as it was
+	manifest := *p.Manifest
+	for _, itemType := range config.AllHubItemTypes() {
+		if items := p.Manifest.GetHubItems(itemType); items != nil {
+			manifest.SetHubItems(itemType, append([]string(nil), items...))
+		}
+	}
+	extras := make(map[config.HubItemType][]string)
+	var items []string
+	for _, ref := range refs {
+		itemType, name, err := ParseOverlayItem(m.paths, ref)
+		if err != nil {
+			return nil, err
+		}
+		extras[itemType] = append(extras[itemType], name)
+		manifest.AddHubItem(itemType, name)
+		items = append(items, string(itemType)+"/"+name)
+	}
+
+	// The overlay directory is named after the profile so machine.toml
+	// overrides for it still apply
+	root, err := os.MkdirTemp("", "ccp-overlay-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create overlay directory: %w", err)
+	}
+	o := &Overlay{
+		Dir:      filepath.Join(root, profileName),
+		Profile:  profileName,
+		Items:    items,
+		Manifest: &manifest,
+		root:     root,
+	}
+	if err := m.buildOverlay(o, p, extras); err != nil {
+		o.Remove()
+		return nil, err
+	}
+	return o, nil
+}
+
+func (m *Manager) buildOverlay(o *Overlay, p *Profile, extras map[config.HubItemType][]string) error {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return err
+	}
+	if err := os.Mkdir(o.Dir, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	hubTypes := make(map[string]bool)
+	for _, itemType := range config.AllHubItemTypes() {
+		hubTypes[string(itemType)] = true
+	}
+
+	entries, err := os.ReadDir(p.Path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		src, dst := filepath.Join(p.Path, name), filepath.Join(o.Dir, name)
+		switch {
+		case overlayGenerated[name]:
+			continue
+		case hubTypes[name] && entry.IsDir():
+			// Item directories are rebuilt so extras can be added next to
+			// the profile's own items
+			if err := m.linkEntries(src, dst); err != nil {
+				return err
+			}
+		default:
+			if err := m.symMgr.Create(dst, src); err != nil {
+				return err
+			}
+		}
+	}
+
+	for itemType, names := range extras {
+		itemDir := filepath.Join(o.Dir, string(itemType))
+		if err := os.MkdirAll(itemDir, 0755); err != nil {
+			return err
+		}
+		for _, name := range names {
+			linkName := name
+			if itemType == config.HubRules {
+				linkName = filepath.Base(name)
+			}
+			itemPath := filepath.Join(itemDir, linkName)
+			if _, err := os.Lstat(itemPath); err == nil {
+				continue // already linked to the profile
+			}
+			if err := m.symMgr.Create(itemPath, m.paths.HubItemPath(itemType, name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The profile's current settings.json, edits not yet captured included,
+	// becomes the overlay's fragment
+	fragment, err := PreviewFragment(m.paths, p.Path, p.Manifest)
+	if err != nil {
+		if fragment, err = loadFragment(p.Path); err != nil {
+			return err
+		}
+	}
+	if len(fragment) > 0 {
+		if err := writeJSONFile(filepath.Join(o.Dir, SettingsFragmentFile), fragment); err != nil {
+			return err
+		}
+	}
+	if err := RegenerateSettings(m.paths, o.Dir, o.Manifest); err != nil {
+		return fmt.Errorf("failed to generate settings.json: %w", err)
+	}
+
+	if len(extras[config.HubMemory]) > 0 {
+		// Render into a copy; writing through the link would change the profile
+		memoryPath := filepath.Join(o.Dir, MemoryFile)
+		data, err := os.ReadFile(filepath.Join(p.Path, MemoryFile))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		os.Remove(memoryPath)
+		if err := os.WriteFile(memoryPath, data, 0644); err != nil {
+			return err
+		}
+		if _, err := RenderMemory(m.paths, o.Dir, o.Manifest); err != nil {
+			return fmt.Errorf("failed to render %s: %w", MemoryFile, err)
+		}
+	}
+	return nil
+}
+
+// linkEntries creates dstDir with a link to each entry of srcDir
+func (m *Manager) linkEntries(srcDir, dstDir string) error {
+	if err := os.MkdirAll(dstDir, 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := m.symMgr.Create(filepath.Join(dstDir, entry.Name()), filepath.Join(srcDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove deletes the overlay. Links are removed, never what they point to.
+func (o *Overlay) Remove() error {
+	return os.RemoveAll(o.root)
+}
@@ -0,0 +1,89 @@
+package profile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+func TestCreateOverlay(t *testing.T) {
+	testDir := t.TempDir()
+	paths := &config.Paths{
+		CcpDir:      testDir,
+		HubDir:      filepath.Join(testDir, "hub"),
+		ProfilesDir: filepath.Join(testDir, "profiles"),
+		SharedDir:   filepath.Join(testDir, "profiles", "shared"),
+		StoreDir:    filepath.Join(testDir, "store"),
+	}
+	mustWrite(t, paths.HubItemPath(config.HubSkills, "debug/SKILL.md"), "# debug\n")
+	mustWrite(t, paths.HubItemPath(config.HubSkills, "k8s/SKILL.md"), "# k8s\n")
+	mustWrite(t, paths.HubItemPath(config.HubAgents, "sre.md"), "# sre\n")
+	mustWrite(t, paths.HubItemPath(config.HubMemory, "ops.md"), "Check the pager.\n")
+
+	mgr := NewManager(paths)
+	p, err := mgr.Create("dev", NewManifest("dev", ""))
+	if err != nil {
+		t.Fatalf("Create profile: %v", err)
+	}
+	if err := mgr.LinkHubItem("dev", config.HubSkills, "debug"); err != nil {
+		t.Fatal(err)
+	}
+	mustWrite(t, filepath.Join(p.Path, MemoryFile), "# Dev notes\n")
+	mustWrite(t, filepath.Join(p.Path, "settings.json"), `{"model": "opus"}`)
+
+	overlay, err := mgr.CreateOverlay("dev", []string{"skills/k8s", "agents/sre", "memory/ops"})
+	if err != nil {
+		t.Fatalf("CreateOverlay() error = %v", err)
+	}
+	if filepath.Base(overlay.Dir) != "dev" {
+		t.Errorf("overlay dir %s should be named after the profile", overlay.Dir)
+	}
+	if want := "skills/k8s agents/sre.md memory/ops.md"; strings.Join(overlay.Items, " ") != want {
+		t.Errorf("Items = %v, want %s", overlay.Items, want)
+	}
+	for _, rel := range []string{"skills/debug/SKILL.md", "skills/k8s/SKILL.md", "agents/sre.md"} {
+		if _, err := os.Stat(filepath.Join(overlay.Dir, rel)); err != nil {
+			t.Errorf("%s missing from overlay: %v", rel, err)
+		}
+	}
+
+	// Uncaptured settings edits of the profile carry over
+	data, err := os.ReadFile(filepath.Join(overlay.Dir, "settings.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil || settings["model"] != "opus" {
+		t.Errorf("settings.json = %s, want model from profile", data)
+	}
+
+	// Memory is rendered into a copy; the profile's CLAUDE.md is untouched
+	memory, _ := os.ReadFile(filepath.Join(overlay.Dir, MemoryFile))
+	if !strings.Contains(string(memory), "Check the pager.") || !strings.Contains(string(memory), "# Dev notes") {
+		t.Errorf("overlay %s = %q", MemoryFile, memory)
+	}
+	if got := readMemory(t, p); got != "# Dev notes\n" {
+		t.Errorf("profile %s changed: %q", MemoryFile, got)
+	}
+	if _, err := os.Lstat(filepath.Join(p.Path, "skills", "k8s")); !os.IsNotExist(err) {
+		t.Error("extra item should not be linked into the profile")
+	}
+
+	if err := overlay.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(overlay.Dir); !os.IsNotExist(err) {
+		t.Error("overlay should be removed")
+	}
+	if _, err := os.Stat(paths.HubItemPath(config.HubSkills, "k8s/SKILL.md")); err != nil {
+		t.Error("Remove() must not delete link targets")
+	}
+
+	if _, err := mgr.CreateOverlay("dev", []string{"skills/missing"}); err == nil {
+		t.Error("CreateOverlay() with a missing item should fail")
+	}
+}
@@ -156,6 +156,18 @@ func (m *Manager) Create(name string, manifest *Manifest) (*Profile, error) {
 		}
 	}
 
+	// Credentials are kept out of the loop above and follow their own default:
+	// isolated, so the profile has no login until Claude Code writes one there
+	// or 'ccp profile auth --share' links the shared one
+	if config.DefaultDataConfig()[config.DataCredentials] == config.ShareModeShared {
+		sharedCreds := m.paths.SharedDataDir(config.DataCredentials)
+		if _, err := os.Stat(sharedCreds); err == nil {
+			if err := m.symMgr.Create(filepath.Join(profileDir, config.DataCredentials.EntryName()), sharedCreds); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Create plugins directory and symlink shared items to store
 	pluginsDir := filepath.Join(profileDir, "plugins")
 	if err := os.MkdirAll(pluginsDir, defaultPerm); err != nil {
@@ -172,12 +184,12 @@ func (m *Manager) Create(name string, manifest *Manifest) (*Profile, error) {
 		}
 	}
 
-	// Create symlinks for hub items
+	// Place hub items: symlinks, or copies in copy mode
 	for _, itemType := range config.AllHubItemTypes() {
 		for _, itemName := range manifest.GetHubItems(itemType) {
 			hubItemPath := m.paths.HubItemPath(itemType, itemName)
 			profileItemPath := filepath.Join(profileDir, string(itemType), itemName)
-			if err := m.symMgr.Create(profileItemPath, hubItemPath); err != nil {
+			if err := MaterializeItem(profileDir, manifest, profileItemPath, hubItemPath); err != nil {
 				return nil, err
 			}
 		}
@@ -189,8 +201,8 @@ func (m *Manager) Create(name string, manifest *Manifest) (*Profile, error) {
 		return nil, err
 	}
 
-	// Generate settings.json with hooks from manifest
-	if len(manifest.Hub.Hooks) > 0 {
+	// Generate settings.json with hooks and statusline from manifest
+	if len(manifest.Hub.Hooks) > 0 || len(manifest.Hub.Statuslines) > 0 {
 		if err := RegenerateSettings(m.paths, profileDir, manifest); err != nil {
 			// Non-fatal - log and continue
 			fmt.Fprintf(os.Stderr, "Warning: failed to generate settings.json: %v\n", err)
@@ -203,6 +215,13 @@ func (m *Manager) Create(name string, manifest *Manifest) (*Profile, error) {
 		}
 	}
 
+	// Render memory snippets into CLAUDE.md
+	if len(manifest.Hub.Memory) > 0 {
+		if _, err := RenderMemory(m.paths, profileDir, manifest); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to render %s: %v\n", MemoryFile, err)
+		}
+	}
+
 	return &Profile{
 		Name:     name,
 		Path:     profileDir,
@@ -218,6 +237,54 @@ func (m *Manager) Delete(name string) error {
 	return os.RemoveAll(profileDir)
 }
 
+// Restore recreates the local layout of a profile that arrived as just its
+// manifest (e.g. through 'ccp sync pull'): hub item directories, data
+// directories linked to shared and plugin store links. Existing entries are
+// left alone; hub item symlinks and settings are up to profile sync.
+func (m *Manager) Restore(name string) error {
+	profileDir := m.paths.ProfileDir(name)
+
+	for _, itemType := range config.AllHubItemTypes() {
+		if err := os.MkdirAll(filepath.Join(profileDir, string(itemType)), 0755); err != nil {
+			return err
+		}
+	}
+
+	for _, dataType := range config.AllDataItemTypes() {
+		dataDir := filepath.Join(profileDir, string(dataType))
+		if _, err := os.Lstat(dataDir); err == nil {
+			continue
+		}
+		sharedDir := m.paths.SharedDataDir(dataType)
+		if err := os.MkdirAll(sharedDir, 0755); err != nil {
+			return err
+		}
+		if err := m.symMgr.Create(dataDir, sharedDir); err != nil {
+			return err
+		}
+	}
+
+	pluginsDir := filepath.Join(profileDir, "plugins")
+	if err := os.MkdirAll(pluginsDir, 0755); err != nil {
+		return err
+	}
+	for _, item := range config.SharedPluginStoreItems() {
+		storeItemPath := m.paths.StorePluginItemPath(item)
+		profileItemPath := filepath.Join(pluginsDir, string(item))
+		if _, err := os.Stat(storeItemPath); err != nil {
+			continue
+		}
+		if _, err := os.Lstat(profileItemPath); err == nil {
+			continue
+		}
+		if err := m.symMgr.Create(profileItemPath, storeItemPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Exists checks if a profile exists
 func (m *Manager) Exists(name string) bool {
 	profileDir := m.paths.ProfileDir(name)
@@ -251,14 +318,24 @@ func (m *Manager) LinkHubItem(profileName string, itemType config.HubItemType, i
 		return err
 	}
 
-	// Create symlink
-	if err := m.symMgr.Create(profileItemPath, hubItemPath); err != nil {
+	// Create symlink (or copy)
+	if err := MaterializeItem(profile.Path, profile.Manifest, profileItemPath, hubItemPath); err != nil {
 		return err
 	}
 
 	// Update manifest
 	profile.Manifest.AddHubItem(itemType, itemName)
-	return profile.Manifest.Save(ManifestPath(profile.Path))
+	if err := profile.Manifest.Save(ManifestPath(profile.Path)); err != nil {
+		return err
+	}
+
+	switch itemType {
+	case config.HubMemory:
+		_, err = RenderMemory(m.paths, profile.Path, profile.Manifest)
+	case config.HubStatuslines:
+		err = RegenerateSettings(m.paths, profile.Path, profile.Manifest)
+	}
+	return err
 }
 
 // UnlinkHubItem removes a hub item from a profile
@@ -277,19 +354,34 @@ func (m *Manager) UnlinkHubItem(profileName string, itemType config.HubItemType,
 		linkName = filepath.Base(itemName)
 	}
 	profileItemPath := filepath.Join(profile.Path, string(itemType), linkName)
+	if _, err := RemoveItem(profile.Path, profileItemPath); err != nil {
+		return err
+	}
 	if err := os.Remove(profileItemPath); err != nil && !os.IsNotExist(err) {
 		return err
 	}
 
 	// Update manifest
 	profile.Manifest.RemoveHubItem(itemType, itemName)
-	return profile.Manifest.Save(ManifestPath(profile.Path))
+	if err := profile.Manifest.Save(ManifestPath(profile.Path)); err != nil {
+		return err
+	}
+
+	switch itemType {
+	case config.HubMemory:
+		_, err = RenderMemory(m.paths, profile.Path, profile.Manifest)
+	case config.HubStatuslines:
+		err = RegenerateSettings(m.paths, profile.Path, profile.Manifest)
+	}
+	return err
 }
 
 // LinkHubBundle links an entire bundle to a profile by materializing each of
 // its members as a per-member symlink into the profile's leaf directories.
 // Only the bundle name is recorded in the manifest, so a bundle is atomic:
-// its members cannot be linked or unlinked individually.
+// its members cannot be linked or unlinked individually. Linking a bundle
+// that is already linked refreshes its members in place and keeps its
+// position in the manifest.
 func (m *Manager) LinkHubBundle(profileName, bundleName string) error {
 	profile, err := m.Get(profileName)
 	if err != nil {
@@ -315,20 +407,33 @@ func (m *Manager) LinkHubBundle(profileName, bundleName string) error {
 			linkName = filepath.Base(member.Name)
 		}
 		dst := filepath.Join(profile.Path, member.Type, linkName)
-		if err := m.symMgr.Create(dst, src); err != nil {
+		if err := MaterializeItem(profile.Path, profile.Manifest, dst, src); err != nil {
 			return err
 		}
 	}
 
 	profile.Manifest.AddHubItem(config.HubBundles, bundleName)
-	return profile.Manifest.Save(ManifestPath(profile.Path))
+	if err := profile.Manifest.Save(ManifestPath(profile.Path)); err != nil {
+		return err
+	}
+
+	// Bundle hooks and settings only take effect through settings.json
+	settings, err := hub.LoadBundleSettings(m.paths.BundlesDir(), bundleName)
+	if err != nil {
+		return err
+	}
+	if settings != nil || len(bundle.Members.Hooks) > 0 {
+		return RegenerateSettings(m.paths, profile.Path, profile.Manifest)
+	}
+	return nil
 }
 
 // UnlinkHubBundle removes a linked bundle and all of its materialized member
 // symlinks from a profile. Members are resolved from the bundle manifest (the
 // source of truth); if the bundle no longer exists in the hub, only the
 // manifest entry is removed and any orphaned member symlinks are left for
-// drift detection to report.
+// drift detection to report. settings.json is regenerated without the
+// bundle's settings; the profile's settings fragment is left as it is.
 func (m *Manager) UnlinkHubBundle(profileName, bundleName string) error {
 	profile, err := m.Get(profileName)
 	if err != nil {
@@ -338,6 +443,7 @@ func (m *Manager) UnlinkHubBundle(profileName, bundleName string) error {
 		return os.ErrNotExist
 	}
 
+	regenerate := false
 	if bundle, err := hub.LoadBundle(m.paths.BundlesDir(), bundleName); err == nil {
 		for _, member := range bundle.Members.AllComponents() {
 			linkName := member.Name
@@ -345,14 +451,30 @@ func (m *Manager) UnlinkHubBundle(profileName, bundleName string) error {
 				linkName = filepath.Base(member.Name)
 			}
 			dst := filepath.Join(profile.Path, member.Type, linkName)
+			if _, err := RemoveItem(profile.Path, dst); err != nil {
+				return err
+			}
 			if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
 				return err
 			}
 		}
+		// Bundle settings are never captured into the fragment, so
+		// regenerating is enough to take them out of settings.json
+		settings, err := hub.LoadBundleSettings(m.paths.BundlesDir(), bundleName)
+		if err != nil {
+			return err
+		}
+		regenerate = settings != nil || len(bundle.Members.Hooks) > 0
 	}
 
 	profile.Manifest.RemoveHubItem(config.HubBundles, bundleName)
-	return profile.Manifest.Save(ManifestPath(profile.Path))
+	if err := profile.Manifest.Save(ManifestPath(profile.Path)); err != nil {
+		return err
+	}
+	if regenerate {
+		return RegenerateSettings(m.paths, profile.Path, profile.Manifest)
+	}
+	return nil
 }
 
 // GetActive returns the currently active profile (via symlink)
@@ -0,0 +1,167 @@
+package profile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+)
+
+// projectHookRoot is how a project's settings.json refers to a hook item
+// copied into its .claude/hooks/, independent of where the project lives
+func projectHookRoot(hookName string) string {
+	return "${CLAUDE_PROJECT_DIR}/.claude/hooks/" + hookName
+}
+
+// resolveProjectHookRoot replaces ${CLAUDE_PLUGIN_ROOT} in a hook command
+// with root. A reference already inside double quotes is substituted as is;
+// a bare one has its whole word quoted, so project paths with spaces work.
+func resolveProjectHookRoot(command, root string) string {
+	const placeholder = "${CLAUDE_PLUGIN_ROOT}"
+	var b strings.Builder
+	inDouble, inSingle := false, false
+	for i := 0; i < len(command); {
+		if !inSingle && strings.HasPrefix(command[i:], placeholder) {
+			if inDouble {
+				b.WriteString(root)
+				i += len(placeholder)
+				continue
+			}
+			end := i + len(placeholder)
+			for end < len(command) && !strings.ContainsRune(" \t;&|<>()'\"", rune(command[end])) {
+				end++
+			}
+			b.WriteString(`"` + root + command[i+len(placeholder):end] + `"`)
+			i = end
+			continue
+		}
+		switch c := command[i]; {
+		case c == '\\' && !inSingle && i+1 < len(command):
+			b.WriteString(command[i : i+2])
+			i += 2
+			continue
+		case c == '"' && !inSingle:
+			inDouble = !inDouble
+		case c == '\'' && !inDouble:
+			inSingle = !inSingle
+		}
+		b.WriteByte(command[i])
+		i++
+	}
+	return b.String()
+}
+
+// ProjectHookSettings returns the settings a hook item copied into
+// <claudeDir>/hooks/<hookName> contributes: the entries of its hooks.json with
+// ${CLAUDE_PLUGIN_ROOT} resolved against $CLAUDE_PROJECT_DIR. It returns nil
+// for items without a hooks.json.
+func ProjectHookSettings(claudeDir, hookName string) (map[string]interface{}, error) {
+	hooksJSON, err := hub.GetHooksJSON(filepath.Join(claudeDir, string(config.HubHooks), hookName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	root := projectHookRoot(hookName)
+	for _, entries := range hooksJSON.Hooks {
+		for _, entry := range entries {
+			for i := range entry.Hooks {
+				entry.Hooks[i].Command = resolveProjectHookRoot(entry.Hooks[i].Command, root)
+			}
+		}
+	}
+	hooks := make(map[config.HookType][]config.SettingsHookEntry)
+	processHooksJSON(hooksJSON, root, hooks)
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"hooks": hooks})
+	if err != nil {
+		return nil, err
+	}
+	var contrib map[string]interface{}
+	if err := json.Unmarshal(data, &contrib); err != nil {
+		return nil, err
+	}
+	return contrib, nil
+}
+
+// AddProjectHooks registers a hook item copied into the project in
+// <claudeDir>/settings.json. Entries are appended next to existing hooks and
+// added only once. It reports whether settings.json changed.
+func AddProjectHooks(claudeDir, hookName string) (bool, error) {
+	contrib, err := ProjectHookSettings(claudeDir, hookName)
+	if err != nil || contrib == nil {
+		return false, err
+	}
+
+	settingsPath := filepath.Join(claudeDir, "settings.json")
+	settings, err := loadProjectSettings(settingsPath)
+	if err != nil {
+		return false, err
+	}
+	merged := additiveMerge(settings, contrib)
+	if reflect.DeepEqual(settings, merged) {
+		return false, nil
+	}
+	return true, writeJSONFile(settingsPath, merged)
+}
+
+// RemoveProjectHooks takes a hook item's entries back out of
+// <claudeDir>/settings.json; it must run before the item is deleted. Other
+// hooks are left untouched and settings.json is removed when nothing else is
+// left. It reports whether settings.json changed.
+func RemoveProjectHooks(claudeDir, hookName string) (bool, error) {
+	contrib, err := ProjectHookSettings(claudeDir, hookName)
+	if err != nil || contrib == nil {
+		return false, err
+	}
+
+	settingsPath := filepath.Join(claudeDir, "settings.json")
+	if _, err := os.Stat(settingsPath); os.IsNotExist(err) {
+		return false, nil
+	}
+	settings, err := loadProjectSettings(settingsPath)
+	if err != nil {
+		return false, err
+	}
+	before, err := json.Marshal(settings)
+	if err != nil {
+		return false, err
+	}
+	StripSettings(settings, contrib)
+	if after, _ := json.Marshal(settings); string(after) == string(before) {
+		return false, nil
+	}
+
+	if len(settings) == 0 {
+		return true, os.Remove(settingsPath)
+	}
+	return true, writeJSONFile(settingsPath, settings)
+}
+
+// loadProjectSettings reads a project settings.json; a missing file is empty
+func loadProjectSettings(path string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return settings, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = make(map[string]interface{})
+	}
+	return settings, nil
+}
@@ -0,0 +1,94 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddRemoveProjectHooks(t *testing.T) {
+	claudeDir := filepath.Join(t.TempDir(), ".claude")
+	hookDir := filepath.Join(claudeDir, "hooks", "lint")
+	if err := os.MkdirAll(hookDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	hooksJSON := `{"hooks":{"PostToolUse":[{"matcher":"Edit","hooks":[{"type":"command","command":"${CLAUDE_PLUGIN_ROOT}/scripts/lint.sh","timeout":30}]}]}}`
+	if err := os.WriteFile(filepath.Join(hookDir, "hooks.json"), []byte(hooksJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A hand-written hook of the same type must survive add and remove
+	settingsPath := filepath.Join(claudeDir, "settings.json")
+	existing := `{"model":"opus","hooks":{"PostToolUse":[{"hooks":[{"type":"command","command":"./fmt.sh"}]}]}}`
+	if err := os.WriteFile(settingsPath, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := AddProjectHooks(claudeDir, "lint")
+	if err != nil || !changed {
+		t.Fatalf("AddProjectHooks() = %v, %v", changed, err)
+	}
+	if changed, err := AddProjectHooks(claudeDir, "lint"); err != nil || changed {
+		t.Errorf("second AddProjectHooks() = %v, %v; want no change", changed, err)
+	}
+
+	settings := readSettings(t, claudeDir)
+	entries := settings["hooks"].(map[string]interface{})["PostToolUse"].([]interface{})
+	if len(entries) != 2 {
+		t.Fatalf("PostToolUse entries = %v, want hand-written + lint", entries)
+	}
+	cmd := entries[1].(map[string]interface{})["hooks"].([]interface{})[0].(map[string]interface{})["command"]
+	if cmd != `"${CLAUDE_PROJECT_DIR}/.claude/hooks/lint/scripts/lint.sh"` {
+		t.Errorf("command = %v", cmd)
+	}
+
+	if changed, err := RemoveProjectHooks(claudeDir, "lint"); err != nil || !changed {
+		t.Fatalf("RemoveProjectHooks() = %v, %v", changed, err)
+	}
+	settings = readSettings(t, claudeDir)
+	entries = settings["hooks"].(map[string]interface{})["PostToolUse"].([]interface{})
+	if len(entries) != 1 || settings["model"] != "opus" {
+		t.Errorf("settings after remove = %v", settings)
+	}
+}
+
+func TestRemoveProjectHooks_DeletesEmptySettings(t *testing.T) {
+	claudeDir := filepath.Join(t.TempDir(), ".claude")
+	hookDir := filepath.Join(claudeDir, "hooks", "greet")
+	if err := os.MkdirAll(hookDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	hooksJSON := `{"hooks":{"SessionStart":[{"hooks":[{"type":"command","command":"echo hi"}]}]}}`
+	if err := os.WriteFile(filepath.Join(hookDir, "hooks.json"), []byte(hooksJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := AddProjectHooks(claudeDir, "greet"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RemoveProjectHooks(claudeDir, "greet"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(claudeDir, "settings.json")); !os.IsNotExist(err) {
+		t.Errorf("settings.json created by ccp should be removed when empty, stat err = %v", err)
+	}
+}
+
+func TestResolveProjectHookRoot(t *testing.T) {
+	root := projectHookRoot("lint")
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"${CLAUDE_PLUGIN_ROOT}/lint.sh", `"${CLAUDE_PROJECT_DIR}/.claude/hooks/lint/lint.sh"`},
+		{`"${CLAUDE_PLUGIN_ROOT}/lint.sh" --fix`, `"${CLAUDE_PROJECT_DIR}/.claude/hooks/lint/lint.sh" --fix`},
+		{`node "${CLAUDE_PLUGIN_ROOT}"/index.js`, `node "${CLAUDE_PROJECT_DIR}/.claude/hooks/lint"/index.js`},
+		{"python3 ${CLAUDE_PLUGIN_ROOT}/a.py && ${CLAUDE_PLUGIN_ROOT}/b.sh", `python3 "${CLAUDE_PROJECT_DIR}/.claude/hooks/lint/a.py" && "${CLAUDE_PROJECT_DIR}/.claude/hooks/lint/b.sh"`},
+		{"echo hi", "echo hi"},
+	}
+	for _, tt := range tests {
+		if got := resolveProjectHookRoot(tt.command, root); got != tt.want {
+			t.Errorf("resolveProjectHookRoot(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
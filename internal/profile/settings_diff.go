@@ -0,0 +1,161 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// SettingsChange is one difference between two settings documents
+type SettingsChange struct {
+	Op      string      // "add", "remove" or "replace"
+	Path    string      // e.g. permissions.allow[3]
+	Pointer string      // RFC 6901 JSON pointer, e.g. /permissions/allow/3
+	Old     interface{} // value before (remove, replace)
+	New     interface{} // value after (add, replace)
+}
+
+// CompareSettings returns the structural differences that turn settings a
+// into b. Objects are compared key by key (sorted) and arrays element by
+// element, so a change deep inside is reported at its own path. Applied in
+// order, the changes form a valid JSON patch.
+func CompareSettings(a, b map[string]interface{}) []SettingsChange {
+	var changes []SettingsChange
+	compareValues(normalizeJSON(a), normalizeJSON(b), "", "", &changes)
+	return changes
+}
+
+// normalizeJSON converts typed values (e.g. generated hook entries) into the
+// generic maps and slices they serialize to, so they can be compared by path
+func normalizeJSON(settings map[string]interface{}) interface{} {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return settings
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return settings
+	}
+	return v
+}
+
+// ReplaceProfileDir returns settings with profileDir, absolute or
+// $HOME-based, replaced by placeholder in every string, so the settings of
+// two profiles compare without each one's own paths (e.g. hook commands)
+func ReplaceProfileDir(settings map[string]interface{}, profileDir, placeholder string) map[string]interface{} {
+	replacer := strings.NewReplacer(config.ToPortablePath(profileDir), placeholder, profileDir, placeholder)
+	replaced, _ := replaceStrings(normalizeJSON(settings), replacer).(map[string]interface{})
+	return replaced
+}
+
+func replaceStrings(v interface{}, replacer *strings.Replacer) interface{} {
+	switch v := v.(type) {
+	case string:
+		return replacer.Replace(v)
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = replaceStrings(value, replacer)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = replaceStrings(value, replacer)
+		}
+	}
+	return v
+}
+
+// JSONPatch renders changes as RFC 6902 operations
+func JSONPatch(changes []SettingsChange) []map[string]interface{} {
+	patch := make([]map[string]interface{}, 0, len(changes))
+	for _, c := range changes {
+		op := map[string]interface{}{"op": c.Op, "path": c.Pointer}
+		if c.Op != "remove" {
+			op["value"] = c.New
+		}
+		patch = append(patch, op)
+	}
+	return patch
+}
+
+func compareValues(a, b interface{}, path, pointer string, changes *[]SettingsChange) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			compareObjects(av, bv, path, pointer, changes)
+			return
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			compareArrays(av, bv, path, pointer, changes)
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, SettingsChange{Op: "replace", Path: path, Pointer: pointer, Old: a, New: b})
+	}
+}
+
+func compareObjects(a, b map[string]interface{}, path, pointer string, changes *[]SettingsChange) {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := joinKeyPath(path, k)
+		childPointer := pointer + "/" + escapePointer(k)
+		av, inA := a[k]
+		bv, inB := b[k]
+		switch {
+		case !inB:
+			*changes = append(*changes, SettingsChange{Op: "remove", Path: childPath, Pointer: childPointer, Old: av})
+		case !inA:
+			*changes = append(*changes, SettingsChange{Op: "add", Path: childPath, Pointer: childPointer, New: bv})
+		default:
+			compareValues(av, bv, childPath, childPointer, changes)
+		}
+	}
+}
+
+func compareArrays(a, b []interface{}, path, pointer string, changes *[]SettingsChange) {
+	common := min(len(a), len(b))
+	for i := 0; i < common; i++ {
+		compareValues(a[i], b[i], fmt.Sprintf("%s[%d]", path, i), pointer+"/"+strconv.Itoa(i), changes)
+	}
+	for i := common; i < len(b); i++ {
+		*changes = append(*changes, SettingsChange{Op: "add", Path: fmt.Sprintf("%s[%d]", path, i), Pointer: pointer + "/" + strconv.Itoa(i), New: b[i]})
+	}
+	// Remove from the end so earlier indexes stay valid when patching
+	for i := len(a) - 1; i >= common; i-- {
+		*changes = append(*changes, SettingsChange{Op: "remove", Path: fmt.Sprintf("%s[%d]", path, i), Pointer: pointer + "/" + strconv.Itoa(i), Old: a[i]})
+	}
+}
+
+// plainKey matches object keys that can be written as .key in a path
+var plainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+func joinKeyPath(path, key string) string {
+	if !plainKey.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,124 @@
+package profile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+)
+
+func parseSettings(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestCompareSettings(t *testing.T) {
+	a := parseSettings(t, `{
+		"model": "sonnet",
+		"env": {"DEBUG": "1", "a/b": "x"},
+		"permissions": {"allow": ["Read", "Edit", "Bash(ls)", "Bash(rm)"]}
+	}`)
+	b := parseSettings(t, `{
+		"model": "opus",
+		"env": {"a/b": "y"},
+		"permissions": {"allow": ["Read", "Write"], "deny": ["WebFetch"]}
+	}`)
+
+	changes := CompareSettings(a, b)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.Op+" "+c.Path+" "+c.Pointer)
+	}
+	want := []string{
+		`remove env.DEBUG /env/DEBUG`,
+		`replace env["a/b"] /env/a~1b`,
+		`replace model /model`,
+		`replace permissions.allow[1] /permissions/allow/1`,
+		`remove permissions.allow[3] /permissions/allow/3`,
+		`remove permissions.allow[2] /permissions/allow/2`,
+		`add permissions.deny /permissions/deny`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareSettings() =\n%q\nwant\n%q", got, want)
+	}
+
+	if changes := CompareSettings(a, a); len(changes) != 0 {
+		t.Errorf("identical settings reported %d changes", len(changes))
+	}
+
+	patch := JSONPatch(changes)
+	if patch[0]["op"] != "remove" || patch[0]["value"] != nil || patch[2]["value"] != "opus" {
+		t.Errorf("JSONPatch() = %v", patch)
+	}
+}
+
+func TestCompareSettings_SharedHookAcrossProfiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	paths := &config.Paths{CcpDir: tmpDir, HubDir: filepath.Join(tmpDir, "hub")}
+	manifest := &Manifest{Hub: HubLinks{Hooks: []string{"lint"}}}
+	hooksJSON := `{"hooks":{"Stop":[{"hooks":[{"type":"command","command":"${CLAUDE_PLUGIN_ROOT}/lint.sh"}]}]}}`
+
+	var settings []map[string]interface{}
+	for _, name := range []string{"dev", "work"} {
+		profileDir := filepath.Join(tmpDir, "profiles", name)
+		mustWrite(t, filepath.Join(profileDir, "hooks", "lint", "hooks.json"), hooksJSON)
+		generated, err := GenerateSettings(manifest, paths, profileDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		settings = append(settings, ReplaceProfileDir(generated, profileDir, "<profile>"))
+	}
+
+	if changes := CompareSettings(settings[0], settings[1]); len(changes) != 0 {
+		t.Errorf("shared hook reported as changed: %+v", changes)
+	}
+	stop := settings[0]["hooks"].(map[string]interface{})["Stop"].([]interface{})
+	command := stop[0].(map[string]interface{})["hooks"].([]interface{})[0].(map[string]interface{})["command"]
+	if command != "<profile>/hooks/lint/lint.sh" {
+		t.Errorf("command = %v", command)
+	}
+}
+
+func TestProjectSettings(t *testing.T) {
+	hubDir := t.TempDir()
+	profileDir := t.TempDir()
+	paths := &config.Paths{HubDir: hubDir}
+
+	if err := hub.NewTemplateManager(hubDir).Save(&hub.Template{Name: "base", Settings: map[string]interface{}{"model": "sonnet"}}); err != nil {
+		t.Fatal(err)
+	}
+	// A hook in the hub that is not linked into the profile yet
+	hookDir := filepath.Join(hubDir, "hooks", "lint")
+	if err := os.MkdirAll(hookDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	hooksJSON := `{"hooks":{"Stop":[{"hooks":[{"type":"command","command":"${CLAUDE_PLUGIN_ROOT}/lint.sh"}]}]}}`
+	if err := os.WriteFile(filepath.Join(hookDir, "hooks.json"), []byte(hooksJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := &Manifest{SettingsTemplate: "base", Hub: HubLinks{Hooks: []string{"lint"}}}
+
+	proj := &SettingsProjection{Templates: map[string]map[string]interface{}{"base": {"model": "opus"}}}
+	projected, err := ProjectSettings(manifest, paths, profileDir, proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projected["model"] != "opus" {
+		t.Errorf("pending template not applied: %v", projected)
+	}
+	hooks, _ := projected["hooks"].(map[config.HookType][]config.SettingsHookEntry)
+	stop := hooks[config.HookType("Stop")]
+	want := filepath.Join(profileDir, "hooks", "lint") + "/lint.sh"
+	if len(stop) != 1 || stop[0].Hooks[0].Command != want {
+		t.Errorf("unlinked hub hook projected as %v, want command %s", projected["hooks"], want)
+	}
+}
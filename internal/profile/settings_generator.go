@@ -14,14 +14,27 @@ import (
 // GenerateSettingsHooks generates the hooks section for settings.json from linked hub hooks
 // Uses $HOME-based absolute paths for portability
 func GenerateSettingsHooks(paths *config.Paths, profileDir string, manifest *Manifest) (map[config.HookType][]config.SettingsHookEntry, error) {
+	return generateSettingsHooks(paths, profileDir, manifest, false)
+}
+
+// generateSettingsHooks is GenerateSettingsHooks; with fromHub, hooks not yet
+// linked into the profile are read from the hub as if they were
+func generateSettingsHooks(paths *config.Paths, profileDir string, manifest *Manifest, fromHub bool) (map[config.HookType][]config.SettingsHookEntry, error) {
 	hooks := make(map[config.HookType][]config.SettingsHookEntry)
+	readHooksJSON := func(hookDir, hookName string) (*config.HooksJSON, error) {
+		hooksJSON, err := hub.GetHooksJSON(hookDir)
+		if err != nil && fromHub {
+			return hub.GetHooksJSON(paths.HubItemPath(config.HubHooks, hookName))
+		}
+		return hooksJSON, err
+	}
 	profileHooksDir := filepath.Join(profileDir, "hooks")
 
 	for _, hookName := range manifest.Hub.Hooks {
 		hookDir := filepath.Join(profileHooksDir, hookName)
 
 		// Try hooks.json first (official format)
-		hooksJSON, err := hub.GetHooksJSON(hookDir)
+		hooksJSON, err := readHooksJSON(hookDir, hookName)
 		if err == nil && hooksJSON != nil {
 			processHooksJSON(hooksJSON, hookDir, hooks)
 			continue
@@ -48,7 +61,7 @@ func GenerateSettingsHooks(paths *config.Paths, profileDir string, manifest *Man
 		}
 		for _, hookName := range bundle.Members.Hooks {
 			hookDir := filepath.Join(profileHooksDir, hookName)
-			if hooksJSON, err := hub.GetHooksJSON(hookDir); err == nil && hooksJSON != nil {
+			if hooksJSON, err := readHooksJSON(hookDir, hookName); err == nil && hooksJSON != nil {
 				processHooksJSON(hooksJSON, hookDir, hooks)
 			}
 		}
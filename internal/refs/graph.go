@@ -0,0 +1,164 @@
+// Package refs builds the graph of everything that references hub items:
+// profiles, bundles, projects, sources and plugins.
+package refs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/profile"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+// Kind is the type of thing referencing a hub item
+type Kind string
+
+const (
+	KindProfile Kind = "profile" // linked in profile.toml
+	KindBundle  Kind = "bundle"  // origin of a bundle member
+	KindProject Kind = "project" // copied into a project by 'ccp project add'
+	KindSource  Kind = "source"  // listed as installed by a source
+	KindPlugin  Kind = "plugin"  // component of an installed plugin
+)
+
+// kindOrder controls the order references are listed in
+var kindOrder = map[Kind]int{KindProfile: 0, KindBundle: 1, KindProject: 2, KindSource: 3, KindPlugin: 4}
+
+// Ref is one reference to a hub item
+type Ref struct {
+	Kind Kind
+	Name string // profile/bundle/plugin name, project dir or source ID
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%s %s", r.Kind, r.Name)
+}
+
+// Graph maps hub item keys ("skills/foo", "bundles/design") to their references
+type Graph struct {
+	refs map[string][]Ref
+}
+
+// Build collects references from every profile, bundle, tracked project,
+// source and plugin. Unreadable profiles, bundles and plugins are skipped.
+func Build(paths *config.Paths) (*Graph, error) {
+	g := &Graph{refs: make(map[string][]Ref)}
+
+	profiles, err := profile.NewManager(paths).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	for _, p := range profiles {
+		for _, itemType := range append(config.AllHubItemTypes(), config.HubBundles) {
+			for _, name := range p.Manifest.GetHubItems(itemType) {
+				g.Add(string(itemType)+"/"+name, Ref{Kind: KindProfile, Name: p.Name})
+			}
+		}
+	}
+
+	bundles, err := hub.ListBundles(paths.BundlesDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bundles: %w", err)
+	}
+	for _, b := range bundles {
+		for _, origin := range b.Origins {
+			g.Add(origin.Item, Ref{Kind: KindBundle, Name: b.Name})
+		}
+	}
+
+	projects, err := config.LoadProjects(paths.CcpDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load projects: %w", err)
+	}
+	for dir, project := range projects.Projects {
+		for _, item := range project.Items {
+			g.Add(item, Ref{Kind: KindProject, Name: dir})
+		}
+	}
+
+	registry, err := source.LoadRegistry(paths.RegistryPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load registry: %w", err)
+	}
+	for id, src := range registry.Sources {
+		for _, item := range src.Installed {
+			g.Add(item, Ref{Kind: KindSource, Name: id})
+		}
+	}
+
+	plugins, err := hub.ListPlugins(paths.PluginsDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plugins: %w", err)
+	}
+	for _, pm := range plugins {
+		for _, ref := range pm.Components.AllComponents() {
+			g.Add(ref.Type+"/"+ref.Name, Ref{Kind: KindPlugin, Name: pm.Name})
+		}
+	}
+
+	return g, nil
+}
+
+// Add records a reference to an item, ignoring duplicates
+func (g *Graph) Add(key string, ref Ref) {
+	for _, existing := range g.refs[key] {
+		if existing == ref {
+			return
+		}
+	}
+	g.refs[key] = append(g.refs[key], ref)
+}
+
+// Refs returns the references to an item, profiles first
+func (g *Graph) Refs(key string) []Ref {
+	refs := append([]Ref(nil), g.refs[key]...)
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Kind != refs[j].Kind {
+			return kindOrder[refs[i].Kind] < kindOrder[refs[j].Kind]
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	return refs
+}
+
+// Keys returns every referenced item key, sorted
+func (g *Graph) Keys() []string {
+	keys := make([]string, 0, len(g.refs))
+	for key := range g.refs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Names returns the names of the references of one kind
+func (g *Graph) Names(key string, kind Kind) []string {
+	var names []string
+	for _, ref := range g.Refs(key) {
+		if ref.Kind == kind {
+			names = append(names, ref.Name)
+		}
+	}
+	return names
+}
+
+// Reason summarizes why an item not linked in any profile is still in use,
+// e.g. "only referenced by project /src/app". Empty when the item has no
+// references at all.
+func (g *Graph) Reason(key string) string {
+	refs := g.Refs(key)
+	if len(refs) == 0 {
+		return ""
+	}
+	parts := make([]string, len(refs))
+	for i, ref := range refs {
+		parts[i] = ref.String()
+	}
+	if len(refs) == 1 {
+		return "only referenced by " + parts[0]
+	}
+	return "referenced by " + strings.Join(parts, ", ")
+}
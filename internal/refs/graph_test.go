@@ -0,0 +1,113 @@
+package refs
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+	"github.com/samhoang/ccp/internal/hub"
+	"github.com/samhoang/ccp/internal/profile"
+	"github.com/samhoang/ccp/internal/source"
+)
+
+func newTestPaths(t *testing.T) *config.Paths {
+	t.Helper()
+	ccpDir := t.TempDir()
+	paths := &config.Paths{
+		CcpDir:      ccpDir,
+		HubDir:      filepath.Join(ccpDir, "hub"),
+		ProfilesDir: filepath.Join(ccpDir, "profiles"),
+	}
+	for _, dir := range []string{paths.HubDir, paths.ProfilesDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return paths
+}
+
+func TestBuild_CollectsAllReferenceKinds(t *testing.T) {
+	paths := newTestPaths(t)
+
+	manifest := profile.NewManifest("dev", "")
+	manifest.SetHubItems(config.HubSkills, []string{"linked"})
+	manifest.SetHubItems(config.HubBundles, []string{"design"})
+	if _, err := profile.NewManager(paths).Create("dev", manifest); err != nil {
+		t.Fatalf("create profile: %v", err)
+	}
+
+	bundle := &hub.Bundle{Name: "design", Members: hub.ComponentList{Skills: []string{"ui"}}}
+	bundle.SetOrigin(hub.ComponentRef{Type: "skills", Name: "ui"}, hub.MemberOrigin{Item: "skills/ui"})
+	if err := os.MkdirAll(paths.BundleDir("design"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := bundle.Save(paths.BundlesDir()); err != nil {
+		t.Fatalf("save bundle: %v", err)
+	}
+
+	projects, _ := config.LoadProjects(paths.CcpDir)
+	projects.Track("/src/app", "skills/copied")
+	if err := projects.Save(paths.CcpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := source.NewRegistry(paths.CcpDir)
+	if err := registry.AddSource("me/tools", source.Source{Provider: "git", Installed: []string{"skills/installed"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	plugin := hub.NewPluginManifest("kit", "", "1.0.0", hub.GitHubSource{}, hub.ComponentList{Agents: []string{"helper.md"}})
+	if err := plugin.Save(paths.PluginsDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := Build(paths)
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want Ref
+	}{
+		{"skills/linked", Ref{Kind: KindProfile, Name: "dev"}},
+		{"bundles/design", Ref{Kind: KindProfile, Name: "dev"}},
+		{"skills/ui", Ref{Kind: KindBundle, Name: "design"}},
+		{"skills/copied", Ref{Kind: KindProject, Name: "/src/app"}},
+		{"skills/installed", Ref{Kind: KindSource, Name: "me/tools"}},
+		{"agents/helper.md", Ref{Kind: KindPlugin, Name: "kit"}},
+	}
+	for _, tt := range tests {
+		if refs := g.Refs(tt.key); !slices.Contains(refs, tt.want) {
+			t.Errorf("Refs(%s) = %v, want %v", tt.key, refs, tt.want)
+		}
+	}
+
+	if reason := g.Reason("skills/copied"); reason != "only referenced by project /src/app" {
+		t.Errorf("Reason = %q", reason)
+	}
+	if refs := g.Refs("skills/unknown"); len(refs) != 0 {
+		t.Errorf("unreferenced item has refs: %v", refs)
+	}
+}
+
+func TestRefs_OrdersProfilesFirst(t *testing.T) {
+	g := &Graph{refs: make(map[string][]Ref)}
+	g.Add("skills/a", Ref{Kind: KindSource, Name: "me/tools"})
+	g.Add("skills/a", Ref{Kind: KindProfile, Name: "work"})
+	g.Add("skills/a", Ref{Kind: KindProfile, Name: "dev"})
+	g.Add("skills/a", Ref{Kind: KindProfile, Name: "dev"})
+
+	want := []Ref{{KindProfile, "dev"}, {KindProfile, "work"}, {KindSource, "me/tools"}}
+	if got := g.Refs("skills/a"); !slices.Equal(got, want) {
+		t.Errorf("Refs = %v, want %v", got, want)
+	}
+	if reason := g.Reason("skills/a"); reason != "referenced by profile dev, profile work, source me/tools" {
+		t.Errorf("Reason = %q", reason)
+	}
+}
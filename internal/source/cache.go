@@ -0,0 +1,302 @@
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotCached is returned in offline mode for anything missing from the cache
+var ErrNotCached = fmt.Errorf("not in offline cache (run 'ccp cache fill' while online)")
+
+// ErrOffline is returned by checks that always need the network
+var ErrOffline = fmt.Errorf("remote checks are unavailable offline")
+
+// Cache is the local package cache under ~/.ccp/cache: bare git mirrors of
+// git sources, downloaded archives of http sources, and registry lookups.
+// Online, providers and registries fill it as a side effect, except for git
+// mirrors, which are only kept up to date by Fill or when mirror is set;
+// offline, they resolve only from it.
+//
+// Layout:
+//
+//	git/<host>/<path>.git          bare mirrors (served over dumb HTTP)
+//	archives/<hash>-<file>         downloaded .tar.gz/.zip files
+//	registry/<name>/packages/*.json  PackageDetails by package ID
+//	registry/<name>/search/*.json    search results by query
+type Cache struct {
+	dir     string
+	offline bool
+	mirror  bool // clone and update git sources through mirrors while online
+}
+
+// NewCache returns a cache rooted at dir. With mirror set, online installs
+// and updates also refresh the git mirrors; otherwise only Fill does.
+func NewCache(dir string, offline, mirror bool) *Cache {
+	return &Cache{dir: dir, offline: offline, mirror: mirror}
+}
+
+// activeCache is used by providers and registries when set
+var activeCache *Cache
+
+// UseCache makes providers and registries read through c; nil disables caching
+func UseCache(c *Cache) {
+	activeCache = c
+}
+
+// ActiveCache returns the cache set by UseCache, or nil
+func ActiveCache() *Cache {
+	return activeCache
+}
+
+// Dir returns the cache root
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Offline reports whether network access is disabled
+func (c *Cache) Offline() bool {
+	return c.offline
+}
+
+// Mirrors reports whether git sources are cloned and updated through mirrors
+func (c *Cache) Mirrors() bool {
+	return c.offline || c.mirror
+}
+
+// MirrorPath returns where the bare mirror of a git URL is kept
+func (c *Cache) MirrorPath(gitURL string) string {
+	return filepath.Join(c.dir, "git", filepath.FromSlash(mirrorKey(gitURL)))
+}
+
+// mirrorKey turns a git URL into a relative "host/owner/repo.git" path
+func mirrorKey(gitURL string) string {
+	key := normalizeGitURL(gitURL)
+	if u, err := url.Parse(key); err == nil && u.Host != "" {
+		key = u.Host + u.Path
+	} else {
+		// scp-like git@host:owner/repo.git, or a local path
+		key = strings.TrimPrefix(key, "git@")
+		key = strings.Replace(key, ":", "/", 1)
+	}
+	key = strings.ReplaceAll(key, ":", "_")
+	return strings.TrimPrefix(path.Clean("/"+key), "/")
+}
+
+// SyncMirror returns the path of an up-to-date bare mirror of gitURL,
+// cloning or fetching it first unless offline
+func (c *Cache) SyncMirror(ctx context.Context, gitURL string) (string, error) {
+	gitURL = normalizeGitURL(gitURL)
+	mirror := c.MirrorPath(gitURL)
+	_, statErr := os.Stat(mirror)
+	if c.offline {
+		if statErr != nil {
+			return "", &SourceError{Op: "offline clone", Source: gitURL, Err: ErrNotCached}
+		}
+		return mirror, nil
+	}
+
+	if statErr == nil {
+		if out, err := exec.CommandContext(ctx, "git", "-C", mirror, "remote", "update", "--prune").CombinedOutput(); err != nil {
+			return "", &SourceError{Op: "mirror fetch", Source: gitURL, Err: gitError(err, out)}
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(mirror), 0755); err != nil {
+			return "", err
+		}
+		if out, err := exec.CommandContext(ctx, "git", "clone", "--mirror", "--quiet", gitURL, mirror).CombinedOutput(); err != nil {
+			os.RemoveAll(mirror)
+			return "", &SourceError{Op: "mirror clone", Source: gitURL, Err: gitError(err, out)}
+		}
+	}
+	// Lets 'ccp cache serve' expose the mirror over git's dumb HTTP protocol
+	if out, err := exec.CommandContext(ctx, "git", "-C", mirror, "update-server-info").CombinedOutput(); err != nil {
+		return "", &SourceError{Op: "mirror update", Source: gitURL, Err: gitError(err, out)}
+	}
+	return mirror, nil
+}
+
+// Fill stores everything needed to install a source offline: its git mirror
+// or archive, plus a package record listing its items so lookups and
+// searches resolve without the registry
+func (c *Cache) Fill(ctx context.Context, id string, src Source, contents []string) error {
+	if c.offline {
+		return fmt.Errorf("cannot fill the cache in offline mode")
+	}
+	switch src.Provider {
+	case "git":
+		if _, err := c.SyncMirror(ctx, src.URL); err != nil {
+			return err
+		}
+	case "http":
+		if _, _, err := download(ctx, c, src.URL, FetchOptions{}); err != nil {
+			return err
+		}
+	default:
+		return &SourceError{Op: "cache fill", Source: id, Err: ErrProviderNotFound}
+	}
+
+	registry := src.Registry
+	if registry == "" {
+		registry = "manual"
+	}
+	// Keep what an earlier registry lookup recorded (description, tags)
+	details := &PackageDetails{PackageInfo: PackageInfo{ID: id, Name: path.Base(id), Registry: registry}}
+	readCacheJSON(c.packagePath(registry, id), details)
+	details.DownloadURL = src.URL
+	details.ProviderType = src.Provider
+	details.Ref = src.Ref
+	details.Contents = contents
+	return c.SavePackage(registry, id, details)
+}
+
+// ArchivePath returns where the download of an archive URL is kept
+func (c *Cache) ArchivePath(archiveURL string) string {
+	sum := sha256.Sum256([]byte(archiveURL))
+	name := path.Base(strings.SplitN(archiveURL, "?", 2)[0])
+	return filepath.Join(c.dir, "archives", hex.EncodeToString(sum[:6])+"-"+name)
+}
+
+// SavePackage records registry details for a package ID
+func (c *Cache) SavePackage(registry, id string, details *PackageDetails) error {
+	return writeCacheJSON(c.packagePath(registry, id), details)
+}
+
+// LoadPackage returns cached details for a package ID, looking in the given
+// registry first and then in every other cached registry
+func (c *Cache) LoadPackage(registry, id string) (*PackageDetails, error) {
+	var details PackageDetails
+	if err := readCacheJSON(c.packagePath(registry, id), &details); err == nil {
+		return &details, nil
+	}
+	matches, _ := filepath.Glob(c.packagePath("*", id))
+	sort.Strings(matches)
+	for _, match := range matches {
+		if err := readCacheJSON(match, &details); err == nil {
+			return &details, nil
+		}
+	}
+	return nil, &SourceError{Op: "offline lookup", Source: id, Err: ErrNotCached}
+}
+
+// Packages returns every cached package, one per ID
+func (c *Cache) Packages() ([]PackageDetails, error) {
+	matches, err := filepath.Glob(filepath.Join(c.dir, "registry", "*", "packages", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	seen := make(map[string]bool)
+	var packages []PackageDetails
+	for _, match := range matches {
+		var details PackageDetails
+		if err := readCacheJSON(match, &details); err != nil {
+			continue
+		}
+		id := strings.ReplaceAll(strings.TrimSuffix(filepath.Base(match), ".json"), "--", "/")
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		details.ID = id
+		packages = append(packages, details)
+	}
+	return packages, nil
+}
+
+// SearchPackages matches a query against cached package IDs, names,
+// descriptions, tags and contents
+func (c *Cache) SearchPackages(query string, limit int) ([]PackageInfo, error) {
+	packages, err := c.Packages()
+	if err != nil {
+		return nil, err
+	}
+	terms := strings.Fields(strings.ToLower(query))
+	var results []PackageInfo
+	for _, pkg := range packages {
+		text := strings.ToLower(strings.Join(append([]string{pkg.ID, pkg.Name, pkg.Description},
+			append(pkg.Tags, pkg.Contents...)...), " "))
+		matched := true
+		for _, term := range terms {
+			if !strings.Contains(text, term) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			results = append(results, pkg.PackageInfo)
+		}
+		if limit > 0 && len(results) == limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// SaveSearch records the results of a registry search
+func (c *Cache) SaveSearch(registry, query string, limit int, results []PackageInfo) error {
+	return writeCacheJSON(c.searchPath(registry, query, limit), results)
+}
+
+// LoadSearch returns recorded results of an identical registry search
+func (c *Cache) LoadSearch(registry, query string, limit int) ([]PackageInfo, bool) {
+	var results []PackageInfo
+	if err := readCacheJSON(c.searchPath(registry, query, limit), &results); err != nil {
+		return nil, false
+	}
+	return results, true
+}
+
+func (c *Cache) packagePath(registry, id string) string {
+	return filepath.Join(c.dir, "registry", registry, "packages", strings.ReplaceAll(id, "/", "--")+".json")
+}
+
+func (c *Cache) searchPath(registry, query string, limit int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", strings.ToLower(strings.TrimSpace(query)), limit)))
+	return filepath.Join(c.dir, "registry", registry, "search", hex.EncodeToString(sum[:8])+".json")
+}
+
+// cachePackageID strips registry prefixes and refs from a package identifier
+func cachePackageID(identifier string) (id, ref string) {
+	id = strings.TrimPrefix(strings.TrimPrefix(identifier, "skills.sh/"), "github:")
+	if idx := strings.Index(id, "@"); idx != -1 {
+		id, ref = id[:idx], id[idx+1:]
+	}
+	return id, ref
+}
+
+func writeCacheJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func readCacheJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// gitError attaches git's output to a failed command's error
+func gitError(err error, output []byte) error {
+	if detail := strings.TrimSpace(string(output)); detail != "" {
+		return fmt.Errorf("%w: %s", err, detail)
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package source
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Handler serves the cache as a skills.sh-compatible mirror. Point
+// skillssh.base_url of another machine at it:
+//
+//	/api/search?q=&limit=            search over cached packages
+//	/api/v1/packages/<owner>/<name>  package details, repo_url rewritten to
+//	                                 the mirror when the content is cached
+//	/git/<host>/<path>.git           bare mirrors (git dumb HTTP protocol)
+//	/archives/<file>                 cached archives
+func (c *Cache) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/search", c.serveSearch)
+	mux.HandleFunc("/api/v1/packages/", c.servePackage)
+	mux.Handle("/git/", http.StripPrefix("/git/", http.FileServer(http.Dir(filepath.Join(c.dir, "git")))))
+	mux.Handle("/archives/", http.StripPrefix("/archives/", http.FileServer(http.Dir(filepath.Join(c.dir, "archives")))))
+	return mux
+}
+
+func (c *Cache) serveSearch(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	results, err := c.SearchPackages(r.URL.Query().Get("q"), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type skill struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		TopSource string `json:"topSource"`
+		Installs  int    `json:"installs"`
+	}
+	skills := make([]skill, 0, len(results))
+	for _, pkg := range results {
+		skills = append(skills, skill{ID: pkg.ID, Name: pkg.Name, TopSource: pkg.ID})
+	}
+	writeJSONResponse(w, map[string]interface{}{"skills": skills})
+}
+
+func (c *Cache) servePackage(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/packages/"), "/")
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.NotFound(w, r)
+		return
+	}
+	details, err := c.LoadPackage("skills.sh", id)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	name := details.Name
+	if name == "" {
+		name = parts[1]
+	}
+	writeJSONResponse(w, map[string]interface{}{
+		"slug":        parts[1],
+		"owner":       parts[0],
+		"name":        name,
+		"description": details.Description,
+		"version":     details.Version,
+		"tags":        details.Tags,
+		"repo_url":    c.mirrorURL(r, details),
+		"repo_ref":    details.Ref,
+		"contents":    details.Contents,
+	})
+}
+
+// mirrorURL points a package's download at this server when its git mirror
+// or archive is cached, so clients never reach the original host
+func (c *Cache) mirrorURL(r *http.Request, details *PackageDetails) string {
+	base := "http://" + r.Host
+	switch details.ProviderType {
+	case "git":
+		mirror := c.MirrorPath(details.DownloadURL)
+		if _, err := os.Stat(mirror); err == nil {
+			return base + "/git/" + mirrorKey(details.DownloadURL)
+		}
+	case "http":
+		archive := c.ArchivePath(details.DownloadURL)
+		if _, err := os.Stat(archive); err == nil {
+			return base + "/archives/" + filepath.Base(archive)
+		}
+	}
+	return details.DownloadURL
+}
+
+func writeJSONResponse(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,234 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// setupUpstreamRepo creates a git repository standing in for a remote and
+// returns its URL as a local path
+func setupUpstreamRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	// normalizeGitURL appends .git, so name the repo accordingly
+	repo := filepath.Join(t.TempDir(), "upstream.git")
+	createTestFile(t, repo, "skills/offline/SKILL.md", "# offline")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"config", "user.email", "dev@example.com"},
+		{"config", "user.name", "Dev"},
+		{"add", "-A"},
+		{"commit", "-q", "-m", "initial"},
+	} {
+		if _, err := gitOutput(repo, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	return repo
+}
+
+func useTestCache(t *testing.T, c *Cache) {
+	t.Helper()
+	prev := activeCache
+	UseCache(c)
+	t.Cleanup(func() { UseCache(prev) })
+}
+
+func TestGitFetch_OfflineClonesFromMirror(t *testing.T) {
+	upstream := setupUpstreamRepo(t)
+	cacheDir := t.TempDir()
+	provider := &GitProvider{}
+	ctx := context.Background()
+
+	useTestCache(t, NewCache(cacheDir, false, true))
+	if err := provider.Fetch(ctx, upstream, filepath.Join(t.TempDir(), "online"), FetchOptions{}); err != nil {
+		t.Fatalf("online Fetch: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(NewCache(cacheDir, false, true).MirrorPath(upstream), "info", "refs")); err != nil {
+		t.Fatalf("mirror not created for dumb HTTP serving: %v", err)
+	}
+
+	// Upstream disappears; offline installs still work from the mirror
+	os.RemoveAll(upstream)
+	useTestCache(t, NewCache(cacheDir, true, false))
+	dest := filepath.Join(t.TempDir(), "offline")
+	if err := provider.Fetch(ctx, upstream, dest, FetchOptions{}); err != nil {
+		t.Fatalf("offline Fetch: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "skills", "offline", "SKILL.md")); err != nil {
+		t.Errorf("offline clone missing content: %v", err)
+	}
+	if origin, _ := gitOutput(dest, "remote", "get-url", "origin"); origin != normalizeGitURL(upstream) {
+		t.Errorf("origin = %q, want the original URL", origin)
+	}
+	if _, err := provider.Update(ctx, dest, UpdateOptions{URL: upstream}); err != nil {
+		t.Errorf("offline Update: %v", err)
+	}
+
+	err := provider.Fetch(ctx, "https://github.com/nobody/uncached", filepath.Join(t.TempDir(), "x"), FetchOptions{})
+	if !errors.Is(err, ErrNotCached) {
+		t.Errorf("offline Fetch of uncached repo = %v, want ErrNotCached", err)
+	}
+}
+
+func TestGitFetch_OnlineSkipsMirrorByDefault(t *testing.T) {
+	upstream := setupUpstreamRepo(t)
+	cache := NewCache(t.TempDir(), false, false)
+	useTestCache(t, cache)
+	provider := &GitProvider{}
+	ctx := context.Background()
+
+	dest := filepath.Join(t.TempDir(), "online")
+	if err := provider.Fetch(ctx, upstream, dest, FetchOptions{}); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if _, err := provider.Update(ctx, dest, UpdateOptions{URL: upstream}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := os.Stat(cache.MirrorPath(upstream)); !os.IsNotExist(err) {
+		t.Errorf("online install without mirror opt-in created a mirror: %v", err)
+	}
+
+	// 'ccp cache fill' still mirrors explicitly
+	if err := cache.Fill(ctx, "me/upstream", Source{Provider: "git", URL: upstream}, nil); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if _, err := os.Stat(cache.MirrorPath(upstream)); err != nil {
+		t.Errorf("Fill did not create the mirror: %v", err)
+	}
+}
+
+func TestGitFetch_FailedCloneLeavesNoDirectory(t *testing.T) {
+	upstream := setupUpstreamRepo(t)
+	useTestCache(t, nil)
+	parent := t.TempDir()
+	dest := filepath.Join(parent, "partial")
+
+	// A checkout hook that outlives the timeout stands in for a slow clone
+	// killed by the source timeout after git created the directory
+	hooks := t.TempDir()
+	createTestFile(t, hooks, "post-checkout", "#!/bin/sh\nsleep 2\n")
+	if err := os.Chmod(filepath.Join(hooks, "post-checkout"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "core.hooksPath")
+	t.Setenv("GIT_CONFIG_VALUE_0", hooks)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := (&GitProvider{}).Fetch(ctx, upstream, dest, FetchOptions{}); err == nil {
+		t.Fatal("expected error for a clone killed by the timeout")
+	}
+	if entries, _ := os.ReadDir(parent); len(entries) != 0 {
+		t.Errorf("failed clone left %d entries behind", len(entries))
+	}
+}
+
+func TestCachedRegistry_OfflineLookupAndSearch(t *testing.T) {
+	cache := NewCache(t.TempDir(), false, false)
+	if err := cache.Fill(context.Background(), "me/tools", Source{Provider: "ftp"}, nil); err == nil {
+		t.Error("expected error for an unknown provider")
+	}
+	details := &PackageDetails{
+		PackageInfo:  PackageInfo{ID: "me/tools", Name: "tools", Description: "Debugging helpers"},
+		DownloadURL:  "https://github.com/me/tools.git",
+		ProviderType: "git",
+		Contents:     []string{"skills/debugging"},
+	}
+	if err := cache.SavePackage("github", "me/tools", details); err != nil {
+		t.Fatal(err)
+	}
+
+	useTestCache(t, NewCache(cache.Dir(), true, false))
+	reg := GetRegistryProvider("skills.sh")
+	got, err := reg.Get(context.Background(), "me/tools")
+	if err != nil {
+		t.Fatalf("offline Get: %v", err)
+	}
+	if got.DownloadURL != details.DownloadURL {
+		t.Errorf("Get = %+v", got)
+	}
+	if _, err := reg.Get(context.Background(), "me/missing"); !errors.Is(err, ErrNotCached) {
+		t.Errorf("Get of uncached package = %v, want ErrNotCached", err)
+	}
+
+	results, err := reg.Search(context.Background(), "debugging", SearchOptions{Limit: 10})
+	if err != nil || len(results) != 1 || results[0].ID != "me/tools" {
+		t.Errorf("offline Search = %v, %v", results, err)
+	}
+}
+
+func TestCacheHandler_ServesSkillsShAPI(t *testing.T) {
+	cache := NewCache(t.TempDir(), false, false)
+	cache.SavePackage("skills.sh", "me/tools", &PackageDetails{
+		PackageInfo:  PackageInfo{ID: "me/tools", Name: "tools"},
+		DownloadURL:  "https://github.com/me/tools.git",
+		ProviderType: "git",
+	})
+	// Pretend the mirror exists
+	if err := os.MkdirAll(cache.MirrorPath("https://github.com/me/tools.git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(cache.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/packages/me/tools")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var pkg struct {
+		Slug    string `json:"slug"`
+		Owner   string `json:"owner"`
+		RepoURL string `json:"repo_url"`
+	}
+	json.NewDecoder(resp.Body).Decode(&pkg)
+	if pkg.Slug != "tools" || pkg.Owner != "me" || pkg.RepoURL != server.URL+"/git/github.com/me/tools.git" {
+		t.Errorf("package = %+v", pkg)
+	}
+
+	resp, err = http.Get(server.URL + "/api/search?q=tools&limit=5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var search struct {
+		Skills []struct {
+			TopSource string `json:"topSource"`
+		} `json:"skills"`
+	}
+	json.NewDecoder(resp.Body).Decode(&search)
+	if len(search.Skills) != 1 || search.Skills[0].TopSource != "me/tools" {
+		t.Errorf("search = %+v", search)
+	}
+
+	if resp, _ := http.Get(server.URL + "/api/v1/packages/me/missing"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("missing package status = %d", resp.StatusCode)
+	}
+}
+
+func TestMirrorKey(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/me/tools":       "github.com/me/tools.git",
+		"github.com/me/tools.git":           "github.com/me/tools.git",
+		"git@github.com:me/tools.git":       "github.com/me/tools.git",
+		"http://localhost:8765/git/a/b.git": "localhost_8765/git/a/b.git",
+	}
+	for url, want := range tests {
+		if got := mirrorKey(url); got != want {
+			t.Errorf("mirrorKey(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
@@ -28,13 +28,14 @@ func NewInstaller(paths *config.Paths, registry *Registry) *Installer {
 
 // pluginJSON represents .claude-plugin/plugin.json structure
 type pluginJSON struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Version     string `json:"version"`
-	Skills      any    `json:"skills"`   // string or []string
-	Commands    any    `json:"commands"` // string or []string
-	Agents      any    `json:"agents"`   // string or []string
-	Hooks       any    `json:"hooks"`    // string or object
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Version      string `json:"version"`
+	Skills       any    `json:"skills"`       // string or []string
+	Commands     any    `json:"commands"`     // string or []string
+	Agents       any    `json:"agents"`       // string or []string
+	OutputStyles any    `json:"outputStyles"` // string or []string
+	Hooks        any    `json:"hooks"`        // string or object
 }
 
 type marketplaceJSON struct {
@@ -339,7 +340,7 @@ var codexDirs = []string{".agents", ".codex"}
 
 // DiscoverItems scans a source directory for installable items
 // Supports multiple structures:
-// 1. Root-level: skills/, agents/, commands/, hooks/
+// 1. Root-level: skills/, agents/, commands/, hooks/, output-styles/, statuslines/
 // 2. Claude Code plugin: .claude-plugin/plugin.json
 // 3. Codex format: .agents/skills/, .codex/skills/
 // 4. Codex plugin: .codex-plugin/plugin.json, .codex-plugin/marketplace.json
@@ -349,7 +350,7 @@ func (i *Installer) DiscoverItems(sourceDir string) []string {
 	var items []string
 	seen := make(map[string]bool)
 
-	itemTypes := []string{"skills", "agents", "commands", "rules", "hooks", "settings-templates"}
+	itemTypes := []string{"skills", "agents", "commands", "rules", "hooks", "output-styles", "statuslines", "settings-templates"}
 
 	// Helper to add items without duplicates
 	addItem := func(item string) {
@@ -453,6 +454,9 @@ func (i *Installer) scanItemDir(typeDir, itemType string, addItem func(string))
 	for _, entry := range entries {
 		if entry.IsDir() {
 			addItem(fmt.Sprintf("%s/%s", itemType, entry.Name()))
+		} else if itemType == string(config.HubStatuslines) && !strings.HasPrefix(entry.Name(), ".") {
+			// Statusline scripts are items as they are, extension included
+			addItem(fmt.Sprintf("%s/%s", itemType, entry.Name()))
 		} else if isValidItemFile(entry.Name()) {
 			// Also scan for flat files (e.g., agents/foo.md, commands/bar.md)
 			name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
@@ -528,9 +532,10 @@ func (i *Installer) discoverFromPluginJSON(sourceDir string, data []byte, _ []st
 
 	// Parse custom paths from plugin.json
 	pathFields := map[string]any{
-		"skills":   plugin.Skills,
-		"commands": plugin.Commands,
-		"agents":   plugin.Agents,
+		"skills":        plugin.Skills,
+		"commands":      plugin.Commands,
+		"agents":        plugin.Agents,
+		"output-styles": plugin.OutputStyles,
 	}
 
 	for itemType, pathVal := range pathFields {
@@ -503,3 +503,33 @@ func TestCopyDir_SkipsGit(t *testing.T) {
 		t.Errorf("expected .git to be skipped, but it exists (err=%v)", err)
 	}
 }
+
+func TestDiscoverItems_OutputStylesAndStatuslines(t *testing.T) {
+	sourceDir := t.TempDir()
+	hubDir := t.TempDir()
+
+	createTestFile(t, sourceDir, "output-styles/terse.md", "---\ndescription: Short answers\n---\n")
+	createTestFile(t, sourceDir, "statuslines/git/statusline.json", `{"command": "${CLAUDE_PLUGIN_ROOT}/git.sh"}`)
+	createTestFile(t, sourceDir, "statuslines/minimal.sh", "#!/bin/sh\n")
+
+	paths := &config.Paths{HubDir: hubDir}
+	registry := NewRegistry(t.TempDir())
+	installer := NewInstaller(paths, registry)
+	items := installer.DiscoverItems(sourceDir)
+
+	found := make(map[string]bool)
+	for _, item := range items {
+		found[item] = true
+	}
+	// Statusline scripts keep their extension so they resolve to the file
+	for _, want := range []string{"output-styles/terse", "statuslines/git", "statuslines/minimal.sh"} {
+		if !found[want] {
+			t.Errorf("expected %s, got: %v", want, items)
+		}
+	}
+
+	srcPath, dstItem, err := installer.resolveItemPaths(sourceDir, "statuslines/minimal.sh")
+	if err != nil || dstItem != "statuslines/minimal.sh" || !strings.HasSuffix(srcPath, "statuslines/minimal.sh") {
+		t.Errorf("resolveItemPaths() = %s, %s, %v", srcPath, dstItem, err)
+	}
+}
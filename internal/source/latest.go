@@ -0,0 +1,116 @@
+package source
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LatestTTL is how long a CheckLatest result is trusted by cheap readers
+// such as 'ccp status'
+const LatestTTL = 24 * time.Hour
+
+// LatestCache stores CheckLatest results per registry source in
+// <cache>/latest.json. An entry is only reused while the source's local
+// version is unchanged and the entry is younger than the caller's TTL.
+type LatestCache struct {
+	mu      sync.Mutex
+	path    string
+	Sources map[string]LatestEntry `json:"sources"`
+}
+
+// LatestEntry is one cached check
+type LatestEntry struct {
+	Local           string    `json:"local"`
+	Latest          string    `json:"latest"`
+	UpdateAvailable bool      `json:"update_available"`
+	Checked         time.Time `json:"checked"`
+}
+
+// LoadLatestCache reads the check cache from cacheDir; a missing or
+// unreadable file yields an empty cache
+func LoadLatestCache(cacheDir string) *LatestCache {
+	c := &LatestCache{path: filepath.Join(cacheDir, "latest.json")}
+	if err := readCacheJSON(c.path, c); err != nil || c.Sources == nil {
+		c.Sources = make(map[string]LatestEntry)
+	}
+	return c
+}
+
+// Get returns the cached check for source id if it still describes src and
+// is younger than ttl
+func (c *LatestCache) Get(id string, src Source, ttl time.Duration) (LatestEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.Sources[id]
+	if !ok || entry.Local != localVersion(src) || time.Since(entry.Checked) > ttl {
+		return LatestEntry{}, false
+	}
+	return entry, true
+}
+
+// Put records a check result for source id
+func (c *LatestCache) Put(id string, src Source, info *LatestInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Sources[id] = LatestEntry{
+		Local:           localVersion(src),
+		Latest:          info.Latest,
+		UpdateAvailable: info.UpdateAvailable,
+		Checked:         time.Now(),
+	}
+}
+
+// Check returns a cached result younger than ttl, or asks the source's
+// provider and caches the answer
+func (c *LatestCache) Check(ctx context.Context, id string, src Source, ttl time.Duration) (*LatestInfo, error) {
+	if entry, ok := c.Get(id, src, ttl); ok {
+		return &LatestInfo{Current: entry.Local, Latest: entry.Latest, UpdateAvailable: entry.UpdateAvailable}, nil
+	}
+	provider := GetProvider(src.Provider)
+	if provider == nil {
+		return nil, &SourceError{Op: "check latest", Source: id, Err: ErrProviderNotFound}
+	}
+	info, err := provider.CheckLatest(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	c.Put(id, src, info)
+	return info, nil
+}
+
+// Outdated lists, sorted, the registry sources with a fresh cached check
+// reporting an update, and the time of the oldest such check
+func (c *LatestCache) Outdated(registry *Registry, ttl time.Duration) ([]string, time.Time) {
+	var ids []string
+	var oldest time.Time
+	for _, entry := range registry.ListSources() {
+		cached, ok := c.Get(entry.ID, entry.Source, ttl)
+		if !ok || !cached.UpdateAvailable {
+			continue
+		}
+		ids = append(ids, entry.ID)
+		if oldest.IsZero() || cached.Checked.Before(oldest) {
+			oldest = cached.Checked
+		}
+	}
+	sort.Strings(ids)
+	return ids, oldest
+}
+
+// Save writes the cache. Stale entries are kept; Get ignores them.
+func (c *LatestCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return writeCacheJSON(c.path, c)
+}
+
+// localVersion is what a source was fetched at: its commit or its checksum
+func localVersion(src Source) string {
+	if src.Commit != "" {
+		return src.Commit
+	}
+	return src.Checksum
+}
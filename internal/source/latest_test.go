@@ -0,0 +1,120 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGitCheckLatest(t *testing.T) {
+	upstream := setupUpstreamRepo(t)
+	provider := &GitProvider{}
+	ctx := context.Background()
+
+	head, err := gitOutput(upstream, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := provider.CheckLatest(ctx, Source{URL: upstream, Commit: head})
+	if err != nil {
+		t.Fatalf("CheckLatest: %v", err)
+	}
+	if info.Latest != head || info.UpdateAvailable {
+		t.Errorf("CheckLatest at HEAD = %+v, want latest %s and no update", info, head)
+	}
+
+	createTestFile(t, upstream, "skills/offline/SKILL.md", "# offline v2")
+	for _, args := range [][]string{{"add", "-A"}, {"commit", "-q", "-m", "v2"}} {
+		if _, err := gitOutput(upstream, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err = provider.CheckLatest(ctx, Source{URL: upstream, Ref: "main", Commit: head[:7]})
+	if err != nil {
+		t.Fatalf("CheckLatest: %v", err)
+	}
+	if info.Latest == head || !info.UpdateAvailable {
+		t.Errorf("CheckLatest after new commit = %+v, want update", info)
+	}
+
+	useTestCache(t, NewCache(t.TempDir(), true, false))
+	if _, err := provider.CheckLatest(ctx, Source{URL: upstream}); !errors.Is(err, ErrOffline) {
+		t.Errorf("offline CheckLatest error = %v, want ErrOffline", err)
+	}
+}
+
+func TestHTTPCheckLatest(t *testing.T) {
+	etag := `"v1"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/etag.tar.gz":
+			w.Header().Set("ETag", etag)
+		case "/plain.zip":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+		}
+		w.Write([]byte("archive"))
+	}))
+	defer server.Close()
+
+	provider := &HTTPProvider{}
+	ctx := context.Background()
+
+	info, err := provider.CheckLatest(ctx, Source{URL: server.URL + "/etag.tar.gz", Checksum: `etag:"v1"`})
+	if err != nil {
+		t.Fatalf("CheckLatest: %v", err)
+	}
+	if info.UpdateAvailable {
+		t.Errorf("unchanged ETag reported an update: %+v", info)
+	}
+	etag = `"v2"`
+	info, err = provider.CheckLatest(ctx, Source{URL: server.URL + "/etag.tar.gz", Checksum: `etag:"v1"`})
+	if err != nil || !info.UpdateAvailable || info.Latest != `etag:"v2"` {
+		t.Errorf("changed ETag = %+v, %v; want update to v2", info, err)
+	}
+
+	// Without HEAD support or validators the content hash is used
+	info, err = provider.CheckLatest(ctx, Source{URL: server.URL + "/plain.zip"})
+	if err != nil {
+		t.Fatalf("CheckLatest: %v", err)
+	}
+	const sum = "sha256:0eb3e36bfb24dcd9bb1d1bece1531216b59539a8fde17ee80224af0653c92aa3"
+	if info.Latest != sum || !info.UpdateAvailable {
+		t.Errorf("hashed CheckLatest = %+v, want %s and an update (no baseline)", info, sum)
+	}
+}
+
+func TestLatestCache(t *testing.T) {
+	dir := t.TempDir()
+	src := Source{Provider: "git", Commit: "abc123"}
+
+	c := LoadLatestCache(dir)
+	c.Put("o/r", src, &LatestInfo{Latest: "def456", UpdateAvailable: true})
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	c = LoadLatestCache(dir)
+	entry, ok := c.Get("o/r", src, time.Hour)
+	if !ok || entry.Latest != "def456" || !entry.UpdateAvailable {
+		t.Fatalf("Get after reload = %+v, %v", entry, ok)
+	}
+	if _, ok := c.Get("o/r", src, 0); ok {
+		t.Error("entry older than the TTL was returned")
+	}
+	if _, ok := c.Get("o/r", Source{Provider: "git", Commit: "def456"}, time.Hour); ok {
+		t.Error("entry returned after the source was updated locally")
+	}
+
+	registry := &Registry{Sources: map[string]Source{"o/r": src, "x/y": {Provider: "git", Commit: "zzz"}}}
+	ids, checked := c.Outdated(registry, time.Hour)
+	if len(ids) != 1 || ids[0] != "o/r" || checked.IsZero() {
+		t.Errorf("Outdated() = %v, %v", ids, checked)
+	}
+}
@@ -0,0 +1,201 @@
+package source
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// MarketplaceSpec describes a Claude Code marketplace to generate: a
+// .claude-plugin/marketplace.json listing plugins stored under plugins/<name>/
+type MarketplaceSpec struct {
+	Name        string
+	Owner       string
+	Description string
+	Plugins     []MarketplacePlugin
+}
+
+// MarketplacePlugin is one plugin of a generated marketplace
+type MarketplacePlugin struct {
+	Name        string
+	Description string
+	Version     string
+	Items       []MarketplaceItem
+}
+
+// MarketplaceItem is a hub item copied into a plugin
+type MarketplaceItem struct {
+	Type string // skills, agents, commands, hooks, rules
+	Name string // hub item name ("foo", "reviewer.md")
+	Path string // hub item path on disk
+}
+
+// BuildMarketplace writes spec into dir in the layout that
+// discoverFromMarketplace reads back: plugin components keep their <type>/
+// directories, each plugin gets a .claude-plugin/plugin.json, and the hooks.json
+// of every hook item is merged into the plugin's hooks/hooks.json with
+// ${CLAUDE_PLUGIN_ROOT} pointing at the item's directory. Returns warnings
+// about content Claude Code plugins cannot use.
+func BuildMarketplace(dir string, spec MarketplaceSpec) ([]string, error) {
+	if spec.Name == "" {
+		return nil, fmt.Errorf("marketplace name is required")
+	}
+	if len(spec.Plugins) == 0 {
+		return nil, fmt.Errorf("a marketplace needs at least one plugin")
+	}
+
+	var warnings []string
+	var entries []interface{}
+	seen := make(map[string]bool)
+	for _, plugin := range spec.Plugins {
+		if err := validatePluginName(plugin.Name); err != nil {
+			return warnings, err
+		}
+		if seen[plugin.Name] {
+			return warnings, fmt.Errorf("duplicate plugin name: %s", plugin.Name)
+		}
+		seen[plugin.Name] = true
+
+		rel := "plugins/" + plugin.Name
+		pluginDir := filepath.Join(dir, filepath.FromSlash(rel))
+		pluginWarnings, err := buildPlugin(pluginDir, plugin)
+		warnings = append(warnings, pluginWarnings...)
+		if err != nil {
+			return warnings, fmt.Errorf("plugin %s: %w", plugin.Name, err)
+		}
+
+		entry := map[string]interface{}{"name": plugin.Name, "source": "./" + rel}
+		if plugin.Description != "" {
+			entry["description"] = plugin.Description
+		}
+		if plugin.Version != "" {
+			entry["version"] = plugin.Version
+		}
+		entries = append(entries, entry)
+	}
+
+	marketplace := map[string]interface{}{
+		"name":    spec.Name,
+		"owner":   map[string]interface{}{"name": spec.Owner},
+		"plugins": entries,
+	}
+	if spec.Description != "" {
+		marketplace["metadata"] = map[string]interface{}{"description": spec.Description}
+	}
+	return warnings, writeJSONObject(filepath.Join(dir, ".claude-plugin", "marketplace.json"), marketplace)
+}
+
+// buildPlugin copies a plugin's items and writes its manifests
+func buildPlugin(pluginDir string, plugin MarketplacePlugin) ([]string, error) {
+	if err := os.RemoveAll(pluginDir); err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	hooks := config.NewHooksJSON()
+	for _, item := range plugin.Items {
+		dst := filepath.Join(pluginDir, item.Type, item.Name)
+		if err := replaceTree(item.Path, dst, false); err != nil {
+			return warnings, fmt.Errorf("failed to copy %s/%s: %w", item.Type, item.Name, err)
+		}
+
+		switch item.Type {
+		case "hooks":
+			ok, err := mergePluginHooks(hooks, dst, item.Name)
+			if err != nil {
+				return warnings, err
+			}
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("%s: hooks/%s has no hooks.json and will not run as a plugin hook", plugin.Name, item.Name))
+			}
+		case "rules":
+			warnings = append(warnings, fmt.Sprintf("%s: rules/%s is only usable through ccp (Claude Code plugins do not load rules)", plugin.Name, item.Name))
+		}
+	}
+
+	if len(hooks.Hooks) > 0 {
+		if err := writeJSONFile(filepath.Join(pluginDir, "hooks", "hooks.json"), hooks); err != nil {
+			return warnings, err
+		}
+	}
+
+	manifest := map[string]interface{}{"name": plugin.Name}
+	if plugin.Description != "" {
+		manifest["description"] = plugin.Description
+	}
+	if plugin.Version != "" {
+		manifest["version"] = plugin.Version
+	}
+	return warnings, writeJSONObject(filepath.Join(pluginDir, ".claude-plugin", "plugin.json"), manifest)
+}
+
+// mergePluginHooks adds the hooks.json of a hook item copied to hookDir into
+// the plugin-wide hooks, rebasing ${CLAUDE_PLUGIN_ROOT} onto the item's
+// directory. Reports false when the item has no hooks.json.
+func mergePluginHooks(hooks *config.HooksJSON, hookDir, name string) (bool, error) {
+	if info, err := os.Stat(hookDir); err != nil || !info.IsDir() {
+		return false, err
+	}
+	data, err := os.ReadFile(filepath.Join(hookDir, "hooks.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	var itemHooks config.HooksJSON
+	if err := json.Unmarshal(data, &itemHooks); err != nil {
+		return false, fmt.Errorf("invalid hooks.json in hooks/%s: %w", name, err)
+	}
+
+	events := make([]string, 0, len(itemHooks.Hooks))
+	for event := range itemHooks.Hooks {
+		events = append(events, string(event))
+	}
+	sort.Strings(events)
+	for _, event := range events {
+		for _, entry := range itemHooks.Hooks[config.HookType(event)] {
+			for i := range entry.Hooks {
+				entry.Hooks[i].Command = strings.ReplaceAll(entry.Hooks[i].Command,
+					"${CLAUDE_PLUGIN_ROOT}", "${CLAUDE_PLUGIN_ROOT}/hooks/"+name)
+			}
+			hooks.Hooks[config.HookType(event)] = append(hooks.Hooks[config.HookType(event)], entry)
+		}
+	}
+	return true, nil
+}
+
+// validatePluginName enforces the kebab-case names Claude Code expects
+func validatePluginName(name string) error {
+	if name == "" {
+		return fmt.Errorf("plugin name is required")
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			return fmt.Errorf("invalid plugin name %q: use lowercase letters, digits and dashes", name)
+		}
+	}
+	return nil
+}
+
+// writeJSONFile writes v as indented JSON without HTML escaping, so shell
+// commands like "a && b" stay readable
+func writeJSONFile(path string, v interface{}) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
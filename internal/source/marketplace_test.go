@@ -0,0 +1,102 @@
+package source
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+func TestBuildMarketplace_RoundTripsThroughDiscover(t *testing.T) {
+	hubDir := t.TempDir()
+	createTestFile(t, hubDir, "skills/debugging/SKILL.md", "# debugging")
+	createTestFile(t, hubDir, "skills/debugging/source.yaml", "type: local\n")
+	createTestFile(t, hubDir, "agents/reviewer.md", "# reviewer")
+	createTestFile(t, hubDir, "hooks/fmt/hooks.json",
+		`{"hooks": {"PostToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "${CLAUDE_PLUGIN_ROOT}/fmt.sh && true"}]}]}}`)
+	createTestFile(t, hubDir, "hooks/fmt/fmt.sh", "#!/bin/sh\n")
+	createTestFile(t, hubDir, "bundles/design/skills/ui/SKILL.md", "# ui")
+
+	dir := t.TempDir()
+	spec := MarketplaceSpec{
+		Name:  "tools",
+		Owner: "Dev",
+		Plugins: []MarketplacePlugin{
+			{Name: "design", Description: "Design kit", Version: "1.2.0", Items: []MarketplaceItem{
+				{Type: "skills", Name: "ui", Path: filepath.Join(hubDir, "bundles", "design", "skills", "ui")},
+			}},
+			{Name: "tools", Version: "1.0.0", Items: []MarketplaceItem{
+				{Type: "skills", Name: "debugging", Path: filepath.Join(hubDir, "skills", "debugging")},
+				{Type: "agents", Name: "reviewer.md", Path: filepath.Join(hubDir, "agents", "reviewer.md")},
+				{Type: "hooks", Name: "fmt", Path: filepath.Join(hubDir, "hooks", "fmt")},
+			}},
+		},
+	}
+	warnings, err := BuildMarketplace(dir, spec)
+	if err != nil {
+		t.Fatalf("BuildMarketplace: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	paths := &config.Paths{CcpDir: t.TempDir()}
+	items := NewInstaller(paths, NewRegistry(paths.CcpDir)).DiscoverItems(dir)
+	for _, want := range []string{"skills/ui", "skills/debugging", "agents/reviewer", "hooks/fmt"} {
+		if !slices.Contains(items, want) {
+			t.Errorf("DiscoverItems = %v, missing %s", items, want)
+		}
+	}
+
+	var marketplace struct {
+		Name    string `json:"name"`
+		Plugins []struct {
+			Name    string `json:"name"`
+			Source  string `json:"source"`
+			Version string `json:"version"`
+		} `json:"plugins"`
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, ".claude-plugin", "marketplace.json"))
+	if err := json.Unmarshal(data, &marketplace); err != nil {
+		t.Fatalf("marketplace.json: %v", err)
+	}
+	if marketplace.Name != "tools" || len(marketplace.Plugins) != 2 ||
+		marketplace.Plugins[0].Source != "./plugins/design" || marketplace.Plugins[0].Version != "1.2.0" {
+		t.Errorf("marketplace.json = %+v", marketplace)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "plugins", "tools", "skills", "debugging", "source.yaml")); !os.IsNotExist(err) {
+		t.Error("source.yaml should not be exported")
+	}
+
+	data, _ = os.ReadFile(filepath.Join(dir, "plugins", "tools", "hooks", "hooks.json"))
+	if !strings.Contains(string(data), `"${CLAUDE_PLUGIN_ROOT}/hooks/fmt/fmt.sh && true"`) {
+		t.Errorf("hook command not rebased onto the item directory:\n%s", data)
+	}
+}
+
+func TestBuildMarketplace_WarnsAboutRules(t *testing.T) {
+	hubDir := t.TempDir()
+	createTestFile(t, hubDir, "rules/style.md", "# style")
+
+	warnings, err := BuildMarketplace(t.TempDir(), MarketplaceSpec{
+		Name: "tools",
+		Plugins: []MarketplacePlugin{{Name: "tools", Items: []MarketplaceItem{
+			{Type: "rules", Name: "style.md", Path: filepath.Join(hubDir, "rules", "style.md")},
+		}}},
+	})
+	if err != nil {
+		t.Fatalf("BuildMarketplace: %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "rules/style.md") {
+		t.Errorf("warnings = %v", warnings)
+	}
+
+	if _, err := BuildMarketplace(t.TempDir(), MarketplaceSpec{Name: "tools", Plugins: []MarketplacePlugin{{Name: "Bad Name"}}}); err == nil {
+		t.Error("expected error for an invalid plugin name")
+	}
+}
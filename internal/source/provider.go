@@ -13,6 +13,10 @@ type Provider interface {
 	// Update refreshes an existing source at sourcePath
 	Update(ctx context.Context, sourcePath string, opts UpdateOptions) (*UpdateResult, error)
 
+	// CheckLatest reports whether a newer version of src is published,
+	// without touching the local copy
+	CheckLatest(ctx context.Context, src Source) (*LatestInfo, error)
+
 	// CanHandle returns true if this provider can handle the given URL
 	CanHandle(url string) bool
 }
@@ -24,6 +28,13 @@ type UpdateResult struct {
 	NewCommit string // new commit (for git)
 }
 
+// LatestInfo is the result of Provider.CheckLatest
+type LatestInfo struct {
+	Current         string // version of the local copy (commit, ETag, ...); empty if unknown
+	Latest          string // version currently published upstream
+	UpdateAvailable bool
+}
+
 // providers is the registry of available providers
 var providers = make(map[string]Provider)
 
@@ -1,10 +1,12 @@
 package source
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
@@ -45,11 +47,34 @@ func (p *GitProvider) Fetch(ctx context.Context, url string, destPath string, op
 		}
 	}
 
+	// With mirrors in use, clone from the local mirror and point origin back
+	// at the real URL. Online, a mirror failure falls back to a direct clone.
+	cloneURL := url
+	if c := activeCache; c != nil && c.Mirrors() {
+		mirror, err := c.SyncMirror(ctx, url)
+		if err == nil {
+			cloneURL = "file://" + filepath.ToSlash(mirror)
+		} else if c.Offline() {
+			return err
+		}
+	}
+
+	// Clone next to destPath and move it into place only when complete, so a
+	// clone killed by a timeout never leaves a checkout that later runs pull
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return &SourceError{Op: "git clone", Source: url, Err: err}
+	}
+	tmpPath, err := os.MkdirTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+".clone-")
+	if err != nil {
+		return &SourceError{Op: "git clone", Source: url, Err: err}
+	}
+	defer os.RemoveAll(tmpPath)
+
 	args := []string{"clone", "--depth", "1"}
 	if opts.Ref != "" {
 		args = append(args, "--branch", opts.Ref)
 	}
-	args = append(args, url, destPath)
+	args = append(args, cloneURL, tmpPath)
 
 	cmd := exec.CommandContext(ctx, "git", args...)
 	if !opts.Progress {
@@ -63,6 +88,14 @@ func (p *GitProvider) Fetch(ctx context.Context, url string, destPath string, op
 	if err := cmd.Run(); err != nil {
 		return &SourceError{Op: "git clone", Source: url, Err: err}
 	}
+	if cloneURL != url {
+		if out, err := exec.Command("git", "-C", tmpPath, "remote", "set-url", "origin", url).CombinedOutput(); err != nil {
+			return &SourceError{Op: "git clone", Source: url, Err: gitError(err, out)}
+		}
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return &SourceError{Op: "git clone", Source: url, Err: err}
+	}
 	return nil
 }
 
@@ -87,6 +120,15 @@ func (p *GitProvider) Update(ctx context.Context, sourcePath string, opts Update
 	if opts.Ref != "" {
 		fetchArgs = append(fetchArgs, opts.Ref)
 	}
+	if c := activeCache; c != nil && c.Mirrors() {
+		args, err := p.mirrorFetchArgs(ctx, c, sourcePath, opts)
+		if err != nil {
+			return nil, err
+		}
+		if args != nil {
+			fetchArgs = args
+		}
+	}
 	fetchCmd := exec.CommandContext(ctx, "git", fetchArgs...)
 	if output, err := fetchCmd.CombinedOutput(); err != nil {
 		detail := strings.TrimSpace(string(output))
@@ -121,6 +163,93 @@ func (p *GitProvider) Update(ctx context.Context, sourcePath string, opts Update
 	return result, nil
 }
 
+// CheckLatest resolves src.Ref (default HEAD) with git ls-remote and compares
+// it with the recorded commit, or the checkout's HEAD when none is recorded
+func (p *GitProvider) CheckLatest(ctx context.Context, src Source) (*LatestInfo, error) {
+	url := normalizeGitURL(src.URL)
+	if c := activeCache; c != nil && c.Offline() {
+		return nil, &SourceError{Op: "git ls-remote", Source: url, Err: ErrOffline}
+	}
+
+	current := src.Commit
+	if current == "" && src.Path != "" {
+		current = p.getCommit(src.Path)
+	}
+	ref := src.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "ls-remote", url, ref)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, &SourceError{Op: "git ls-remote", Source: url, Err: gitError(err, stderr.Bytes())}
+	}
+	latest := parseLsRemote(output)
+	if latest == "" {
+		return nil, &SourceError{Op: "git ls-remote", Source: url, Err: fmt.Errorf("no commit found for ref %s", ref)}
+	}
+
+	sameCommit := current != "" && (strings.HasPrefix(latest, current) || strings.HasPrefix(current, latest))
+	return &LatestInfo{Current: current, Latest: latest, UpdateAvailable: !sameCommit}, nil
+}
+
+// parseLsRemote returns the commit of the first ref listed by git ls-remote,
+// preferring the peeled commit ("<tag>^{}") of an annotated tag
+func parseLsRemote(output []byte) string {
+	first := ""
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		if strings.HasSuffix(fields[1], "^{}") {
+			return fields[0]
+		}
+		if first == "" {
+			first = fields[0]
+		}
+	}
+	return first
+}
+
+// mirrorFetchArgs refreshes the cached mirror of a source. Offline it returns
+// fetch arguments that update the origin/* refs from the mirror instead of
+// the network; online it returns nil and the normal fetch runs.
+func (p *GitProvider) mirrorFetchArgs(ctx context.Context, c *Cache, sourcePath string, opts UpdateOptions) ([]string, error) {
+	url := opts.URL
+	if url == "" {
+		out, err := exec.Command("git", "-C", sourcePath, "remote", "get-url", "origin").Output()
+		if err != nil {
+			return nil, &SourceError{Op: "git update", Source: sourcePath, Err: fmt.Errorf("cannot determine origin URL: %w", err)}
+		}
+		url = strings.TrimSpace(string(out))
+	}
+
+	mirror, err := c.SyncMirror(ctx, url)
+	if !c.Offline() {
+		return nil, nil // a stale mirror does not block the online update
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	branch := opts.Ref
+	if branch == "" {
+		out, err := exec.Command("git", "-C", mirror, "symbolic-ref", "--short", "HEAD").Output()
+		if err != nil {
+			return nil, &SourceError{Op: "offline update", Source: url, Err: err}
+		}
+		branch = strings.TrimSpace(string(out))
+	}
+	branch = strings.TrimPrefix(branch, "origin/")
+	refspec := fmt.Sprintf("+refs/heads/%s:refs/remotes/origin/%s", branch, branch)
+	return []string{"-C", sourcePath, "fetch", "--depth", "1", "file://" + filepath.ToSlash(mirror), refspec}, nil
+}
+
 func (p *GitProvider) getCommit(repoPath string) string {
 	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "HEAD")
 	output, err := cmd.Output()
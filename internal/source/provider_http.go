@@ -6,6 +6,7 @@ import (
 	"compress/gzip"
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
@@ -36,16 +37,66 @@ func (p *HTTPProvider) CanHandle(url string) bool {
 }
 
 func (p *HTTPProvider) Fetch(ctx context.Context, url string, destPath string, opts FetchOptions) error {
-	tmpFile, err := os.CreateTemp("", "ccp-download-*")
+	archive, cleanup, err := download(ctx, activeCache, url, opts)
 	if err != nil {
-		return &SourceError{Op: "http download", Source: url, Err: err}
+		return err
+	}
+	defer cleanup()
+
+	if err := os.MkdirAll(destPath, 0755); err != nil {
+		return &SourceError{Op: "http extract", Source: url, Err: err}
+	}
+
+	lower := strings.ToLower(url)
+	if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") {
+		f, err := os.Open(archive)
+		if err != nil {
+			return &SourceError{Op: "http extract", Source: url, Err: err}
+		}
+		defer f.Close()
+		if err := extractTarGz(f, destPath); err != nil {
+			return &SourceError{Op: "http extract", Source: url, Err: err}
+		}
+	} else if strings.HasSuffix(lower, ".zip") {
+		if err := extractZip(archive, destPath); err != nil {
+			return &SourceError{Op: "http extract", Source: url, Err: err}
+		}
 	}
-	defer os.Remove(tmpFile.Name())
+
+	return nil
+}
+
+// download fetches url to a local file: the archive in cache c when set
+// (required offline), else a temp file removed by cleanup.
+func download(ctx context.Context, c *Cache, url string, opts FetchOptions) (string, func(), error) {
+	noop := func() {}
+	tmpDir := ""
+	cached := ""
+	if c != nil {
+		cached = c.ArchivePath(url)
+		if c.Offline() {
+			if _, err := os.Stat(cached); err != nil {
+				return "", noop, &SourceError{Op: "offline download", Source: url, Err: ErrNotCached}
+			}
+			return cached, noop, nil
+		}
+		tmpDir = filepath.Dir(cached)
+		if err := os.MkdirAll(tmpDir, 0755); err != nil {
+			return "", noop, &SourceError{Op: "http download", Source: url, Err: err}
+		}
+	}
+
+	tmpFile, err := os.CreateTemp(tmpDir, "ccp-download-*")
+	if err != nil {
+		return "", noop, &SourceError{Op: "http download", Source: url, Err: err}
+	}
+	cleanup := func() { os.Remove(tmpFile.Name()) }
 	defer tmpFile.Close()
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return &SourceError{Op: "http download", Source: url, Err: err}
+		cleanup()
+		return "", noop, &SourceError{Op: "http download", Source: url, Err: err}
 	}
 
 	for k, v := range opts.Headers {
@@ -54,45 +105,97 @@ func (p *HTTPProvider) Fetch(ctx context.Context, url string, destPath string, o
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return &SourceError{Op: "http download", Source: url, Err: err}
+		cleanup()
+		return "", noop, &SourceError{Op: "http download", Source: url, Err: err}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return &SourceError{Op: "http download", Source: url,
+		cleanup()
+		return "", noop, &SourceError{Op: "http download", Source: url,
 			Err: fmt.Errorf("status %d", resp.StatusCode)}
 	}
 
-	hash := sha256.New()
-	writer := io.MultiWriter(tmpFile, hash)
-	if _, err := io.Copy(writer, resp.Body); err != nil {
-		return &SourceError{Op: "http download", Source: url, Err: err}
+	if _, err := io.Copy(tmpFile, resp.Body); err != nil {
+		cleanup()
+		return "", noop, &SourceError{Op: "http download", Source: url, Err: err}
+	}
+	if err := tmpFile.Close(); err != nil {
+		cleanup()
+		return "", noop, &SourceError{Op: "http download", Source: url, Err: err}
 	}
 
-	if _, err := tmpFile.Seek(0, 0); err != nil {
-		return &SourceError{Op: "http extract", Source: url, Err: err}
+	if cached != "" {
+		if err := os.Rename(tmpFile.Name(), cached); err != nil {
+			cleanup()
+			return "", noop, &SourceError{Op: "http download", Source: url, Err: err}
+		}
+		return cached, noop, nil
 	}
+	return tmpFile.Name(), cleanup, nil
+}
 
-	if err := os.MkdirAll(destPath, 0755); err != nil {
-		return &SourceError{Op: "http extract", Source: url, Err: err}
+func (p *HTTPProvider) Update(ctx context.Context, sourcePath string, opts UpdateOptions) (*UpdateResult, error) {
+	return &UpdateResult{Updated: false}, nil
+}
+
+// CheckLatest compares the archive's ETag or Last-Modified header with the
+// recorded src.Checksum. When the server sends neither, the archive is
+// downloaded and identified by its SHA-256.
+func (p *HTTPProvider) CheckLatest(ctx context.Context, src Source) (*LatestInfo, error) {
+	if c := activeCache; c != nil && c.Offline() {
+		return nil, &SourceError{Op: "http check", Source: src.URL, Err: ErrOffline}
+	}
+	latest, err := remoteVersion(ctx, src.URL)
+	if err != nil {
+		return nil, &SourceError{Op: "http check", Source: src.URL, Err: err}
 	}
+	return &LatestInfo{
+		Current:         src.Checksum,
+		Latest:          latest,
+		UpdateAvailable: src.Checksum != latest,
+	}, nil
+}
 
-	lower := strings.ToLower(url)
-	if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") {
-		if err := extractTarGz(tmpFile, destPath); err != nil {
-			return &SourceError{Op: "http extract", Source: url, Err: err}
+// remoteVersion identifies the current content at url as "etag:<value>",
+// "modified:<date>" or "sha256:<hex>"
+func remoteVersion(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			return "etag:" + etag, nil
 		}
-	} else if strings.HasSuffix(lower, ".zip") {
-		if err := extractZip(tmpFile.Name(), destPath); err != nil {
-			return &SourceError{Op: "http extract", Source: url, Err: err}
+		if modified := resp.Header.Get("Last-Modified"); modified != "" {
+			return "modified:" + modified, nil
 		}
 	}
 
-	return nil
-}
-
-func (p *HTTPProvider) Update(ctx context.Context, sourcePath string, opts UpdateOptions) (*UpdateResult, error) {
-	return &UpdateResult{Updated: false}, nil
+	// HEAD unsupported or no validators: hash the content instead
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d", resp.StatusCode)
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, resp.Body); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(hash.Sum(nil)), nil
 }
 
 func extractTarGz(r io.Reader, destPath string) error {
@@ -0,0 +1,465 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// PublishOptions controls how a hub item is published to a source
+type PublishOptions struct {
+	Branch  string // branch to commit on (default ccp/publish-<type>-<name>)
+	Message string // commit message (default "Publish <type>/<name> from ccp")
+	Plugin  string // marketplace plugin to publish into, when there are several
+	Push    bool   // push the branch to origin after committing
+}
+
+// PublishResult describes what Publish did
+type PublishResult struct {
+	Dest      string   // item path relative to the source root
+	Branch    string   // branch the commit was made on
+	Commit    string   // new commit SHA ("" when nothing changed)
+	Manifests []string // plugin.json/marketplace.json files updated
+	Pushed    bool
+}
+
+// Publisher copies hub items back into git source checkouts — the reverse of
+// Installer.Install
+type Publisher struct {
+	paths    *config.Paths
+	registry *Registry
+}
+
+// NewPublisher creates a new publisher
+func NewPublisher(paths *config.Paths, registry *Registry) *Publisher {
+	return &Publisher{paths: paths, registry: registry}
+}
+
+// Publish copies the hub item at srcPath into the checkout of sourceID and
+// commits it on a branch with the user's git identity. item is the hub key
+// ("skills/foo", "agents/bar.md"). An item the source already provides is
+// overwritten in place; a new one goes where DiscoverItems will find it: the
+// type dirs of plugin.json, of the marketplace plugin, or at the root.
+// The checkout is returned to its original branch afterwards, so
+// 'ccp source update' keeps tracking upstream.
+func (p *Publisher) Publish(sourceID, item, srcPath string, opts PublishOptions) (*PublishResult, error) {
+	sourceDir, err := p.checkout(sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(item, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid item format: %s", item)
+	}
+	itemType, itemName := parts[0], parts[1]
+
+	dest, pluginDir, err := p.publishPath(sourceDir, itemType, itemName, opts.Plugin)
+	if err != nil {
+		return nil, &SourceError{Op: "publish", Source: sourceID, Err: err}
+	}
+
+	result := &PublishResult{Branch: opts.Branch}
+	if result.Branch == "" {
+		result.Branch = fmt.Sprintf("ccp/publish-%s-%s", itemType, strings.TrimSuffix(itemName, filepath.Ext(itemName)))
+	}
+	message := opts.Message
+	if message == "" {
+		message = fmt.Sprintf("Publish %s from ccp", item)
+	}
+
+	return result, p.commit(sourceID, sourceDir, result, message, opts.Push, func() error {
+		if err := replaceTree(srcPath, dest, dest == sourceDir); err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(sourceDir, dest)
+		result.Dest = filepath.ToSlash(rel)
+
+		updated, err := registerInManifests(sourceDir, pluginDir, itemType, dest)
+		if err != nil {
+			return err
+		}
+		result.Manifests = updated
+		return nil
+	})
+}
+
+// PublishBundle publishes a bundle directory as a Claude Code plugin under
+// plugins/<name>/ (members keep their <type>/ layout), writing its
+// .claude-plugin/plugin.json and adding it to the root marketplace.json when
+// the source has one.
+func (p *Publisher) PublishBundle(sourceID, name, description, version, bundleDir string, opts PublishOptions) (*PublishResult, error) {
+	sourceDir, err := p.checkout(sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PublishResult{Branch: opts.Branch}
+	if result.Branch == "" {
+		result.Branch = "ccp/publish-bundle-" + name
+	}
+	message := opts.Message
+	if message == "" {
+		message = fmt.Sprintf("Publish bundle %s from ccp", name)
+	}
+
+	return result, p.commit(sourceID, sourceDir, result, message, opts.Push, func() error {
+		rel := "plugins/" + name
+		dest := filepath.Join(sourceDir, filepath.FromSlash(rel))
+		if err := replaceTree(bundleDir, dest, false); err != nil {
+			return err
+		}
+		result.Dest = rel
+
+		manifest := map[string]interface{}{"name": name}
+		pluginPath := filepath.Join(dest, ".claude-plugin", "plugin.json")
+		if existing, err := readJSONObject(pluginPath); err == nil {
+			manifest = existing
+		}
+		if description != "" {
+			manifest["description"] = description
+		}
+		if version != "" {
+			manifest["version"] = version
+		}
+		if err := writeJSONObject(pluginPath, manifest); err != nil {
+			return err
+		}
+
+		marketplacePath := filepath.Join(sourceDir, ".claude-plugin", "marketplace.json")
+		marketplace, err := readJSONObject(marketplacePath)
+		if err != nil {
+			return nil // no marketplace to register in
+		}
+		plugins, _ := marketplace["plugins"].([]interface{})
+		for _, entry := range plugins {
+			if m, ok := entry.(map[string]interface{}); ok && m["name"] == name {
+				if version != "" {
+					m["version"] = version
+				}
+				result.Manifests = append(result.Manifests, ".claude-plugin/marketplace.json")
+				return writeJSONObject(marketplacePath, marketplace)
+			}
+		}
+		entry := map[string]interface{}{"name": name, "source": "./" + rel}
+		if description != "" {
+			entry["description"] = description
+		}
+		if version != "" {
+			entry["version"] = version
+		}
+		marketplace["plugins"] = append(plugins, entry)
+		result.Manifests = append(result.Manifests, ".claude-plugin/marketplace.json")
+		return writeJSONObject(marketplacePath, marketplace)
+	})
+}
+
+// checkout returns the git checkout of a source
+func (p *Publisher) checkout(sourceID string) (string, error) {
+	src, err := p.registry.GetSource(sourceID)
+	if err != nil {
+		return "", err
+	}
+	if src.Provider != "git" {
+		return "", &SourceError{Op: "publish", Source: sourceID,
+			Err: fmt.Errorf("only git sources can be published to (provider: %s)", src.Provider)}
+	}
+	sourceDir := p.paths.SourceDir(sourceID)
+	if _, err := os.Stat(filepath.Join(sourceDir, ".git")); err != nil {
+		return "", &SourceError{Op: "publish", Source: sourceID,
+			Err: fmt.Errorf("source checkout not found: %s", sourceDir)}
+	}
+	return sourceDir, nil
+}
+
+// commit switches the checkout to result.Branch, applies write, commits
+// everything it changed and switches back to the original branch. On any
+// failure the checkout is restored and a branch created for it deleted.
+func (p *Publisher) commit(sourceID, sourceDir string, result *PublishResult, message string, push bool, write func() error) (retErr error) {
+	fail := func(op string, err error) error {
+		return &SourceError{Op: op, Source: sourceID, Err: err}
+	}
+
+	if email, _ := gitOutput(sourceDir, "config", "user.email"); email == "" {
+		return fail("publish", fmt.Errorf("git identity not configured: set user.name and user.email with 'git config --global'"))
+	}
+	if status, err := gitOutput(sourceDir, "status", "--porcelain"); err != nil {
+		return fail("git status", err)
+	} else if status != "" {
+		return fail("publish", fmt.Errorf("source checkout has uncommitted changes: %s", sourceDir))
+	}
+
+	original, err := gitOutput(sourceDir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return fail("git rev-parse", err)
+	}
+	if original == "HEAD" {
+		// Detached (e.g. a pinned ref): come back to the same commit
+		if original, err = gitOutput(sourceDir, "rev-parse", "HEAD"); err != nil {
+			return fail("git rev-parse", err)
+		}
+	}
+	// Reuse the branch when it exists, so repeated publishes stack up
+	created := true
+	checkoutArgs := []string{"checkout", "-b", result.Branch}
+	if _, err := gitOutput(sourceDir, "rev-parse", "--verify", "--quiet", "refs/heads/"+result.Branch); err == nil {
+		created = false
+		checkoutArgs = []string{"checkout", result.Branch}
+	}
+	if _, err := gitOutput(sourceDir, checkoutArgs...); err != nil {
+		return fail("git checkout", err)
+	}
+	base, err := gitOutput(sourceDir, "rev-parse", "HEAD")
+	if err != nil {
+		gitOutput(sourceDir, "checkout", "--force", original)
+		return fail("git rev-parse", err)
+	}
+	defer func() {
+		if retErr == nil {
+			gitOutput(sourceDir, "checkout", "--force", original)
+			return
+		}
+		// Leave the checkout as it was: drop anything written or committed,
+		// go back to the original branch and remove a branch made for this
+		gitOutput(sourceDir, "reset", "--hard", base)
+		gitOutput(sourceDir, "clean", "-fd")
+		gitOutput(sourceDir, "checkout", "--force", original)
+		if created {
+			gitOutput(sourceDir, "branch", "-D", result.Branch)
+		}
+		result.Commit = ""
+	}()
+
+	if err := write(); err != nil {
+		return fail("publish", err)
+	}
+
+	if _, err := gitOutput(sourceDir, "add", "-A"); err != nil {
+		return fail("git add", err)
+	}
+	if status, _ := gitOutput(sourceDir, "status", "--porcelain"); status == "" {
+		return nil // identical to what the source already has
+	}
+	if _, err := gitOutput(sourceDir, "commit", "-m", message); err != nil {
+		return fail("git commit", err)
+	}
+	result.Commit, _ = gitOutput(sourceDir, "rev-parse", "HEAD")
+
+	if push {
+		if _, err := gitOutput(sourceDir, "push", "-u", "origin", result.Branch); err != nil {
+			return fail("git push", err)
+		}
+		result.Pushed = true
+	}
+	return nil
+}
+
+// publishPath returns where an item goes in the source, and the plugin
+// directory whose plugin.json/marketplace entry describes it ("" for the
+// source root)
+func (p *Publisher) publishPath(sourceDir, itemType, itemName, plugin string) (string, string, error) {
+	bare := strings.TrimSuffix(itemName, filepath.Ext(itemName))
+	installer := &Installer{paths: p.paths, registry: p.registry}
+
+	// Already provided by the source: overwrite in place
+	if existing, _, err := installer.resolveItemPaths(sourceDir, itemType+"/"+bare); err == nil {
+		if _, statErr := os.Stat(existing); statErr == nil {
+			return existing, pluginDirOf(sourceDir, existing), nil
+		}
+	}
+
+	// Marketplace repos keep items inside plugin directories
+	marketplacePath := filepath.Join(sourceDir, ".claude-plugin", "marketplace.json")
+	if data, err := os.ReadFile(marketplacePath); err == nil {
+		var marketplace marketplaceJSON
+		if err := json.Unmarshal(data, &marketplace); err == nil && len(marketplace.Plugins) > 0 {
+			var names []string
+			for _, mp := range marketplace.Plugins {
+				names = append(names, mp.Name)
+				if mp.Source == "" || (plugin != "" && mp.Name != plugin) {
+					continue
+				}
+				if plugin != "" || len(marketplace.Plugins) == 1 {
+					pluginDir := filepath.Join(sourceDir, strings.TrimPrefix(mp.Source, "./"))
+					return filepath.Join(pluginItemDir(pluginDir, itemType), itemName), pluginDir, nil
+				}
+			}
+			if plugin != "" {
+				return "", "", fmt.Errorf("plugin not found in marketplace.json: %s", plugin)
+			}
+			return "", "", fmt.Errorf("source has several plugins, choose one with --plugin (%s)", strings.Join(names, ", "))
+		}
+	}
+
+	return filepath.Join(pluginItemDir(sourceDir, itemType), itemName), sourceDir, nil
+}
+
+// pluginItemDir returns the directory holding items of itemType in a plugin
+// (or repo) root: the first directory plugin.json lists for the type, else
+// <root>/<type>
+func pluginItemDir(root, itemType string) string {
+	if manifest, err := readJSONObject(filepath.Join(root, ".claude-plugin", "plugin.json")); err == nil {
+		for _, p := range manifestPaths(manifest[itemType]) {
+			dir := filepath.Join(root, strings.TrimPrefix(p, "./"))
+			if info, err := os.Stat(dir); err == nil && info.IsDir() {
+				return dir
+			}
+		}
+	}
+	return filepath.Join(root, itemType)
+}
+
+// pluginDirOf returns the closest directory above path (up to sourceDir)
+// holding a .claude-plugin/plugin.json, or sourceDir
+func pluginDirOf(sourceDir, path string) string {
+	for dir := filepath.Dir(path); strings.HasPrefix(dir, sourceDir) && dir != sourceDir; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".claude-plugin", "plugin.json")); err == nil {
+			return dir
+		}
+	}
+	return sourceDir
+}
+
+// registerInManifests adds dest to plugin.json (and the plugin's
+// marketplace.json entry) when they enumerate components of itemType as
+// explicit lists that do not already cover it. Returns the updated files,
+// relative to sourceDir.
+func registerInManifests(sourceDir, pluginDir, itemType, dest string) ([]string, error) {
+	if itemType != "skills" && itemType != "commands" && itemType != "agents" {
+		return nil, nil // hooks and rules are not listed by path
+	}
+	var updated []string
+
+	pluginPath := filepath.Join(pluginDir, ".claude-plugin", "plugin.json")
+	if manifest, err := readJSONObject(pluginPath); err == nil {
+		if list, ok := manifest[itemType].([]interface{}); ok && !pathsCover(pluginDir, manifestPaths(list), dest) {
+			manifest[itemType] = append(list, relPath(pluginDir, dest))
+			if err := writeJSONObject(pluginPath, manifest); err != nil {
+				return nil, err
+			}
+			rel, _ := filepath.Rel(sourceDir, pluginPath)
+			updated = append(updated, filepath.ToSlash(rel))
+		}
+	}
+
+	marketplacePath := filepath.Join(sourceDir, ".claude-plugin", "marketplace.json")
+	if marketplace, err := readJSONObject(marketplacePath); err == nil {
+		plugins, _ := marketplace["plugins"].([]interface{})
+		changed := false
+		for _, entry := range plugins {
+			m, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			src, _ := m["source"].(string)
+			if filepath.Join(sourceDir, strings.TrimPrefix(src, "./")) != pluginDir {
+				continue
+			}
+			if list, ok := m[itemType].([]interface{}); ok && !pathsCover(pluginDir, manifestPaths(list), dest) {
+				m[itemType] = append(list, relPath(pluginDir, dest))
+				changed = true
+			}
+		}
+		if changed {
+			if err := writeJSONObject(marketplacePath, marketplace); err != nil {
+				return nil, err
+			}
+			updated = append(updated, ".claude-plugin/marketplace.json")
+		}
+	}
+	return updated, nil
+}
+
+// manifestPaths normalizes a plugin.json component field (string or list)
+func manifestPaths(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		var out []string
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// pathsCover reports whether dest is one of paths or inside one of them
+func pathsCover(root string, paths []string, dest string) bool {
+	for _, p := range paths {
+		full := filepath.Join(root, strings.TrimPrefix(p, "./"))
+		if full == dest || strings.HasPrefix(dest, full+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func relPath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return "./" + filepath.ToSlash(rel)
+}
+
+// replaceTree copies src over dst, leaving out ccp's source.yaml. With
+// overlay set (the destination is the repository root of a bare skill repo)
+// dst is updated in place instead of being replaced, so .git survives.
+func replaceTree(src, dst string, overlay bool) error {
+	if !overlay {
+		if err := os.RemoveAll(dst); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := CopyTree(src, dst); err != nil {
+		return err
+	}
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		os.Remove(filepath.Join(dst, "source.yaml"))
+	}
+	return nil
+}
+
+func readJSONObject(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return obj, nil
+}
+
+func writeJSONObject(path string, obj map[string]interface{}) error {
+	return writeJSONFile(path, obj)
+}
+
+// gitOutput runs git in dir and returns its trimmed stdout; failures carry
+// git's stderr
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return "", fmt.Errorf("%w: %s", err, detail)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,189 @@
+package source
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// setupPublishSource creates a git checkout for source "me/tools" containing
+// the given files, and returns paths plus a registry that knows the source.
+func setupPublishSource(t *testing.T, files map[string]string) (*config.Paths, *Registry, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	ccpDir := t.TempDir()
+	paths := &config.Paths{CcpDir: ccpDir, HubDir: filepath.Join(ccpDir, "hub")}
+	registry := NewRegistry(ccpDir)
+	if err := registry.AddSource("me/tools", Source{Provider: "git", URL: "https://github.com/me/tools"}); err != nil {
+		t.Fatal(err)
+	}
+
+	sourceDir := paths.SourceDir("me/tools")
+	for name, content := range files {
+		createTestFile(t, sourceDir, name, content)
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"config", "user.email", "dev@example.com"},
+		{"config", "user.name", "Dev"},
+		{"add", "-A"},
+		{"commit", "-q", "-m", "initial"},
+	} {
+		if _, err := gitOutput(sourceDir, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	return paths, registry, sourceDir
+}
+
+func TestPublish_NewSkillIsDiscoverable(t *testing.T) {
+	paths, registry, sourceDir := setupPublishSource(t, map[string]string{
+		"skills/existing/SKILL.md": "# existing",
+	})
+
+	hubSkill := filepath.Join(paths.HubDir, "skills", "fresh")
+	createTestFile(t, hubSkill, "SKILL.md", "# fresh")
+	createTestFile(t, hubSkill, "source.yaml", "type: local\n")
+
+	result, err := NewPublisher(paths, registry).Publish("me/tools", "skills/fresh", hubSkill, PublishOptions{})
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if result.Dest != "skills/fresh" || result.Commit == "" || result.Branch != "ccp/publish-skills-fresh" {
+		t.Fatalf("result = %+v", result)
+	}
+
+	// The checkout is back on main; the item lives on the publish branch
+	if branch, _ := gitOutput(sourceDir, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+		t.Errorf("checkout left on %q, want main", branch)
+	}
+	if _, err := gitOutput(sourceDir, "checkout", "-q", result.Branch); err != nil {
+		t.Fatal(err)
+	}
+	items := NewInstaller(paths, registry).DiscoverItems(sourceDir)
+	if !slices.Contains(items, "skills/fresh") {
+		t.Errorf("DiscoverItems = %v, want skills/fresh", items)
+	}
+	if _, err := os.Stat(filepath.Join(sourceDir, "skills", "fresh", "source.yaml")); !os.IsNotExist(err) {
+		t.Error("source.yaml should not be published")
+	}
+	if author, _ := gitOutput(sourceDir, "log", "-1", "--format=%ae"); author != "dev@example.com" {
+		t.Errorf("commit author = %q", author)
+	}
+}
+
+func TestPublish_OverwritesExistingAndUpdatesPluginJSON(t *testing.T) {
+	paths, registry, sourceDir := setupPublishSource(t, map[string]string{
+		".claude-plugin/plugin.json": `{"name": "tools", "agents": ["./agents/reviewer.md"]}`,
+		"agents/reviewer.md":         "# reviewer v1",
+	})
+	hubDir := filepath.Join(paths.HubDir, "agents")
+	createTestFile(t, hubDir, "reviewer.md", "# reviewer v2")
+	createTestFile(t, hubDir, "planner.md", "# planner")
+
+	publisher := NewPublisher(paths, registry)
+	result, err := publisher.Publish("me/tools", "agents/reviewer.md", filepath.Join(hubDir, "reviewer.md"), PublishOptions{Branch: "improve"})
+	if err != nil {
+		t.Fatalf("Publish reviewer: %v", err)
+	}
+	if result.Dest != "agents/reviewer.md" || len(result.Manifests) != 0 {
+		t.Errorf("reviewer result = %+v", result)
+	}
+
+	result, err = publisher.Publish("me/tools", "agents/planner.md", filepath.Join(hubDir, "planner.md"), PublishOptions{Branch: "improve"})
+	if err != nil {
+		t.Fatalf("Publish planner: %v", err)
+	}
+	if len(result.Manifests) != 1 || result.Manifests[0] != ".claude-plugin/plugin.json" {
+		t.Errorf("planner manifests = %v", result.Manifests)
+	}
+
+	gitOutput(sourceDir, "checkout", "-q", "improve")
+	data, _ := os.ReadFile(filepath.Join(sourceDir, "agents", "reviewer.md"))
+	if string(data) != "# reviewer v2" {
+		t.Errorf("reviewer not overwritten: %q", data)
+	}
+	var plugin struct {
+		Agents []string `json:"agents"`
+	}
+	data, _ = os.ReadFile(filepath.Join(sourceDir, ".claude-plugin", "plugin.json"))
+	json.Unmarshal(data, &plugin)
+	if !slices.Equal(plugin.Agents, []string{"./agents/reviewer.md", "./agents/planner.md"}) {
+		t.Errorf("plugin.json agents = %v", plugin.Agents)
+	}
+	// Both publishes stacked on the same branch
+	if count, _ := gitOutput(sourceDir, "rev-list", "--count", "HEAD"); count != "3" {
+		t.Errorf("commits on branch = %s, want 3", count)
+	}
+}
+
+func TestPublishBundle_AddsMarketplacePlugin(t *testing.T) {
+	paths, registry, sourceDir := setupPublishSource(t, map[string]string{
+		".claude-plugin/marketplace.json": `{"name": "tools", "plugins": []}`,
+	})
+	bundleDir := filepath.Join(paths.HubDir, "bundles", "design")
+	createTestFile(t, bundleDir, "bundle.yaml", "name: design\n")
+	createTestFile(t, bundleDir, "skills/ui/SKILL.md", "# ui")
+
+	result, err := NewPublisher(paths, registry).PublishBundle("me/tools", "design", "Design kit", "1.2.0", bundleDir, PublishOptions{})
+	if err != nil {
+		t.Fatalf("PublishBundle: %v", err)
+	}
+	if result.Dest != "plugins/design" {
+		t.Errorf("dest = %s", result.Dest)
+	}
+
+	gitOutput(sourceDir, "checkout", "-q", result.Branch)
+	items := NewInstaller(paths, registry).DiscoverItems(sourceDir)
+	if !slices.Contains(items, "skills/ui") {
+		t.Errorf("DiscoverItems = %v, want skills/ui via marketplace", items)
+	}
+	data, _ := os.ReadFile(filepath.Join(sourceDir, "plugins", "design", ".claude-plugin", "plugin.json"))
+	var plugin map[string]interface{}
+	json.Unmarshal(data, &plugin)
+	if plugin["version"] != "1.2.0" || plugin["description"] != "Design kit" {
+		t.Errorf("plugin.json = %v", plugin)
+	}
+}
+
+func TestPublish_RefusesDirtyCheckout(t *testing.T) {
+	paths, registry, sourceDir := setupPublishSource(t, map[string]string{"skills/a/SKILL.md": "# a"})
+	createTestFile(t, sourceDir, "skills/a/SKILL.md", "# local edit")
+	hubSkill := filepath.Join(paths.HubDir, "skills", "a")
+	createTestFile(t, hubSkill, "SKILL.md", "# a v2")
+
+	if _, err := NewPublisher(paths, registry).Publish("me/tools", "skills/a", hubSkill, PublishOptions{}); err == nil {
+		t.Fatal("expected error for a checkout with uncommitted changes")
+	}
+}
+
+func TestPublish_FailedCommitRestoresCheckout(t *testing.T) {
+	paths, registry, sourceDir := setupPublishSource(t, map[string]string{"skills/a/SKILL.md": "# a"})
+	createTestFile(t, sourceDir, ".git/hooks/pre-commit", "#!/bin/sh\nexit 1\n")
+	hook := filepath.Join(sourceDir, ".git", "hooks", "pre-commit")
+	if err := os.Chmod(hook, 0755); err != nil {
+		t.Fatal(err)
+	}
+	hubSkill := filepath.Join(paths.HubDir, "skills", "b")
+	createTestFile(t, hubSkill, "SKILL.md", "# b")
+
+	if _, err := NewPublisher(paths, registry).Publish("me/tools", "skills/b", hubSkill, PublishOptions{}); err == nil {
+		t.Fatal("expected error when git commit fails")
+	}
+	if status, _ := gitOutput(sourceDir, "status", "--porcelain"); status != "" {
+		t.Errorf("checkout left dirty:\n%s", status)
+	}
+	if branch, _ := gitOutput(sourceDir, "rev-parse", "--abbrev-ref", "HEAD"); branch != "main" {
+		t.Errorf("branch = %s, want main", branch)
+	}
+	if _, err := gitOutput(sourceDir, "rev-parse", "--verify", "--quiet", "refs/heads/ccp/publish-skills-b"); err == nil {
+		t.Error("publish branch should be deleted")
+	}
+}
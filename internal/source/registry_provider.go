@@ -34,14 +34,14 @@ func RegisterRegistryProvider(r RegistryProvider) {
 
 // GetRegistryProvider returns a registry by name
 func GetRegistryProvider(name string) RegistryProvider {
-	return registryProviders[name]
+	return withCache(registryProviders[name])
 }
 
 // DetectRegistry auto-selects registry based on identifier
 func DetectRegistry(identifier string) RegistryProvider {
 	for _, r := range registryProviders {
 		if r.CanHandle(identifier) {
-			return r
+			return withCache(r)
 		}
 	}
 	return nil
@@ -49,14 +49,64 @@ func DetectRegistry(identifier string) RegistryProvider {
 
 // DefaultRegistry returns the default registry (skills.sh)
 func DefaultRegistry() RegistryProvider {
-	return registryProviders["skills.sh"]
+	return withCache(registryProviders["skills.sh"])
 }
 
 // AllRegistries returns all registered registries
 func AllRegistries() []RegistryProvider {
 	result := make([]RegistryProvider, 0, len(registryProviders))
 	for _, r := range registryProviders {
-		result = append(result, r)
+		result = append(result, withCache(r))
 	}
 	return result
 }
+
+// cachedRegistry records searches and lookups in the active cache and, in
+// offline mode, answers them from it
+type cachedRegistry struct {
+	RegistryProvider
+	cache *Cache
+}
+
+// withCache wraps r when a cache is active
+func withCache(r RegistryProvider) RegistryProvider {
+	if r == nil || activeCache == nil {
+		return r
+	}
+	return &cachedRegistry{RegistryProvider: r, cache: activeCache}
+}
+
+func (r *cachedRegistry) Search(ctx context.Context, query string, opts SearchOptions) ([]PackageInfo, error) {
+	if r.cache.Offline() {
+		if results, ok := r.cache.LoadSearch(r.Name(), query, opts.Limit); ok {
+			return results, nil
+		}
+		return r.cache.SearchPackages(query, opts.Limit)
+	}
+	results, err := r.RegistryProvider.Search(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.SaveSearch(r.Name(), query, opts.Limit, results)
+	return results, nil
+}
+
+func (r *cachedRegistry) Get(ctx context.Context, packageID string) (*PackageDetails, error) {
+	id, ref := cachePackageID(packageID)
+	if r.cache.Offline() {
+		details, err := r.cache.LoadPackage(r.Name(), id)
+		if err != nil {
+			return nil, err
+		}
+		if ref != "" {
+			details.Ref = ref
+		}
+		return details, nil
+	}
+	details, err := r.RegistryProvider.Get(ctx, packageID)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.SavePackage(r.Name(), id, details)
+	return details, nil
+}
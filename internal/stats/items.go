@@ -0,0 +1,88 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+// DefaultUnusedAfter is how long an item may stay linked without being
+// invoked before it is reported as unused
+const DefaultUnusedAfter = 14 * 24 * time.Hour
+
+// ItemUsage is the usage of one hub item
+type ItemUsage struct {
+	Type     config.HubItemType `json:"type"`
+	Name     string             `json:"name"`
+	Count    int                `json:"count"`
+	LastUsed time.Time          `json:"last_used,omitempty"`
+	LinkedAt time.Time          `json:"linked_at,omitempty"`
+}
+
+// Key returns the "type/name" key of the item
+func (u ItemUsage) Key() string {
+	return string(u.Type) + "/" + u.Name
+}
+
+// Unused reports whether the item has been linked for at least minAge
+// without a single recorded invocation
+func (u ItemUsage) Unused(minAge time.Duration, now time.Time) bool {
+	return u.Count == 0 && !u.LinkedAt.IsZero() && now.Sub(u.LinkedAt) >= minAge
+}
+
+// Trackable reports whether invocations of an item type show up in
+// transcripts. Hooks, rules and settings templates are applied implicitly.
+func Trackable(itemType config.HubItemType) bool {
+	_, ok := invocationKind(itemType)
+	return ok
+}
+
+func invocationKind(itemType config.HubItemType) (Kind, bool) {
+	switch itemType {
+	case config.HubSkills:
+		return KindSkill, true
+	case config.HubAgents:
+		return KindAgent, true
+	case config.HubCommands:
+		return KindCommand, true
+	}
+	return "", false
+}
+
+// InvocationName returns the name Claude Code uses to invoke a hub item:
+// skills by directory name, agents and commands by file name without the
+// extension, with nested command directories joined by ':'
+func InvocationName(itemType config.HubItemType, itemName string) string {
+	name := strings.TrimSuffix(itemName, filepath.Ext(itemName))
+	if itemType == config.HubCommands {
+		name = strings.ReplaceAll(filepath.ToSlash(name), "/", ":")
+	}
+	return name
+}
+
+// ForItem returns the usage of a hub item. linkedAt may be zero when unknown.
+func (r *Report) ForItem(itemType config.HubItemType, itemName string, linkedAt time.Time) ItemUsage {
+	iu := ItemUsage{Type: itemType, Name: itemName, LinkedAt: linkedAt}
+	if kind, ok := invocationKind(itemType); ok {
+		u := r.Get(kind, InvocationName(itemType, itemName))
+		iu.Count, iu.LastUsed = u.Count, u.LastUsed
+	}
+	return iu
+}
+
+// LinkTime returns when a hub item was linked into a profile, taken from the
+// modification time of the profile's symlink. Zero when not linked.
+func LinkTime(profileDir string, itemType config.HubItemType, itemName string) time.Time {
+	linkName := itemName
+	if itemType == config.HubRules {
+		linkName = filepath.Base(itemName)
+	}
+	info, err := os.Lstat(filepath.Join(profileDir, string(itemType), linkName))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,263 @@
+// Package stats derives hub item usage from Claude Code session transcripts
+// (the JSONL files under the projects/ data dir).
+package stats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Kind is the kind of invocation recorded in a transcript
+type Kind string
+
+const (
+	KindSkill   Kind = "skill"
+	KindAgent   Kind = "agent"
+	KindCommand Kind = "command"
+	KindTool    Kind = "tool"
+)
+
+// maxLineSize bounds a single transcript line; tool results can be large.
+// Longer lines are skipped and counted in Report.SkippedLines.
+const maxLineSize = 256 * 1024 * 1024
+
+// Usage is how often and when something was last invoked
+type Usage struct {
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"last_used,omitempty"`
+}
+
+// Report aggregates invocations across transcripts
+type Report struct {
+	Sessions     int                        `json:"sessions"`
+	Usage        map[Kind]map[string]*Usage `json:"usage"`
+	First        time.Time                  `json:"first,omitempty"` // oldest event seen
+	Last         time.Time                  `json:"last,omitempty"`  // newest event seen
+	SkippedLines int                        `json:"skipped_lines"`   // lines over maxLineSize`
+}
+
+// NewReport creates an empty report
+func NewReport() *Report {
+	return &Report{
+		Usage: map[Kind]map[string]*Usage{
+			KindSkill:   {},
+			KindAgent:   {},
+			KindCommand: {},
+			KindTool:    {},
+		},
+	}
+}
+
+// Get returns the usage of a named skill, agent, command or tool. The name
+// must match exactly: a plugin namespaced invocation ("plugin:name") is a
+// different item than "name".
+func (r *Report) Get(kind Kind, name string) Usage {
+	if u, ok := r.Usage[kind][name]; ok {
+		return *u
+	}
+	return Usage{}
+}
+
+// record counts one invocation
+func (r *Report) record(kind Kind, name string, at time.Time) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	u, ok := r.Usage[kind][name]
+	if !ok {
+		u = &Usage{}
+		r.Usage[kind][name] = u
+	}
+	u.Count++
+	if at.After(u.LastUsed) {
+		u.LastUsed = at
+	}
+	if !at.IsZero() {
+		if r.First.IsZero() || at.Before(r.First) {
+			r.First = at
+		}
+		if at.After(r.Last) {
+			r.Last = at
+		}
+	}
+}
+
+// ScanDirs parses every *.jsonl transcript below the given directories.
+// Missing directories are skipped; unreadable files are skipped too, since
+// Claude Code may be writing to them concurrently.
+func ScanDirs(dirs ...string) (*Report, error) {
+	r := NewReport()
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		resolved, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if seen[resolved] {
+			continue
+		}
+		seen[resolved] = true
+
+		err = filepath.WalkDir(resolved, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Ext(path) != ".jsonl" {
+				return nil
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return nil
+			}
+			defer f.Close()
+			r.Sessions++
+			return r.ParseTranscript(f)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// transcriptLine is the subset of a transcript entry we read
+type transcriptLine struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   struct {
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+type contentBlock struct {
+	Type  string          `json:"type"`
+	Name  string          `json:"name"`
+	Text  string          `json:"text"`
+	Input json.RawMessage `json:"input"`
+}
+
+type toolInput struct {
+	Skill        string `json:"skill"`
+	Command      string `json:"command"`
+	SubagentType string `json:"subagent_type"`
+}
+
+var commandNameRe = regexp.MustCompile(`<command-name>/?([^<\s]+)</command-name>`)
+
+// ParseTranscript reads one JSONL transcript and records its invocations:
+// every tool_use block counts as a tool call, Skill/Task/SlashCommand tool
+// calls are attributed to skills, agents and commands, and user-typed slash
+// commands are read from their <command-name> tag. Malformed lines are
+// ignored; lines over maxLineSize are skipped and counted.
+func (r *Report) ParseTranscript(rd io.Reader) error {
+	br := bufio.NewReaderSize(rd, 1024*1024)
+	for {
+		line, tooLong, err := readLine(br, maxLineSize)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if tooLong {
+			r.SkippedLines++
+			continue
+		}
+		// Cheap pre-filter: most lines are plain text or tool results
+		if !bytes.Contains(line, []byte(`"tool_use"`)) && !bytes.Contains(line, []byte("command-name>")) {
+			continue
+		}
+		var entry transcriptLine
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		r.parseContent(entry.Message.Content, entry.Timestamp)
+	}
+}
+
+// readLine returns the next line without its line ending. A line longer than
+// max is read to its end but not kept, and reported with tooLong.
+func readLine(br *bufio.Reader, max int) (line []byte, tooLong bool, err error) {
+	for {
+		chunk, isPrefix, err := br.ReadLine()
+		if err != nil {
+			if err == io.EOF && (line != nil || tooLong) {
+				return line, tooLong, nil
+			}
+			return nil, false, err
+		}
+		if !tooLong {
+			if len(line)+len(chunk) > max {
+				tooLong, line = true, nil
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+		if !isPrefix {
+			if line == nil {
+				line = []byte{}
+			}
+			return line, tooLong, nil
+		}
+	}
+}
+
+func (r *Report) parseContent(raw json.RawMessage, at time.Time) {
+	if len(raw) == 0 {
+		return
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		r.recordCommandTags(text, at)
+		return
+	}
+	var blocks []contentBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return
+	}
+	for _, block := range blocks {
+		switch block.Type {
+		case "text":
+			r.recordCommandTags(block.Text, at)
+		case "tool_use":
+			r.recordToolUse(block, at)
+		}
+	}
+}
+
+func (r *Report) recordCommandTags(text string, at time.Time) {
+	for _, m := range commandNameRe.FindAllStringSubmatch(text, -1) {
+		r.record(KindCommand, m[1], at)
+	}
+}
+
+func (r *Report) recordToolUse(block contentBlock, at time.Time) {
+	r.record(KindTool, block.Name, at)
+
+	var in toolInput
+	if len(block.Input) > 0 {
+		json.Unmarshal(block.Input, &in)
+	}
+	switch block.Name {
+	case "Skill":
+		name := in.Skill
+		if name == "" {
+			name = in.Command
+		}
+		r.record(KindSkill, name, at)
+	case "Task", "Agent":
+		r.record(KindAgent, in.SubagentType, at)
+	case "SlashCommand":
+		if fields := strings.Fields(in.Command); len(fields) > 0 {
+			r.record(KindCommand, strings.TrimPrefix(fields[0], "/"), at)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samhoang/ccp/internal/config"
+)
+
+const sampleTranscript = `{"type":"user","timestamp":"2026-10-01T10:00:00Z","message":{"role":"user","content":"<command-message>deploy is running</command-message>\n<command-name>/deploy</command-name>"}}
+{"type":"assistant","timestamp":"2026-10-01T10:01:00Z","message":{"role":"assistant","content":[{"type":"text","text":"Loading skill"},{"type":"tool_use","id":"t1","name":"Skill","input":{"skill":"terraform"}}]}}
+{"type":"assistant","timestamp":"2026-10-02T09:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t2","name":"Task","input":{"subagent_type":"reviewer","prompt":"review"}},{"type":"tool_use","id":"t3","name":"Bash","input":{"command":"ls"}}]}}
+{"type":"assistant","timestamp":"2026-10-03T09:00:00Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t4","name":"Skill","input":{"command":"infra:terraform"}},{"type":"tool_use","id":"t5","name":"SlashCommand","input":{"command":"/frontend:component Button"}}]}}
+{"type":"user","timestamp":"2026-10-03T09:00:01Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t4","content":"ok"}]}}
+not json at all
+`
+
+func TestParseTranscript(t *testing.T) {
+	r := NewReport()
+	if err := r.ParseTranscript(strings.NewReader(sampleTranscript)); err != nil {
+		t.Fatalf("ParseTranscript: %v", err)
+	}
+
+	skill := r.Get(KindSkill, "terraform")
+	if skill.Count != 1 {
+		t.Errorf("terraform count = %d, want 1 (plugin-namespaced use counted apart)", skill.Count)
+	}
+	if want := time.Date(2026, 10, 1, 10, 1, 0, 0, time.UTC); !skill.LastUsed.Equal(want) {
+		t.Errorf("terraform last used = %v, want %v", skill.LastUsed, want)
+	}
+	if got := r.Get(KindSkill, "infra:terraform").Count; got != 1 {
+		t.Errorf("infra:terraform count = %d, want 1", got)
+	}
+	if got := r.Get(KindAgent, "reviewer").Count; got != 1 {
+		t.Errorf("reviewer count = %d, want 1", got)
+	}
+	if got := r.Get(KindCommand, "deploy").Count; got != 1 {
+		t.Errorf("deploy count = %d, want 1", got)
+	}
+	if got := r.Get(KindCommand, "frontend:component").Count; got != 1 {
+		t.Errorf("frontend:component count = %d, want 1", got)
+	}
+	if got := r.Get(KindTool, "Bash").Count; got != 1 {
+		t.Errorf("Bash count = %d, want 1", got)
+	}
+	if got := r.Get(KindTool, "Skill").Count; got != 2 {
+		t.Errorf("Skill tool count = %d, want 2", got)
+	}
+}
+
+func TestParseTranscriptSkipsLongLines(t *testing.T) {
+	long := `{"type":"user","message":{"content":"` + strings.Repeat("x", 2*1024*1024) + `"}}`
+	skill := `{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Skill","input":{"skill":"terraform"}}]}}`
+
+	r := NewReport()
+	br := bufio.NewReader(strings.NewReader(long + "\n" + skill + "\n" + long))
+	for {
+		line, tooLong, err := readLine(br, 1024*1024)
+		if err != nil {
+			break
+		}
+		if tooLong {
+			r.SkippedLines++
+			continue
+		}
+		var entry transcriptLine
+		if json.Unmarshal(line, &entry) == nil {
+			r.parseContent(entry.Message.Content, entry.Timestamp)
+		}
+	}
+	if r.SkippedLines != 2 {
+		t.Errorf("skipped lines = %d, want 2", r.SkippedLines)
+	}
+	if got := r.Get(KindSkill, "terraform").Count; got != 1 {
+		t.Errorf("line after a long one not parsed: terraform count = %d", got)
+	}
+
+	// Lines within the limit are parsed however the reader splits them
+	r = NewReport()
+	if err := r.ParseTranscript(strings.NewReader(long + "\n" + skill + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	if r.SkippedLines != 0 || r.Get(KindSkill, "terraform").Count != 1 {
+		t.Errorf("report = %+v", r)
+	}
+}
+
+func TestForItem(t *testing.T) {
+	r := NewReport()
+	r.ParseTranscript(strings.NewReader(sampleTranscript))
+
+	cases := []struct {
+		itemType config.HubItemType
+		name     string
+		want     int
+	}{
+		{config.HubSkills, "terraform", 1},
+		{config.HubAgents, "reviewer.md", 1},
+		{config.HubCommands, "deploy.md", 1},
+		{config.HubCommands, "frontend/component.md", 1},
+		{config.HubSkills, "unused", 0},
+		{config.HubRules, "style.md", 0},
+	}
+	for _, tc := range cases {
+		if got := r.ForItem(tc.itemType, tc.name, time.Time{}).Count; got != tc.want {
+			t.Errorf("ForItem(%s, %s) = %d, want %d", tc.itemType, tc.name, got, tc.want)
+		}
+	}
+	if Trackable(config.HubHooks) || !Trackable(config.HubCommands) {
+		t.Error("only skills, agents and commands are trackable")
+	}
+}
+
+func TestUnused(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-30 * 24 * time.Hour)
+	recent := now.Add(-2 * 24 * time.Hour)
+
+	if !(ItemUsage{LinkedAt: old}).Unused(DefaultUnusedAfter, now) {
+		t.Error("item linked 30 days ago without use should be unused")
+	}
+	if (ItemUsage{LinkedAt: recent}).Unused(DefaultUnusedAfter, now) {
+		t.Error("recently linked item should not be flagged")
+	}
+	if (ItemUsage{LinkedAt: old, Count: 1}).Unused(DefaultUnusedAfter, now) {
+		t.Error("used item should not be flagged")
+	}
+	if (ItemUsage{}).Unused(DefaultUnusedAfter, now) {
+		t.Error("item with unknown link time should not be flagged")
+	}
+}
+
+func TestScanDirsDeduplicatesSymlinks(t *testing.T) {
+	root := t.TempDir()
+	shared := filepath.Join(root, "shared", "projects")
+	projectDir := filepath.Join(shared, "-home-me-repo")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "session.jsonl"), []byte(sampleTranscript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	profileDir := filepath.Join(root, "dev")
+	os.MkdirAll(profileDir, 0755)
+	if err := os.Symlink(shared, filepath.Join(profileDir, "projects")); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ScanDirs(shared, filepath.Join(profileDir, "projects"), filepath.Join(root, "missing"))
+	if err != nil {
+		t.Fatalf("ScanDirs: %v", err)
+	}
+	if r.Sessions != 1 {
+		t.Errorf("sessions = %d, want 1", r.Sessions)
+	}
+	if got := r.Get(KindSkill, "terraform").Count; got != 1 {
+		t.Errorf("terraform count = %d, want 1", got)
+	}
+}
+
+func TestLinkTime(t *testing.T) {
+	profileDir := t.TempDir()
+	os.MkdirAll(filepath.Join(profileDir, "skills"), 0755)
+	if err := os.Symlink("/nonexistent/target", filepath.Join(profileDir, "skills", "broken")); err != nil {
+		t.Fatal(err)
+	}
+	if LinkTime(profileDir, config.HubSkills, "broken").IsZero() {
+		t.Error("expected link time from the symlink itself, even when dangling")
+	}
+	if !LinkTime(profileDir, config.HubSkills, "absent").IsZero() {
+		t.Error("expected zero link time for unlinked item")
+	}
+}